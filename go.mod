@@ -8,7 +8,9 @@ require (
 	github.com/qdrant/go-client v1.12.0
 	github.com/sashabaranov/go-openai v1.35.7
 	github.com/spf13/cobra v1.8.1
+	golang.org/x/sync v0.8.0
 	google.golang.org/genai v0.5.0
+	google.golang.org/grpc v1.67.1
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -36,6 +38,5 @@ require (
 	golang.org/x/term v0.24.0 // indirect
 	golang.org/x/text v0.18.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240903143218-8af14fe29dc1 // indirect
-	google.golang.org/grpc v1.67.1 // indirect
 	google.golang.org/protobuf v1.34.2 // indirect
 )
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/spf13/cobra"
+)
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Embedding cache management commands",
+	}
+
+	cmd.AddCommand(newCacheClearCmd())
+	return cmd
+}
+
+func newCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached embeddings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if cfg.Embedding.CacheDir == "" {
+				fmt.Println("Embedding cache is not configured (embedding.cache_dir)")
+				return nil
+			}
+
+			cache, err := embedding.NewDiskCache(cfg.Embedding.CacheDir)
+			if err != nil {
+				return fmt.Errorf("failed to open cache: %w", err)
+			}
+
+			removed, err := cache.Clear()
+			if err != nil {
+				return fmt.Errorf("failed to clear cache: %w", err)
+			}
+
+			fmt.Printf("Removed %d cached embeddings from %s\n", removed, cfg.Embedding.CacheDir)
+			return nil
+		},
+	}
+}
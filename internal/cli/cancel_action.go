@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newCancelActionCmd() *cobra.Command {
+	var (
+		repo  string
+		issue int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cancel-action",
+		Short: "Cancel the pending transfer, close, or drafted comment on an issue",
+		Long: `Cancels a scheduled delayed action on a single issue without waiting for a reaction.
+The pending label is removed and a cancellation comment is posted on the issue.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			org, name, err := github.ParseRepo(repo)
+			if err != nil {
+				return err
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			target, err := gh.GetIssue(ctx, org, name, issue)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %w", err)
+			}
+
+			pendingMgr := pending.NewManager(gh, cfg)
+			action, err := pendingMgr.GetPendingAction(ctx, target)
+			if err != nil {
+				return fmt.Errorf("failed to find pending action: %w", err)
+			}
+			if action == nil {
+				fmt.Printf("No pending action on %s#%d\n", repo, issue)
+				return nil
+			}
+
+			if dryRun {
+				fmt.Printf("[dry-run] Would cancel pending %s on %s#%d\n", action.Type, repo, issue)
+				return nil
+			}
+
+			if err := pendingMgr.Cancel(ctx, action); err != nil {
+				return fmt.Errorf("failed to cancel pending action: %w", err)
+			}
+
+			notice := pending.FormatCancelledComment(action, style.ForRepo(cfg, action.Org, action.Repo))
+			if err := gh.PostComment(ctx, org, name, issue, notice); err != nil {
+				return fmt.Errorf("failed to post cancellation comment: %w", err)
+			}
+
+			fmt.Printf("Cancelled pending %s on %s#%d\n", action.Type, repo, issue)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository of the issue (owner/repo)")
+	cmd.Flags().IntVar(&issue, "issue", 0, "issue number")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("issue")
+
+	return cmd
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func newConfigCmd() *cobra.Command {
@@ -14,9 +15,39 @@ func newConfigCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigPrintCmd())
 	return cmd
 }
 
+func newConfigPrintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration",
+		Long: `Prints the configuration the tool actually runs with: defaults applied and
+environment variables expanded. API keys are masked as ***.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			out, err := yaml.Marshal(cfg.Redacted())
+			if err != nil {
+				return fmt.Errorf("failed to marshal config: %w", err)
+			}
+
+			fmt.Printf("# Effective configuration from %s\n", cfgPath)
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+}
+
 func newConfigValidateCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "validate",
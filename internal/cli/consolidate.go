@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+func newConsolidateCmd() *cobra.Command {
+	var (
+		repo     string
+		issues   []int
+		title    string
+		labels   []string
+		backlink bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "consolidate",
+		Short: "Open a tracking issue linking a cluster of related issues",
+		Long: `Opens a new issue that lists the given issues so a cluster of duplicates or
+closely related reports can be followed in one place. With --backlink, each
+listed issue also gets a comment pointing at the tracking issue.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			org, name, err := github.ParseRepo(repo)
+			if err != nil {
+				return err
+			}
+			issues = uniqueIssueNumbers(issues)
+			if len(issues) < 2 {
+				return fmt.Errorf("--issues needs at least two distinct issue numbers")
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			cluster := make([]*models.Issue, 0, len(issues))
+			for _, number := range issues {
+				issue, err := gh.GetIssue(ctx, org, name, number)
+				if err != nil {
+					return fmt.Errorf("failed to get issue #%d: %w", number, err)
+				}
+				cluster = append(cluster, issue)
+			}
+
+			if title == "" {
+				title = "Tracking: " + cluster[0].Title
+			}
+			body := formatTrackingBody(cluster)
+
+			if dryRun {
+				fmt.Printf("[dry-run] Would open %q on %s linking %d issue(s):\n\n%s\n", title, repo, len(cluster), body)
+				return nil
+			}
+
+			return openTrackingIssue(ctx, gh, org, name, title, body, labels, cluster, backlink, style.ForDefaults(&cfg.Defaults))
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository of the issues (owner/repo)")
+	cmd.Flags().IntSliceVar(&issues, "issues", nil, "issue numbers to consolidate (comma-separated)")
+	cmd.Flags().StringVar(&title, "title", "", "tracking issue title (default: \"Tracking: \" + first issue's title)")
+	cmd.Flags().StringSliceVar(&labels, "label", nil, "label to apply to the tracking issue (repeatable)")
+	cmd.Flags().BoolVar(&backlink, "backlink", false, "comment on each listed issue with a link to the tracking issue")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("issues")
+
+	return cmd
+}
+
+// formatTrackingBody lists the cluster as a task list so GitHub renders each
+// entry with its live title and state
+func formatTrackingBody(cluster []*models.Issue) string {
+	var sb strings.Builder
+	sb.WriteString("This issue consolidates the following related reports:\n\n")
+	for _, issue := range cluster {
+		fmt.Fprintf(&sb, "- [ ] #%d\n", issue.Number)
+	}
+	return sb.String()
+}
+
+// openTrackingIssue creates the tracking issue and, with backlink, comments
+// on each issue in the cluster with a link to it
+func openTrackingIssue(ctx context.Context, gh *github.Client, org, repo, title, body string, labels []string, cluster []*models.Issue, backlink bool, s style.Style) error {
+	tracking, err := gh.CreateIssue(ctx, org, repo, title, body, labels)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Opened tracking issue %s/%s#%d: %s\n", org, repo, tracking.Number, tracking.URL)
+
+	if !backlink {
+		return nil
+	}
+
+	notice := fmt.Sprintf("%sThis issue is being tracked together with related reports in #%d.\n\n%s",
+		s.Icon("🔗"), tracking.Number, s.Footer("Simili"))
+	var failed int
+	for _, issue := range cluster {
+		if err := gh.PostComment(ctx, org, repo, issue.Number, notice); err != nil {
+			fmt.Printf("Failed to comment on #%d: %v\n", issue.Number, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to back-link %d of %d issue(s)", failed, len(cluster))
+	}
+	fmt.Printf("Back-linked %d issue(s)\n", len(cluster))
+	return nil
+}
+
+// uniqueIssueNumbers drops repeated numbers, keeping the first occurrence
+func uniqueIssueNumbers(numbers []int) []int {
+	seen := make(map[int]bool, len(numbers))
+	unique := make([]int, 0, len(numbers))
+	for _, n := range numbers {
+		if !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
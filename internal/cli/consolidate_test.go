@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestUniqueIssueNumbers(t *testing.T) {
+	if got, want := uniqueIssueNumbers([]int{4, 7, 4, 9, 7}), []int{4, 7, 9}; !slices.Equal(got, want) {
+		t.Errorf("uniqueIssueNumbers() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatTrackingBody(t *testing.T) {
+	body := formatTrackingBody([]*models.Issue{{Number: 4}, {Number: 9}})
+
+	want := "This issue consolidates the following related reports:\n\n- [ ] #4\n- [ ] #9\n"
+	if body != want {
+		t.Errorf("formatTrackingBody() = %q, want %q", body, want)
+	}
+}
+
+// issueAPI fakes the issue endpoints consolidate uses: creating an issue
+// returns #50, and every request is recorded with its JSON body
+type issueAPI struct {
+	requests []string
+	bodies   []map[string]any
+}
+
+func (a *issueAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]any
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+	}
+	a.requests = append(a.requests, req.Method+" "+req.URL.Path)
+	a.bodies = append(a.bodies, body)
+
+	resp := "{}"
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/issues") {
+		resp = `{"number": 50, "html_url": "https://github.com/octo/app/issues/50"}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(resp)),
+		Request:    req,
+	}, nil
+}
+
+func TestOpenTrackingIssue(t *testing.T) {
+	cluster := []*models.Issue{{Number: 4}, {Number: 9}}
+	body := formatTrackingBody(cluster)
+
+	for _, backlink := range []bool{false, true} {
+		api := &issueAPI{}
+		gh, err := github.NewClientWithTransport("test", api)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = openTrackingIssue(context.Background(), gh, "octo", "app", "Tracking: crash", body, []string{"tracking"}, cluster, backlink, style.New(style.Plain))
+		if err != nil {
+			t.Fatalf("openTrackingIssue(backlink=%v) error = %v", backlink, err)
+		}
+
+		want := []string{"POST /repos/octo/app/issues"}
+		if backlink {
+			want = append(want, "POST /repos/octo/app/issues/4/comments", "POST /repos/octo/app/issues/9/comments")
+		}
+		if !slices.Equal(api.requests, want) {
+			t.Fatalf("requests = %v, want %v", api.requests, want)
+		}
+
+		created := api.bodies[0]
+		if created["title"] != "Tracking: crash" || created["body"] != body {
+			t.Errorf("created issue = %v, want the tracking title and body", created)
+		}
+		if labels, _ := created["labels"].([]any); len(labels) != 1 || labels[0] != "tracking" {
+			t.Errorf("labels = %v, want [tracking]", created["labels"])
+		}
+		if backlink && !strings.Contains(api.bodies[1]["body"].(string), "#50") {
+			t.Errorf("back-link comment = %q, want a link to #50", api.bodies[1]["body"])
+		}
+	}
+}
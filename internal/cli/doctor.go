@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+// doctorSample is the fixed text embedded by the preflight check
+const doctorSample = "Simili doctor: checking that embeddings work."
+
+// doctorCheck is one named preflight check
+type doctorCheck struct {
+	name string
+	run  func() (string, error)
+}
+
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check embedding, Qdrant, GitHub, and LLM credentials",
+		Long: `Run a one-shot preflight: embed a sample string and report its dimension and
+which provider answered, ping Qdrant, and verify the GitHub token. With triage
+enabled, also stream a short reply from the LLM and report how long the first
+chunk took. Exits non-zero if any check fails.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			failed := 0
+			checks := []doctorCheck{
+				{"embedding", func() (string, error) { return checkEmbedding(ctx, cfg) }},
+				{"qdrant", func() (string, error) { return checkQdrant(ctx, cfg) }},
+				{"github", func() (string, error) { return checkGitHub(ctx) }},
+			}
+			if cfg.Triage.Enabled {
+				checks = append(checks, doctorCheck{"llm", func() (string, error) { return checkLLM(ctx, cfg) }})
+			}
+
+			for _, check := range checks {
+				status, err := check.run()
+				if err != nil {
+					failed++
+					fmt.Printf("❌ %s: %v\n", check.name, err)
+					continue
+				}
+				fmt.Printf("✅ %s: %s\n", check.name, status)
+			}
+
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+			return nil
+		},
+	}
+}
+
+// checkEmbedding embeds the sample text and verifies the vector dimension
+func checkEmbedding(ctx context.Context, cfg *config.Config) (string, error) {
+	embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
+	if err != nil {
+		return "", err
+	}
+	defer embedder.Close()
+
+	vector, source, err := embedder.Probe(ctx, doctorSample)
+	if err != nil {
+		return "", err
+	}
+
+	providerCfg := cfg.Embedding.Primary
+	if source == "fallback" {
+		providerCfg = cfg.Embedding.Fallback
+	}
+	if len(vector) != providerCfg.Dimensions {
+		return "", fmt.Errorf("%s provider returned %d dimensions, config expects %d",
+			source, len(vector), providerCfg.Dimensions)
+	}
+
+	return fmt.Sprintf("%s provider (%s %s) returned %d dimensions",
+		source, providerCfg.Provider, providerCfg.Model, len(vector)), nil
+}
+
+// checkQdrant pings the configured Qdrant instance
+func checkQdrant(ctx context.Context, cfg *config.Config) (string, error) {
+	vdb, err := vectordb.NewClient(&cfg.Qdrant)
+	if err != nil {
+		return "", err
+	}
+	defer vdb.Close()
+
+	version, err := vdb.HealthCheck(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("reachable (version %s)", version), nil
+}
+
+// checkGitHub verifies the GitHub token authenticates
+func checkGitHub(ctx context.Context) (string, error) {
+	gh, err := github.NewClient()
+	if err != nil {
+		return "", err
+	}
+
+	login, err := gh.CurrentUser(ctx)
+	if errors.Is(err, github.ErrForbidden) {
+		// Installation and Actions tokens authenticate but cannot read /user
+		return "token valid (integration token)", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("authenticated as %s", login), nil
+}
+
+// checkLLM streams a short reply from the triage LLM and reports the time
+// to the first chunk, which is what a slow or overloaded provider shows first
+func checkLLM(ctx context.Context, cfg *config.Config) (string, error) {
+	provider, err := createLLMProvider(&cfg.Triage.LLM)
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	start := time.Now()
+	chunks, err := llm.CompleteWithSystemStream(ctx, provider, "Reply with the single word OK.", doctorSample)
+	if err != nil {
+		return "", err
+	}
+
+	var firstChunk time.Duration
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			return "", chunk.Err
+		}
+		if firstChunk == 0 && chunk.Text != "" {
+			firstChunk = time.Since(start)
+		}
+	}
+	if firstChunk == 0 {
+		return "", fmt.Errorf("%s returned an empty reply", cfg.Triage.LLM.Provider)
+	}
+
+	return fmt.Sprintf("%s %s answered, first chunk after %s",
+		cfg.Triage.LLM.Provider, cfg.Triage.LLM.Model, firstChunk.Round(time.Millisecond)), nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/spf13/cobra"
+)
+
+func newEvalCmd() *cobra.Command {
+	var (
+		repo         string
+		duplicates   string
+		concurrency  int
+		minThreshold float64
+		jsonOutput   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Score similarity thresholds against labeled duplicates",
+		Long: `Search the nearest neighbors of every indexed issue in a repository and
+compare them with a CSV of known duplicates ("issue,original" issue numbers)
+to report precision, recall and F1 at each threshold, and the threshold
+with the best F1. Searches use the configured embedding provider and
+collection, so the index should be current (see sync and verify).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			if minThreshold <= 0 || minThreshold >= 1 {
+				return fmt.Errorf("--min-threshold must be between 0 and 1")
+			}
+
+			f, err := os.Open(duplicates)
+			if err != nil {
+				return fmt.Errorf("failed to open duplicates file: %w", err)
+			}
+			pairs, err := processor.LoadDuplicatePairs(f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+
+			syncer, err := processor.NewSyncer(cfg, true)
+			if err != nil {
+				return fmt.Errorf("failed to create syncer: %w", err)
+			}
+			defer syncer.Close()
+
+			report, err := syncer.Evaluate(ctx, repo, pairs, processor.EvalOptions{
+				Concurrency:  concurrency,
+				MinThreshold: minThreshold,
+			})
+			if err != nil {
+				return fmt.Errorf("eval failed: %w", err)
+			}
+
+			if jsonOutput {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal report: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("%s: %d indexed issues, %d labeled duplicate pairs\n", report.Repo, report.Issues, report.Pairs)
+			if report.Failed > 0 {
+				fmt.Printf("Warning: %d searches failed\n", report.Failed)
+			}
+			fmt.Printf("\n%-10s %-10s %-8s %-6s %s\n", "THRESHOLD", "PRECISION", "RECALL", "F1", "TP/FP/FN")
+			for _, p := range report.Points {
+				fmt.Printf("%-10.2f %-10.2f %-8.2f %-6.2f %d/%d/%d\n",
+					p.Threshold, p.Precision, p.Recall, p.F1, p.TruePositives, p.FalsePositives, p.FalseNegatives)
+			}
+
+			if report.Best.F1 == 0 {
+				fmt.Println("\nNo threshold found any labeled duplicate")
+				return nil
+			}
+			fmt.Printf("\nBest threshold: %.2f (precision %.2f, recall %.2f, F1 %.2f)\n",
+				report.Best.Threshold, report.Best.Precision, report.Best.Recall, report.Best.F1)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to evaluate (owner/repo)")
+	cmd.Flags().StringVar(&duplicates, "duplicates", "", "CSV of labeled duplicates: issue,original")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "issues searched in parallel")
+	cmd.Flags().Float64Var(&minThreshold, "min-threshold", 0.5, "lowest similarity threshold to score")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("duplicates")
+
+	return cmd
+}
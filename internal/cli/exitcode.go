@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/triage"
+)
+
+// Exit codes returned with --fail-on-duplicate so CI can gate on the analysis
+const (
+	ExitDuplicate = 2 // Issue is a likely duplicate
+	ExitNeedsInfo = 3 // Issue needs more information
+)
+
+// ExitError carries a process exit code out of a command
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+// triageExitError maps a triage result to an ExitError, or nil when the issue passes
+func triageExitError(result *triage.Result) error {
+	if result == nil {
+		return nil
+	}
+	if result.Duplicate != nil && result.Duplicate.IsDuplicate {
+		msg := fmt.Sprintf("likely duplicate (%.0f%% similar)", result.Duplicate.Similarity*100)
+		if result.Duplicate.Original != nil {
+			msg = fmt.Sprintf("likely duplicate of #%d (%.0f%% similar)", result.Duplicate.Original.Number, result.Duplicate.Similarity*100)
+		}
+		return &ExitError{Code: ExitDuplicate, Message: msg}
+	}
+	if result.Quality != nil && result.Quality.NeedsInfo {
+		return &ExitError{Code: ExitNeedsInfo, Message: fmt.Sprintf("needs more information (quality %.0f%%)", result.Quality.Score*100)}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/triage"
+)
+
+func TestTriageExitError(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *triage.Result
+		want   int
+	}{
+		{"no result", nil, 0},
+		{"passes", &triage.Result{Quality: &triage.QualityResult{Score: 0.2}}, 0},
+		{"needs info", &triage.Result{Quality: &triage.QualityResult{Score: 0.9, NeedsInfo: true}}, ExitNeedsInfo},
+		{"duplicate wins", &triage.Result{
+			Duplicate: &triage.DuplicateResult{IsDuplicate: true, Similarity: 0.95},
+			Quality:   &triage.QualityResult{NeedsInfo: true},
+		}, ExitDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := triageExitError(tt.result)
+			var exitErr *ExitError
+			got := 0
+			if errors.As(err, &exitErr) {
+				got = exitErr.Code
+			}
+			if got != tt.want {
+				t.Errorf("triageExitError() code = %d, want %d (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
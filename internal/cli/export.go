@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		org       string
+		repo      string
+		output    string
+		batchSize int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export an org's indexed vectors and payloads to JSONL",
+		Long: `Dump every point in an org's collection (id, vector, payload) to a JSONL file.
+Use it for backups or to move an index between Qdrant instances with 'import'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			collection, err := scopedCollection(cfg, org, repo)
+			if err != nil {
+				return err
+			}
+
+			vdb, err := vectordb.NewClient(&cfg.Qdrant)
+			if err != nil {
+				return fmt.Errorf("failed to create vector DB client: %w", err)
+			}
+			defer vdb.Close()
+
+			if output == "" {
+				output = collection + ".jsonl"
+			}
+
+			fmt.Printf("Exporting %s...\n", collection)
+			points, err := vdb.Scroll(ctx, collection, batchSize)
+			if err != nil {
+				return fmt.Errorf("failed to read collection: %w", err)
+			}
+
+			if err := writeExportFile(output, points); err != nil {
+				return err
+			}
+
+			fmt.Printf("Exported %d points to %s\n", len(points), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "organization whose collection to export")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file (default <org>_issues.jsonl)")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 256, "points per scroll request")
+	cmd.Flags().StringVar(&repo, "repo", "", "repository name (required when qdrant.collection_scope is repo)")
+	_ = cmd.MarkFlagRequired("org")
+
+	return cmd
+}
+
+// writeExportFile encodes one ExportedPoint per line, the format readExportFile reads
+func writeExportFile(path string, points []vectordb.ExportedPoint) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, p := range points {
+		if err := enc.Encode(p); err != nil {
+			return fmt.Errorf("failed to write point %s: %w", p.ID, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
+
+// scopedCollection resolves the collection for the --org/--repo flags
+func scopedCollection(cfg *config.Config, org, repo string) (string, error) {
+	if cfg.Qdrant.CollectionScope == vectordb.CollectionScopeRepo && repo == "" {
+		return "", fmt.Errorf("--repo is required when qdrant.collection_scope is repo")
+	}
+	return vectordb.CollectionName(&cfg.Qdrant, org, repo), nil
+}
@@ -12,7 +12,11 @@ import (
 
 func newFullProcessCmd() *cobra.Command {
 	var (
-		execute bool
+		execute      bool
+		profile      bool
+		githubOutput string
+		onlySteps    []string
+		skipSteps    []string
 	)
 
 	cmd := &cobra.Command{
@@ -56,6 +60,10 @@ Without --execute, only analysis is performed (read-only mode).`,
 				return fmt.Errorf("failed to create processor: %w", err)
 			}
 			defer proc.Close()
+			proc.SetProfile(profile)
+			if err := proc.SelectSteps(onlySteps, skipSteps); err != nil {
+				return err
+			}
 
 			result, err := proc.ProcessEvent(ctx, eventPath)
 			if err != nil {
@@ -65,6 +73,12 @@ Without --execute, only analysis is performed (read-only mode).`,
 			// Print result summary
 			pipeline.PrintUnifiedResult(result)
 
+			if githubOutput != "" {
+				if err := pipeline.WriteGitHubOutput(githubOutput, result); err != nil {
+					return err
+				}
+			}
+
 			if result.Skipped {
 				fmt.Printf("\nSkipped: %s\n", result.SkipReason)
 				return nil
@@ -86,6 +100,8 @@ Without --execute, only analysis is performed (read-only mode).`,
 			if result.TransferTarget != "" {
 				if result.Transferred {
 					fmt.Printf("✓ Transferred to %s\n", result.TransferTarget)
+				} else if result.TransferScheduled {
+					fmt.Printf("✓ Scheduled transfer to %s\n", result.TransferTarget)
 				} else {
 					fmt.Printf("→ Would transfer to %s\n", result.TransferTarget)
 				}
@@ -109,6 +125,10 @@ Without --execute, only analysis is performed (read-only mode).`,
 	}
 
 	cmd.Flags().BoolVar(&execute, "execute", false, "execute actions (labels, comments, transfers, closes)")
+	cmd.Flags().BoolVar(&profile, "profile", false, "record and print per-stage timings")
+	cmd.Flags().StringSliceVar(&onlySteps, "only", nil, "run only these pipeline steps (comma-separated), e.g. similarity_search,response_builder")
+	cmd.Flags().StringSliceVar(&skipSteps, "skip", nil, "skip these pipeline steps (comma-separated)")
+	cmd.Flags().StringVar(&githubOutput, "github-output", os.Getenv("GITHUB_OUTPUT"), "file to append step outputs (skipped, error_count, errors) to")
 	_ = cmd.MarkPersistentFlagRequired("event-path")
 
 	return cmd
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+func newImportCmd() *cobra.Command {
+	var (
+		org       string
+		repo      string
+		input     string
+		batchSize int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import vectors exported with 'export' without re-embedding",
+		Long: `Read a JSONL file produced by 'export' and upsert its points into an org's
+collection. The collection is created if missing and vector dimensions are
+checked before anything is written.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			collection, err := scopedCollection(cfg, org, repo)
+			if err != nil {
+				return err
+			}
+
+			points, err := readExportFile(input)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Read %d points from %s\n", len(points), input)
+
+			vdb, err := vectordb.NewClient(&cfg.Qdrant)
+			if err != nil {
+				return fmt.Errorf("failed to create vector DB client: %w", err)
+			}
+			vdb.SetMultiVector(&cfg.Embedding.MultiVector)
+			defer vdb.Close()
+
+			if !dryRun {
+				if err := vdb.EnsureCollection(ctx, collection); err != nil {
+					return fmt.Errorf("failed to ensure collection: %w", err)
+				}
+			}
+
+			// Check every vector up front so a bad file never half-imports
+			if exists, err := vdb.CollectionExists(ctx, collection); err != nil {
+				return fmt.Errorf("failed to check collection: %w", err)
+			} else if exists {
+				shape, err := vdb.CollectionShape(ctx, collection)
+				if err != nil {
+					return err
+				}
+				if err := checkExportShape(points, shape, collection); err != nil {
+					return err
+				}
+			}
+
+			if dryRun {
+				fmt.Printf("Dry run: would import %d points into %s\n", len(points), collection)
+				return nil
+			}
+
+			if batchSize <= 0 {
+				batchSize = 256
+			}
+
+			imported := 0
+			for start := 0; start < len(points); start += batchSize {
+				end := start + batchSize
+				if end > len(points) {
+					end = len(points)
+				}
+				if err := vdb.UpsertExported(ctx, collection, points[start:end]); err != nil {
+					return fmt.Errorf("failed to import batch at line %d: %w", start+1, err)
+				}
+				imported += end - start
+			}
+
+			fmt.Printf("Imported %d points into %s\n", imported, collection)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "organization whose collection to import into")
+	cmd.Flags().StringVarP(&input, "input", "i", "", "JSONL file produced by export")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 256, "points per upsert request")
+	cmd.Flags().StringVar(&repo, "repo", "", "repository name (required when qdrant.collection_scope is repo)")
+	_ = cmd.MarkFlagRequired("org")
+	_ = cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// readExportFile decodes one ExportedPoint per line
+func readExportFile(path string) ([]vectordb.ExportedPoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	var points []vectordb.ExportedPoint
+	dec := json.NewDecoder(bufio.NewReader(f))
+	dec.UseNumber()
+	for dec.More() {
+		var p vectordb.ExportedPoint
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("failed to decode point %d: %w", len(points)+1, err)
+		}
+		// Multi-vector exports carry only named vectors
+		if p.ID == "" || (len(p.Vector) == 0 && len(p.Vectors) == 0) {
+			return nil, fmt.Errorf("point %d is missing an id or vector", len(points)+1)
+		}
+		points = append(points, p)
+	}
+
+	return points, nil
+}
+
+// checkExportShape verifies every point matches the collection's single or
+// named vector layout
+func checkExportShape(points []vectordb.ExportedPoint, shape vectordb.VectorShape, collection string) error {
+	for i, p := range points {
+		if err := shape.Check(p); err != nil {
+			return fmt.Errorf("point %s (line %d) doesn't fit collection %s: %w", p.ID, i+1, collection, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+)
+
+func TestReadExportFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr string
+	}{
+		{"single vector", `{"id":"a","vector":[0.1,0.2],"payload":{}}`, 1, ""},
+		{"named vectors", `{"id":"a","vectors":{"title":[0.1],"body":[0.2]},"payload":{}}`, 1, ""},
+		{"no vector", `{"id":"a","payload":{}}`, 0, "missing an id or vector"},
+		{"no id", `{"vector":[0.1],"payload":{}}`, 0, "missing an id or vector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "export.jsonl")
+			if err := os.WriteFile(path, []byte(tt.data+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			points, err := readExportFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readExportFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || len(points) != tt.want {
+				t.Fatalf("readExportFile() = %d points, %v; want %d", len(points), err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	single := vectordb.VectorShape{Size: 3}
+	multi := vectordb.VectorShape{Named: map[string]int{vectordb.VectorTitle: 2, vectordb.VectorBody: 2}}
+
+	tests := []struct {
+		name    string
+		point   vectordb.ExportedPoint
+		shape   vectordb.VectorShape
+		wantErr string
+	}{
+		{"single vector", vectordb.ExportedPoint{ID: "a", Vector: []float32{0.1, 0.2, 0.3}}, single, ""},
+		{"named vectors", vectordb.ExportedPoint{ID: "a", Vectors: map[string][]float32{"title": {0.1, 0.2}, "body": {0.3, 0.4}}}, multi, ""},
+		{"single into named", vectordb.ExportedPoint{ID: "a", Vector: []float32{0.1, 0.2}}, multi, "expects named vectors"},
+		{"named into single", vectordb.ExportedPoint{ID: "a", Vectors: map[string][]float32{"title": {0.1, 0.2, 0.3}}}, single, "expects a single vector"},
+		{"wrong named size", vectordb.ExportedPoint{ID: "a", Vectors: map[string][]float32{"title": {0.1, 0.2}, "body": {0.3}}}, multi, `"body" vector has 1 dimensions`},
+		{"missing named vector", vectordb.ExportedPoint{ID: "a", Vectors: map[string][]float32{"title": {0.1, 0.2}}}, multi, `missing the "body" vector`},
+		{"wrong single size", vectordb.ExportedPoint{ID: "a", Vector: []float32{0.1}}, single, "has 1 dimensions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "export.jsonl")
+			tt.point.Payload = map[string]any{"number": 1}
+			if err := writeExportFile(path, []vectordb.ExportedPoint{tt.point}); err != nil {
+				t.Fatal(err)
+			}
+
+			points, err := readExportFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = checkExportShape(points, tt.shape, "octo_issues")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkExportShape() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkExportShape() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
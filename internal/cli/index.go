@@ -11,8 +11,12 @@ import (
 
 func newIndexCmd() *cobra.Command {
 	var (
-		repo      string
-		batchSize int
+		repo        string
+		batchSize   int
+		sinceNumber int
+		resume      bool
+		maxIssues   int
+		discussions bool
 	)
 
 	cmd := &cobra.Command{
@@ -44,6 +48,10 @@ func newIndexCmd() *cobra.Command {
 				return fmt.Errorf("failed to create indexer: %w", err)
 			}
 			defer indexer.Close()
+			indexer.SetSinceNumber(sinceNumber)
+			indexer.SetMaxIssues(maxIssues)
+			indexer.SetIncludeDiscussions(discussions)
+			indexer.SetResume(processor.DefaultIndexStatePath, resume)
 
 			stats, err := indexer.IndexRepo(ctx, repo, batchSize)
 			if err != nil {
@@ -59,6 +67,10 @@ func newIndexCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&repo, "repo", "", "repository to index (owner/repo)")
 	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "number of issues to fetch per batch")
+	cmd.Flags().IntVar(&sinceNumber, "since-number", 0, "only index issues numbered above N (incremental backfill)")
+	cmd.Flags().BoolVar(&resume, "resume", false, "continue an interrupted run from "+processor.DefaultIndexStatePath)
+	cmd.Flags().IntVar(&maxIssues, "max-issues", 0, "stop after fetching N issues (0 = all); useful for trying simili on huge repos")
+	cmd.Flags().BoolVar(&discussions, "discussions", false, "also index GitHub Discussions so new issues can match them")
 	_ = cmd.MarkFlagRequired("repo")
 
 	return cmd
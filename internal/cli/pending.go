@@ -2,10 +2,15 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/triage"
@@ -13,10 +18,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// pendingActionCost estimates GitHub requests spent per pending action
+const pendingActionCost = 5
+
 func newProcessPendingCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "process-pending",
-		Short: "Process expired pending actions (transfers and closes)",
+		Short: "Process expired pending actions (transfers, closes, and drafted comments)",
 		Long:  `Processes pending actions that have expired and checks for user reactions to determine if actions should execute or be cancelled.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -70,32 +78,28 @@ func newProcessPendingCmd() *cobra.Command {
 				// Find pending actions
 				actions, err := pendingMgr.FindPendingActions(ctx, repoConfig.Org, repoConfig.Repo)
 				if err != nil {
-					fmt.Printf("Warning: failed to find pending actions: %v\n", err)
+					logging.Warnf("failed to find pending actions: %v", err)
 					continue
 				}
 
-				// Process each action
-				for _, action := range actions {
-					fmt.Printf("Processing %s action for issue #%d...\n", action.Type, action.IssueNumber)
-
-					switch action.Type {
-					case pending.ActionTypeTransfer:
-						executor := transfer.NewExecutor(gh, gh, vdb, cfg, dryRun)
-						if err := executor.ProcessPendingTransfer(ctx, action); err != nil {
-							fmt.Printf("Error processing transfer: %v\n", err)
-							continue
-						}
-						processedCount++
-
-					case pending.ActionTypeClose:
-						duplicateChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&cfg.Triage.Duplicate, gh, cfg, dryRun)
-						if err := duplicateChecker.ProcessPendingClose(ctx, action); err != nil {
-							fmt.Printf("Error processing close: %v\n", err)
-							continue
-						}
-						processedCount++
+				// Each action costs a handful of requests (comments, reactions, labels, transfer)
+				if len(actions) > 0 {
+					if err := gh.EnsureBudget(ctx, len(actions)*pendingActionCost); err != nil {
+						return err
 					}
 				}
+
+				// Process actions concurrently; each action's failure is isolated
+				processed, rateLimited := processPendingActions(ctx, actions, cfg, func(action *pending.PendingAction) error {
+					return processPendingAction(ctx, action, gh, vdb, pendingMgr, cfg)
+				})
+				processedCount += processed
+
+				// Remaining actions will be picked up on the next scheduled run
+				if rateLimited {
+					logging.Warnf("GitHub rate limit reached, stopping until next run")
+					break
+				}
 			}
 
 			fmt.Printf("Processed %d pending actions\n", processedCount)
@@ -105,3 +109,92 @@ func newProcessPendingCmd() *cobra.Command {
 
 	return cmd
 }
+
+// processPendingAction executes or cancels a single pending action
+func processPendingAction(ctx context.Context, action *pending.PendingAction, gh *github.Client, vdb *vectordb.Client, pendingMgr *pending.Manager, cfg *config.Config) error {
+	fmt.Printf("Processing %s action for issue #%d...\n", action.Type, action.IssueNumber)
+
+	switch action.Type {
+	case pending.ActionTypeTransfer:
+		executor := transfer.NewExecutor(gh, gh, vdb, cfg, dryRun)
+		if err := executor.ProcessPendingTransfer(ctx, action); err != nil {
+			return fmt.Errorf("failed to process transfer: %w", err)
+		}
+
+	case pending.ActionTypeClose:
+		duplicateChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&cfg.Triage.Duplicate, gh, cfg, dryRun)
+		if err := duplicateChecker.ProcessPendingClose(ctx, action); err != nil {
+			return fmt.Errorf("failed to process close: %w", err)
+		}
+
+	case pending.ActionTypeComment:
+		if err := pendingMgr.ProcessPendingComment(ctx, action, dryRun); err != nil {
+			return fmt.Errorf("failed to process draft comment: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown action type: %s", action.Type)
+	}
+
+	return nil
+}
+
+// processPendingActions runs process over actions with up to
+// delayed_actions.concurrency workers, starting actions no faster than the
+// configured GitHub request rate allows. It stops handing out actions once
+// one hits the rate limit and returns how many succeeded.
+func processPendingActions(ctx context.Context, actions []*pending.PendingAction, cfg *config.Config, process func(*pending.PendingAction) error) (int, bool) {
+	workers := cfg.Defaults.DelayedActions.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(actions) {
+		workers = len(actions)
+	}
+
+	// Each action spends about pendingActionCost requests
+	var throttle <-chan time.Time
+	if rps := cfg.RateLimits.GitHubRPS; rps > 0 && workers > 1 {
+		ticker := time.NewTicker(time.Duration(pendingActionCost) * time.Second / time.Duration(rps))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var (
+		processed   atomic.Int64
+		rateLimited atomic.Bool
+		wg          sync.WaitGroup
+	)
+	queue := make(chan *pending.PendingAction)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for action := range queue {
+				if err := process(action); err != nil {
+					logging.Errorf("%s/%s#%d: %v", action.Org, action.Repo, action.IssueNumber, err)
+					if errors.Is(err, github.ErrRateLimited) {
+						rateLimited.Store(true)
+					}
+					continue
+				}
+				processed.Add(1)
+			}
+		}()
+	}
+
+	for _, action := range actions {
+		if rateLimited.Load() || ctx.Err() != nil {
+			break
+		}
+		if throttle != nil {
+			<-throttle
+		}
+		queue <- action
+	}
+	close(queue)
+	wg.Wait()
+
+	return int(processed.Load()), rateLimited.Load()
+}
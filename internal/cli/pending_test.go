@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+)
+
+func pendingActions(n int) []*pending.PendingAction {
+	actions := make([]*pending.PendingAction, n)
+	for i := range actions {
+		actions[i] = &pending.PendingAction{Org: "org", Repo: "repo", IssueNumber: i + 1}
+	}
+	return actions
+}
+
+func TestProcessPendingActions(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Concurrency = 3
+
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+		seen           = map[int]bool{}
+	)
+	process := func(action *pending.PendingAction) error {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		seen[action.IssueNumber] = true
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if action.IssueNumber%4 == 0 {
+			return fmt.Errorf("issue %d: boom", action.IssueNumber)
+		}
+		return nil
+	}
+
+	processed, rateLimited := processPendingActions(context.Background(), pendingActions(10), cfg, process)
+	if processed != 8 || rateLimited {
+		t.Errorf("processPendingActions() = %d, %v, want 8 processed and no rate limit", processed, rateLimited)
+	}
+	if len(seen) != 10 {
+		t.Errorf("processed %d distinct actions, want 10", len(seen))
+	}
+	if peak > 3 || peak < 2 {
+		t.Errorf("peak concurrency = %d, want 2-3", peak)
+	}
+}
+
+func TestProcessPendingActions_StopsWhenRateLimited(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Concurrency = 1
+
+	var calls int
+	process := func(action *pending.PendingAction) error {
+		calls++
+		if action.IssueNumber == 2 {
+			return fmt.Errorf("list reactions: %w", github.ErrRateLimited)
+		}
+		return nil
+	}
+
+	processed, rateLimited := processPendingActions(context.Background(), pendingActions(10), cfg, process)
+	// The action already handed out behind the failing one may still run
+	if !rateLimited || processed < 1 || processed > 2 {
+		t.Errorf("processPendingActions() = %d, %v, want 1-2 processed and rate limited", processed, rateLimited)
+	}
+	if calls > 3 {
+		t.Errorf("process called %d times after the rate limit, want it to stop", calls)
+	}
+}
+
+func TestProcessPendingActions_Cancelled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Concurrency = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	processed, _ := processPendingActions(ctx, pendingActions(5), cfg, func(*pending.PendingAction) error {
+		return errors.New("should not run")
+	})
+	if processed != 0 {
+		t.Errorf("processed %d actions after cancellation, want 0", processed)
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pipeline"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/spf13/cobra"
+)
+
+// planOutput is the JSON document emitted by the plan command
+type planOutput struct {
+	Result  *core.UnifiedResult `json:"result"`
+	Comment string              `json:"comment"`
+}
+
+func newPlanCmd() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Preview the unified comment without posting",
+		Long: `Run similarity search, transfer matching and triage for an issue event
+and print the resulting plan and comment body as JSON. No comments, labels,
+transfers or index writes are performed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			event, err := github.ParseEventFile(eventPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse event: %w", err)
+			}
+
+			issue := event.ToIssue()
+			if issue == nil {
+				return fmt.Errorf("failed to extract issue from event")
+			}
+
+			proc, err := pipeline.NewUnifiedProcessor(cfg, true, false)
+			if err != nil {
+				return fmt.Errorf("failed to create processor: %w", err)
+			}
+			defer proc.Close()
+
+			result, comment, err := proc.Plan(ctx, issue)
+			if err != nil {
+				return fmt.Errorf("planning failed: %w", err)
+			}
+
+			data, err := json.MarshalIndent(planOutput{Result: result, Comment: comment}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal plan: %w", err)
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, data, 0644); err != nil {
+					return fmt.Errorf("failed to write plan: %w", err)
+				}
+				fmt.Printf("Plan written to: %s\n", outputPath)
+				return nil
+			}
+
+			fmt.Println(string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputPath, "output", "", "path to write plan JSON (default: stdout)")
+	_ = cmd.MarkPersistentFlagRequired("event-path")
+
+	return cmd
+}
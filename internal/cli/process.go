@@ -11,7 +11,14 @@ import (
 )
 
 func newProcessCmd() *cobra.Command {
-	var execute bool
+	var (
+		execute         bool
+		profile         bool
+		failOnDuplicate bool
+		githubOutput    string
+		onlySteps       []string
+		skipSteps       []string
+	)
 	cmd := &cobra.Command{
 		Use:   "process",
 		Short: "Process a single issue from GitHub Action event",
@@ -56,6 +63,10 @@ func newProcessCmd() *cobra.Command {
 				return fmt.Errorf("failed to create processor: %w", err)
 			}
 			defer proc.Close()
+			proc.SetProfile(profile)
+			if err := proc.SelectSteps(onlySteps, skipSteps); err != nil {
+				return err
+			}
 
 			result, err := proc.ProcessEvent(ctx, eventPath)
 			if err != nil {
@@ -63,10 +74,28 @@ func newProcessCmd() *cobra.Command {
 			}
 
 			pipeline.PrintUnifiedResult(result)
+
+			if githubOutput != "" {
+				if err := pipeline.WriteGitHubOutput(githubOutput, result); err != nil {
+					return err
+				}
+			}
+
+			if failOnDuplicate {
+				if err := triageExitError(result.TriageResult); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+			}
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&profile, "profile", false, "record and print per-stage timings")
+	cmd.Flags().StringSliceVar(&onlySteps, "only", nil, "run only these pipeline steps (comma-separated), e.g. similarity_search,response_builder")
+	cmd.Flags().StringSliceVar(&skipSteps, "skip", nil, "skip these pipeline steps (comma-separated)")
+	cmd.Flags().StringVar(&githubOutput, "github-output", os.Getenv("GITHUB_OUTPUT"), "file to append step outputs (skipped, error_count, errors) to")
+	cmd.Flags().BoolVar(&failOnDuplicate, "fail-on-duplicate", false, "exit 2 for likely duplicates and 3 for needs-info (for CI gating)")
 	_ = cmd.MarkPersistentFlagRequired("event-path")
 
 	return cmd
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/pipeline"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/spf13/cobra"
+)
+
+func newReplayCmd() *cobra.Command {
+	var execute bool
+
+	cmd := &cobra.Command{
+		Use:   "replay <dir|glob>",
+		Short: "Reprocess a batch of saved event files",
+		Long: `Runs each saved GitHub event JSON (every *.json in a directory, or the
+files matching a glob) through the unified pipeline and prints a summary of
+the outcomes. Runs in dry-run mode unless --execute is given, so config and
+logic changes can be checked against real historical events.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			files, err := replayFiles(args[0])
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				return fmt.Errorf("no event files match %s", args[0])
+			}
+
+			proc, err := pipeline.NewUnifiedProcessor(cfg, dryRun || !execute, execute)
+			if err != nil {
+				return fmt.Errorf("failed to create processor: %w", err)
+			}
+			defer proc.Close()
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "EVENT\tISSUE\tOUTCOME")
+
+			failed := 0
+			for _, file := range files {
+				result, err := proc.ProcessEvent(ctx, file)
+				if err != nil {
+					failed++
+					fmt.Fprintf(tw, "%s\t-\terror: %v\n", filepath.Base(file), err)
+					continue
+				}
+				fmt.Fprintf(tw, "%s\t#%d\t%s\n", filepath.Base(file), result.IssueNumber, replayOutcome(result))
+			}
+			tw.Flush()
+
+			fmt.Printf("\nReplayed %d event(s), %d failed\n", len(files), failed)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&execute, "execute", false, "perform writes instead of a dry run")
+
+	return cmd
+}
+
+// replayFiles expands a directory to its *.json files, or a glob to its matches
+func replayFiles(pattern string) ([]string, error) {
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*.json")
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// replayOutcome summarizes a result in one line
+func replayOutcome(result *core.UnifiedResult) string {
+	if result.Skipped {
+		return "skipped: " + result.SkipReason
+	}
+
+	var parts []string
+	if len(result.SimilarFound) > 0 {
+		parts = append(parts, fmt.Sprintf("%d similar", len(result.SimilarFound)))
+	}
+	if t := result.TriageResult; t != nil {
+		if len(t.Labels) > 0 {
+			labels := make([]string, len(t.Labels))
+			for i, l := range t.Labels {
+				labels[i] = l.Label
+			}
+			parts = append(parts, "labels "+strings.Join(labels, ","))
+		}
+		if t.Duplicate != nil && t.Duplicate.IsDuplicate && t.Duplicate.Original != nil {
+			parts = append(parts, fmt.Sprintf("duplicate of #%d", t.Duplicate.Original.Number))
+		}
+	}
+	if result.TransferTarget != "" {
+		parts = append(parts, "transfer to "+result.TransferTarget)
+	}
+	if result.CommentPosted {
+		parts = append(parts, "commented")
+	}
+	if len(result.Errors) > 0 {
+		parts = append(parts, fmt.Sprintf("%d error(s)", len(result.Errors)))
+	}
+	if len(parts) == 0 {
+		return "no action"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+func newReprocessCmd() *cobra.Command {
+	var (
+		repo      string
+		label     string
+		maxIssues int
+		execute   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reprocess",
+		Short: "Re-triage open issues carrying a label",
+		Long: `Lists the open issues carrying --label and runs each through the unified
+pipeline again, so improved triage config can be applied to an existing
+backlog. Only triage actions run: no summary is posted, no transfer is made
+and nothing is re-indexed, and only older issues count as duplicate
+originals. Cooldowns and opt-out labels apply as for new issues. Runs in
+dry-run mode unless --execute is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			org, name, err := github.ParseRepo(repo)
+			if err != nil {
+				return err
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			issues, err := gh.ListIssuesByLabel(ctx, org, name, label)
+			if err != nil {
+				return err
+			}
+			if maxIssues > 0 && len(issues) > maxIssues {
+				issues = issues[:maxIssues]
+			}
+			if len(issues) == 0 {
+				fmt.Printf("No open issues labeled %q in %s\n", label, repo)
+				return nil
+			}
+
+			proc, err := pipeline.NewUnifiedProcessorWithTransferToken(cfg, dryRun || !execute, execute, os.Getenv("TRANSFER_TOKEN"))
+			if err != nil {
+				return fmt.Errorf("failed to create processor: %w", err)
+			}
+			defer proc.Close()
+			proc.PrepareReprocess()
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ISSUE\tOUTCOME")
+
+			failed := 0
+			for _, listed := range issues {
+				// Refetch so label changes and transfers since listing are seen
+				issue, err := gh.GetIssue(ctx, org, name, listed.Number)
+				if err != nil {
+					failed++
+					fmt.Fprintf(tw, "#%d\terror: %v\n", listed.Number, err)
+					continue
+				}
+				result, err := proc.ProcessIssue(ctx, issue)
+				if err != nil {
+					failed++
+					fmt.Fprintf(tw, "#%d\terror: %v\n", issue.Number, err)
+					continue
+				}
+				fmt.Fprintf(tw, "#%d\t%s\n", issue.Number, replayOutcome(result))
+			}
+			tw.Flush()
+
+			fmt.Printf("\nReprocessed %d issue(s), %d failed\n", len(issues), failed)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to reprocess (owner/repo)")
+	cmd.Flags().StringVar(&label, "label", "", "only reprocess open issues carrying this label")
+	cmd.Flags().IntVar(&maxIssues, "max-issues", 0, "stop after this many issues (0 for all)")
+	cmd.Flags().BoolVar(&execute, "execute", false, "perform writes instead of a dry run")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("label")
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/deadletter"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
+	"github.com/spf13/cobra"
+)
+
+func newRetryFailedCmd() *cobra.Command {
+	var queuePath string
+
+	cmd := &cobra.Command{
+		Use:   "retry-failed",
+		Short: "Retry comments and labels that failed to apply",
+		Long: `Replays actions recorded in the dead-letter file when posting a comment or
+applying a label failed (for example during a GitHub outage). Actions that
+succeed are removed from the file; the rest stay for the next run. A summary
+is dropped when one was posted since or the issue was closed, and actions on
+issues that were ignored or opted out with the no-bot label are dropped.
+
+The file is defaults.dead_letter_file unless --file is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			if queuePath == "" {
+				queuePath = cfg.Defaults.DeadLetterFile
+			}
+			if queuePath == "" {
+				return fmt.Errorf("no dead-letter file: set defaults.dead_letter_file or pass --file")
+			}
+
+			queue := deadletter.NewQueue(queuePath)
+			entries, err := queue.Load()
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				fmt.Println("No failed actions to retry")
+				return nil
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			executor := steps.NewActionExecutor(gh, gh, nil, dryRun, true)
+			executor.SetDeadLetter(nil) // failures are kept below rather than re-appended
+
+			var remaining []deadletter.Entry
+			dropped := 0
+			for _, entry := range entries {
+				ref := fmt.Sprintf("%s/%s#%d", entry.Org, entry.Repo, entry.IssueNumber)
+				reason, err := executor.Retry(ctx, cfg, entry)
+				if err != nil {
+					logging.Warnf("retry of %s on %s failed: %v", entry.Action, ref, err)
+					entry.Attempts++
+					entry.Error = err.Error()
+					remaining = append(remaining, entry)
+					continue
+				}
+				if reason != "" {
+					dropped++
+					fmt.Printf("Dropped %s on %s: %s\n", entry.Action, ref, reason)
+					continue
+				}
+				fmt.Printf("Retried %s on %s\n", entry.Action, ref)
+			}
+
+			fmt.Printf("\nRetried %d action(s), dropped %d, %d still failing\n", len(entries)-len(remaining)-dropped, dropped, len(remaining))
+
+			if dryRun {
+				return nil
+			}
+			// Failures recorded by other runs while this one replayed stay queued
+			return queue.Consume(len(entries), remaining)
+		},
+	}
+
+	cmd.Flags().StringVar(&queuePath, "file", "", "dead-letter file to replay (default: defaults.dead_letter_file)")
+
+	return cmd
+}
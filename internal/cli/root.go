@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +11,10 @@ var (
 	cfgFile   string
 	eventPath string
 	dryRun    bool
+	logLevel  string
+	logFormat string
+	verbose   bool
+	quiet     bool
 	version   = "dev"
 )
 
@@ -20,6 +25,9 @@ var rootCmd = &cobra.Command{
 classification rules and detects duplicate/similar issues using semantic search.
 
 Uses Gemini embeddings + Qdrant vector DB for similarity detection.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return configureLogging()
+	},
 }
 
 func Execute() error {
@@ -30,19 +38,60 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path")
 	rootCmd.PersistentFlags().StringVar(&eventPath, "event-path", "", "path to GitHub event JSON file")
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "skip all writes (GitHub + Qdrant)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log format: text or json (structured, for log ingestion)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "shorthand for --log-level debug")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "shorthand for --log-level error")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
 
 	rootCmd.AddCommand(newIndexCmd())
 	rootCmd.AddCommand(newProcessCmd())
 	rootCmd.AddCommand(newSyncCmd())
+	rootCmd.AddCommand(newVerifyCmd())
+	rootCmd.AddCommand(newEvalCmd())
 	rootCmd.AddCommand(newSearchCmd())
+	rootCmd.AddCommand(newServeCmd())
 	rootCmd.AddCommand(newConfigCmd())
 	rootCmd.AddCommand(newTriageCmd())
 	rootCmd.AddCommand(newTriageExecuteCmd())
 	rootCmd.AddCommand(newProcessPendingCmd())
+	rootCmd.AddCommand(newCancelActionCmd())
+	rootCmd.AddCommand(newConsolidateCmd())
+	rootCmd.AddCommand(newRetryFailedCmd())
 	rootCmd.AddCommand(newFullProcessCmd())
+	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newPlanCmd())
+	rootCmd.AddCommand(newReplayCmd())
+	rootCmd.AddCommand(newReprocessCmd())
+	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(newImportCmd())
+	rootCmd.AddCommand(newDoctorCmd())
 	rootCmd.AddCommand(newVersionCmd())
 }
 
+// configureLogging applies --log-format and --log-level, with --verbose and
+// --quiet taking precedence over the level
+func configureLogging() error {
+	format, err := logging.ParseFormat(logFormat)
+	if err != nil {
+		return err
+	}
+	logging.SetFormat(format)
+
+	level, err := logging.ParseLevel(logLevel)
+	if err != nil {
+		return err
+	}
+	switch {
+	case verbose:
+		level = logging.LevelDebug
+	case quiet:
+		level = logging.LevelError
+	}
+	logging.SetLevel(level)
+	return nil
+}
+
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
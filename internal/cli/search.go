@@ -2,18 +2,24 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 func newSearchCmd() *cobra.Command {
 	var (
-		repo  string
-		limit int
+		repo         string
+		excludeRepos []string
+		limit        int
+		jsonOutput   bool
 	)
 
 	cmd := &cobra.Command{
@@ -42,26 +48,32 @@ func newSearchCmd() *cobra.Command {
 				return fmt.Errorf("invalid configuration")
 			}
 
+			cfg.Defaults.CrossRepoExclude = append(cfg.Defaults.CrossRepoExclude, excludeRepos...)
+
 			searcher, err := processor.NewSearcher(cfg)
 			if err != nil {
 				return fmt.Errorf("failed to create searcher: %w", err)
 			}
 			defer searcher.Close()
 
-			// Parse org from repo if provided
-			org := ""
+			// Parse org and repo name if provided
+			org, repoName := "", ""
 			if repo != "" {
 				parts := strings.Split(repo, "/")
 				if len(parts) == 2 {
-					org = parts[0]
+					org, repoName = parts[0], parts[1]
 				}
 			}
 
-			results, err := searcher.Search(ctx, query, org, limit)
+			results, err := searcher.Search(ctx, query, org, repoName, limit)
 			if err != nil {
 				return fmt.Errorf("search failed: %w", err)
 			}
 
+			if jsonOutput {
+				return writeSearchJSON(os.Stdout, results)
+			}
+
 			if len(results) == 0 {
 				fmt.Println("No similar issues found")
 				return nil
@@ -76,6 +88,9 @@ func newSearchCmd() *cobra.Command {
 				fmt.Printf("%d. #%d - %s\n", i+1, r.Issue.Number, r.Issue.Title)
 				fmt.Printf("   Repo: %s/%s | Similarity: %.1f%% | Status: %s\n",
 					r.Issue.Org, r.Issue.Repo, r.Score*100, status)
+				if len(r.Issue.Labels) > 0 {
+					fmt.Printf("   Labels: %s\n", strings.Join(r.Issue.Labels, ", "))
+				}
 				fmt.Printf("   %s\n\n", r.Issue.URL)
 			}
 
@@ -84,7 +99,28 @@ func newSearchCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&repo, "repo", "", "limit search to repository (owner/repo)")
+	cmd.Flags().StringSliceVar(&excludeRepos, "exclude-repo", nil, "leave out matches from repository (owner/repo); repeatable")
 	cmd.Flags().IntVar(&limit, "limit", 10, "maximum results to return")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print results as JSON")
 
 	return cmd
 }
+
+// writeSearchJSON prints results as a JSON array, with empty lists as []
+// rather than null so scripts can iterate them without a nil check
+func writeSearchJSON(w io.Writer, results []models.SearchResult) error {
+	out := make([]models.SearchResult, len(results))
+	for i, r := range results {
+		if r.Issue.Labels == nil {
+			r.Issue.Labels = []string{}
+		}
+		out[i] = r
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal results: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestWriteSearchJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []models.SearchResult
+		want    string
+	}{
+		{"nil results", nil, "[]"},
+		{"no results", []models.SearchResult{}, "[]"},
+		{"nil labels", []models.SearchResult{{Issue: models.Issue{Number: 1}}}, `"labels": []`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeSearchJSON(&buf, tt.results); err != nil {
+				t.Fatalf("writeSearchJSON() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("writeSearchJSON() = %q, want it to contain %q", buf.String(), tt.want)
+			}
+			if strings.Contains(buf.String(), "null") {
+				t.Errorf("writeSearchJSON() = %q, want no null", buf.String())
+			}
+		})
+	}
+}
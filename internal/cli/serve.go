@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func newServeCmd() *cobra.Command {
+	var (
+		addr        string
+		allowOrigin string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a similar-issues HTTP API",
+		Long: `Starts an HTTP server answering GET /similar?repo=owner/name&title=...&body=...
+with the indexed issues most similar to the given text, so an issue form can
+warn about likely duplicates before submission.
+
+The API is unauthenticated by design, since a browser form can't keep a
+secret: it only answers for public repositories enabled in the config, and
+treats the text like a new issue there, so matches follow cross_repo_search,
+the similarity filters, and private_matches (redacted matches are omitted).
+Setting SIMILI_API_TOKEN additionally requires "Authorization: Bearer <token>",
+which is useful for server-side callers but is not access control.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			searcher, err := processor.NewSearcher(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create searcher: %w", err)
+			}
+			defer searcher.Close()
+			searcher.SetVisibilityChecker(gh)
+
+			handler := server.NewHandler(searcher, server.Options{
+				Token:        os.Getenv("SIMILI_API_TOKEN"),
+				AllowOrigin:  allowOrigin,
+				DefaultLimit: cfg.Defaults.MaxSimilarToShow,
+				RepoAllowed: func(ctx context.Context, org, repo string) bool {
+					if rc := cfg.GetRepoConfig(org, repo); rc == nil || !rc.Enabled {
+						return false
+					}
+					// Private repos' issues never go to an unauthenticated caller
+					visibility, err := gh.GetRepoVisibility(ctx, org, repo)
+					if err != nil {
+						logging.Warn("failed to read repository visibility, refusing", "repo", org+"/"+repo, "error", err)
+						return false
+					}
+					return visibility == github.VisibilityPublic
+				},
+			})
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           handler,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			logging.Info("serving similarity API", "addr", addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "address to listen on")
+	cmd.Flags().StringVar(&allowOrigin, "allow-origin", "", "origin allowed to call the API from a browser (CORS), e.g. https://example.com")
+
+	return cmd
+}
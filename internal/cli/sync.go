@@ -11,8 +11,9 @@ import (
 
 func newSyncCmd() *cobra.Command {
 	var (
-		repo  string
-		since string
+		repo   string
+		since  string
+		report string
 	)
 
 	cmd := &cobra.Command{
@@ -45,6 +46,13 @@ func newSyncCmd() *cobra.Command {
 			}
 			defer syncer.Close()
 
+			if report != "" {
+				if !dryRun {
+					return fmt.Errorf("--dry-run-report requires --dry-run")
+				}
+				syncer.SetReportPath(report)
+			}
+
 			stats, err := syncer.SyncRepo(ctx, repo, since)
 			if err != nil {
 				return fmt.Errorf("sync failed: %w", err)
@@ -58,7 +66,8 @@ func newSyncCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&repo, "repo", "", "repository to sync (owner/repo)")
-	cmd.Flags().StringVar(&since, "since", "24h", "sync issues updated since (e.g., 24h, 7d)")
+	cmd.Flags().StringVar(&since, "since", "24h", "sync issues updated since (e.g., 24h, 7d, 2w, 1d12h)")
+	cmd.Flags().StringVar(&report, "dry-run-report", "", "with --dry-run, write a JSON summary of new/updated/unchanged issues to this file")
 	_ = cmd.MarkFlagRequired("repo")
 
 	return cmd
@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
@@ -19,8 +23,10 @@ import (
 
 func newTriageCmd() *cobra.Command {
 	var (
-		outputPath string
-		execute    bool
+		outputPath      string
+		execute         bool
+		format          string
+		failOnDuplicate bool
 	)
 
 	cmd := &cobra.Command{
@@ -32,6 +38,12 @@ or execute actions directly.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			switch format {
+			case "text", "json", "markdown", "table":
+			default:
+				return fmt.Errorf("unknown format %q (expected text, json, markdown or table)", format)
+			}
+
 			cfgPath := config.FindConfigPath(cfgFile)
 			if cfgPath == "" {
 				return fmt.Errorf("config file not found")
@@ -80,6 +92,7 @@ or execute actions directly.`,
 			if err != nil {
 				return fmt.Errorf("failed to create vector DB client: %w", err)
 			}
+			vdb.SetMultiVector(&cfg.Embedding.MultiVector)
 			defer vdb.Close()
 
 			similarity := processor.NewSimilarityFinder(cfg, embedder, vdb)
@@ -89,24 +102,27 @@ or execute actions directly.`,
 			if err != nil {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
+			similarity.SetVisibilityChecker(ghClient)
 			agent := triage.NewAgentWithGitHub(cfg, llmProvider, similarity, ghClient)
 
-			// Run triage
-			fmt.Printf("Triaging issue #%d: %s\n", issue.Number, issue.Title)
+			// Run triage (progress goes to stderr so structured formats stay parseable)
+			fmt.Fprintf(os.Stderr, "Triaging issue #%d: %s\n", issue.Number, issue.Title)
 			result, err := agent.Triage(ctx, issue)
 			if err != nil {
 				return fmt.Errorf("triage failed: %w", err)
 			}
 
 			// Output results
-			printTriageResult(result)
+			if err := writeTriageResult(os.Stdout, result, format); err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
 
 			// Write output file if specified
 			if outputPath != "" {
 				if err := triage.WriteOutput(result, outputPath); err != nil {
 					return fmt.Errorf("failed to write output: %w", err)
 				}
-				fmt.Printf("Output written to: %s\n", outputPath)
+				fmt.Fprintf(os.Stderr, "Output written to: %s\n", outputPath)
 			}
 
 			// Execute actions if requested
@@ -122,7 +138,14 @@ or execute actions directly.`,
 				if err := executor.Execute(ctx, issue, result); err != nil {
 					return fmt.Errorf("failed to execute actions: %w", err)
 				}
-				fmt.Println("Actions executed successfully")
+				fmt.Fprintln(os.Stderr, "Actions executed successfully")
+			}
+
+			if failOnDuplicate {
+				if err := triageExitError(result); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
 			}
 
 			return nil
@@ -131,20 +154,91 @@ or execute actions directly.`,
 
 	cmd.Flags().StringVar(&outputPath, "output", "", "path to write triage output JSON")
 	cmd.Flags().BoolVar(&execute, "execute", false, "execute actions (default: analyze only)")
+	cmd.Flags().StringVar(&format, "format", "text", "output format: text, json, markdown, table")
+	cmd.Flags().BoolVar(&failOnDuplicate, "fail-on-duplicate", false, "exit 2 for likely duplicates and 3 for needs-info (for CI gating)")
 	_ = cmd.MarkPersistentFlagRequired("event-path")
 
 	return cmd
 }
 
 func createLLMProvider(cfg *config.LLMConfig) (llm.Provider, error) {
+	opts := llm.Options{
+		Temperature: float32(cfg.GetTemperature()),
+		Seed:        cfg.Seed,
+		Timeout:     time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+	var provider llm.Provider
+	var err error
 	switch cfg.Provider {
 	case "gemini":
-		return llm.NewGeminiProvider(cfg.APIKey, cfg.Model)
+		provider, err = llm.NewGeminiProvider(cfg.APIKey, cfg.Model, opts)
 	case "openai":
-		return llm.NewOpenAIProvider(cfg.APIKey, cfg.Model)
+		provider, err = llm.NewOpenAIProvider(cfg.APIKey, cfg.Model, opts)
 	default:
 		return nil, fmt.Errorf("unknown LLM provider: %s", cfg.Provider)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast to rule-based triage during an LLM outage
+	cooldown := time.Duration(cfg.BreakerCooldownSeconds) * time.Second
+	return llm.NewCircuitBreaker(provider, cfg.BreakerThreshold, cooldown), nil
+}
+
+// writeTriageResult renders a triage result in the requested format
+func writeTriageResult(w io.Writer, result *triage.Result, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "markdown":
+		// The same comment bodies the bot would post, in posting order
+		var bodies []string
+		for _, a := range triage.FilterActions(result, triage.ActionComment) {
+			bodies = append(bodies, a.Comment)
+		}
+		_, err := fmt.Fprintln(w, strings.Join(bodies, "\n\n"))
+		return err
+	case "table":
+		return printTriageTable(w, result)
+	default:
+		printTriageResult(result)
+		return nil
+	}
+}
+
+// printTriageTable renders labels and actions as aligned columns
+func printTriageTable(w io.Writer, result *triage.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "LABEL\tCONFIDENCE\tREASON")
+	for _, l := range result.Labels {
+		fmt.Fprintf(tw, "%s\t%.0f%%\t%s\n", l.Label, l.Confidence*100, l.Reason)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "ACTION\tTARGET\tREASON")
+	for _, a := range result.Actions {
+		target := a.Label
+		if a.Type == triage.ActionComment {
+			target = fmt.Sprintf("%d chars", len(a.Comment))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", a.Type, target, a.Reason)
+	}
+
+	if result.Quality != nil {
+		fmt.Fprintf(tw, "\nQUALITY\t%.0f%%\t%s\n", result.Quality.Score*100, strings.Join(result.Quality.Missing, ", "))
+	}
+	if result.Duplicate != nil && result.Duplicate.IsDuplicate && result.Duplicate.Original != nil {
+		fmt.Fprintf(tw, "DUPLICATE\t%.0f%%\t#%d %s\n", result.Duplicate.Similarity*100, result.Duplicate.Original.Number, result.Duplicate.Original.Title)
+	}
+
+	return tw.Flush()
 }
 
 func printTriageResult(result *triage.Result) {
@@ -184,6 +278,8 @@ func printTriageResult(result *triage.Result) {
 				fmt.Printf("  - Post comment (%d chars)\n", len(a.Comment))
 			case triage.ActionClose:
 				fmt.Printf("  - Close issue\n")
+			case triage.ActionLock:
+				fmt.Printf("  - Lock conversation (%s)\n", a.LockReason)
 			}
 		}
 	}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/spf13/cobra"
+)
+
+func newVerifyCmd() *cobra.Command {
+	var (
+		repo       string
+		repair     bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check the index against a repository's issues",
+		Long: `Compare every issue in a repository with its indexed copy and report
+issues that are missing from the index, indexed with outdated content, or
+indexed but no longer exist. With --repair, orphaned vectors are deleted and
+missing or stale issues are reindexed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			syncer, err := processor.NewSyncer(cfg, dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to create syncer: %w", err)
+			}
+			defer syncer.Close()
+
+			report, err := syncer.VerifyRepo(ctx, repo, repair && !dryRun)
+			if report == nil {
+				return fmt.Errorf("verify failed: %w", err)
+			}
+			// A partial repair still prints its report before failing
+			repairErr := err
+
+			if jsonOutput {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal report: %w", err)
+				}
+				fmt.Println(string(data))
+				return repairErr
+			}
+
+			fmt.Printf("%s: %d live issues, %d indexed\n", report.Repo, report.Live, report.Indexed)
+			if report.Consistent() {
+				fmt.Println("Index is consistent")
+				return nil
+			}
+			fmt.Printf("  Missing:  %d %v\n", len(report.Missing), report.Missing)
+			fmt.Printf("  Stale:    %d %v\n", len(report.Stale), report.Stale)
+			fmt.Printf("  Orphaned: %d %v\n", len(report.Orphaned), report.Orphaned)
+			switch {
+			case repairErr != nil:
+				fmt.Printf("Repaired %d, failed %d %v\n", report.Repaired, len(report.Failed), report.Failed)
+				return fmt.Errorf("repair incomplete: %w", repairErr)
+			case report.Repaired > 0:
+				fmt.Printf("Repaired %d: orphaned vectors deleted, missing and stale issues reindexed\n", report.Repaired)
+			default:
+				fmt.Println("Run with --repair to fix")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to verify (owner/repo)")
+	cmd.Flags().BoolVar(&repair, "repair", false, "delete orphaned vectors and reindex missing or stale issues")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
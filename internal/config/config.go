@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -26,6 +28,12 @@ type TriageConfig struct {
 	Classifier ClassifierConfig `yaml:"classifier"`
 	Quality    QualityConfig    `yaml:"quality"`
 	Duplicate  DuplicateConfig  `yaml:"duplicate"`
+	Popularity PopularityConfig `yaml:"popularity"`
+	Spam       SpamConfig       `yaml:"spam"`
+	// NeedsTriage marks issues the bot hasn't finished processing yet
+	NeedsTriage NeedsTriageConfig `yaml:"needs_triage"`
+	// MaxPromptBodyChars caps the issue body sent to the LLM
+	MaxPromptBodyChars int `yaml:"max_prompt_body_chars,omitempty"`
 }
 
 // LLMConfig contains LLM provider settings for triage
@@ -33,6 +41,28 @@ type LLMConfig struct {
 	Provider string `yaml:"provider"`
 	Model    string `yaml:"model"`
 	APIKey   string `yaml:"api_key"`
+	// Circuit breaker: stop calling the LLM for BreakerCooldownSeconds
+	// after BreakerThreshold consecutive failures
+	BreakerThreshold       int `yaml:"breaker_threshold,omitempty"`
+	BreakerCooldownSeconds int `yaml:"breaker_cooldown_seconds,omitempty"`
+	// Temperature is the sampling temperature; kept low so triage is stable
+	// across runs. A pointer so an explicit 0 is kept rather than defaulted.
+	Temperature *float64 `yaml:"temperature,omitempty"`
+	// Seed requests reproducible sampling where the provider supports it; 0 leaves it unset
+	Seed int `yaml:"seed,omitempty"`
+	// TimeoutSeconds bounds each LLM call; on timeout triage falls back to rule-based results
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
+
+// DefaultLLMTemperature is the sampling temperature when none is configured
+const DefaultLLMTemperature = 0.1
+
+// GetTemperature returns the configured sampling temperature, or the default
+func (c *LLMConfig) GetTemperature() float64 {
+	if c.Temperature == nil {
+		return DefaultLLMTemperature
+	}
+	return *c.Temperature
 }
 
 // ClassifierConfig contains label classification settings
@@ -40,12 +70,22 @@ type ClassifierConfig struct {
 	Enabled       bool          `yaml:"enabled"`
 	Labels        []LabelConfig `yaml:"labels"`
 	MinConfidence float64       `yaml:"min_confidence"`
+	// RuleSmoothing is added to a label's keyword count when scoring keyword
+	// matches, so a single matched keyword no longer scores 1.0 (try 1)
+	RuleSmoothing float64 `yaml:"rule_smoothing,omitempty"`
+	// RuleMaxConfidence caps keyword-match confidence; 0 means no cap
+	RuleMaxConfidence float64 `yaml:"rule_max_confidence,omitempty"`
 }
 
 // LabelConfig defines a label with optional matching keywords
 type LabelConfig struct {
 	Name     string   `yaml:"name"`
 	Keywords []string `yaml:"keywords,omitempty"`
+	// MinConfidence overrides the classifier's global threshold for this label
+	MinConfidence float64 `yaml:"min_confidence,omitempty"`
+	// MinKeywordMatches is how many distinct keywords must match before the
+	// rule applies the label (default 1)
+	MinKeywordMatches int `yaml:"min_keyword_matches,omitempty"`
 }
 
 // QualityConfig contains quality detection settings
@@ -53,6 +93,36 @@ type QualityConfig struct {
 	Enabled        bool    `yaml:"enabled"`
 	MinScore       float64 `yaml:"min_score"`
 	NeedsInfoLabel string  `yaml:"needs_info_label"`
+	// AutoNeedsInfoPhrases force needs-info when found in the body (case-insensitive)
+	AutoNeedsInfoPhrases []string `yaml:"auto_needs_info_phrases,omitempty"`
+	// RequiredSections are issue-form headings that must be answered
+	RequiredSections []string `yaml:"required_sections,omitempty"`
+}
+
+// PopularityConfig labels issues that receive many 👍 reactions
+type PopularityConfig struct {
+	Threshold int    `yaml:"threshold"` // Minimum 👍 count; 0 disables
+	Label     string `yaml:"label"`
+}
+
+// SpamConfig flags spam issues before the other triage checks run
+type SpamConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Label   string `yaml:"label"`
+	// Threshold is the confidence at or above which an issue is treated as spam
+	Threshold float64 `yaml:"threshold"`
+	// Close closes spam as not planned; skipped when delayed actions are on
+	Close bool `yaml:"close,omitempty"`
+	// Lock locks the conversation on spam so it can't attract replies
+	Lock bool `yaml:"lock,omitempty"`
+	// Keywords are spam phrases (case-insensitive); each match adds 0.5 confidence
+	Keywords []string `yaml:"keywords,omitempty"`
+}
+
+// NeedsTriageConfig controls the holding label applied while an issue is processed
+type NeedsTriageConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Label   string `yaml:"label"`
 }
 
 // DuplicateConfig contains duplicate detection settings
@@ -60,6 +130,13 @@ type DuplicateConfig struct {
 	Enabled            bool    `yaml:"enabled"`
 	AutoCloseThreshold float64 `yaml:"auto_close_threshold"`
 	RequireConfirm     bool    `yaml:"require_confirmation"`
+	// MentionOriginalAuthor pings the original issue's author in duplicate comments
+	MentionOriginalAuthor bool `yaml:"mention_original_author,omitempty"`
+	// LinkOriginal posts a back-link to the new issue on the original
+	LinkOriginal bool `yaml:"link_original,omitempty"`
+	// IndependentSearch searches for duplicates at AutoCloseThreshold
+	// instead of reusing matches found at the similarity threshold
+	IndependentSearch bool `yaml:"independent_search,omitempty"`
 }
 
 // QdrantConfig contains Qdrant connection settings
@@ -67,12 +144,49 @@ type QdrantConfig struct {
 	URL     string `yaml:"url"`
 	APIKey  string `yaml:"api_key"`
 	UseGRPC bool   `yaml:"use_grpc"`
+	// CollectionScope is "org" (one collection per org) or "repo" (one per repository)
+	CollectionScope string `yaml:"collection_scope,omitempty"`
+	// CollectionPrefix namespaces collections (e.g. "prod" -> prod_myorg_issues)
+	// so several deployments can share one cluster
+	CollectionPrefix string `yaml:"collection_prefix,omitempty"`
+	// OnDisk keeps vectors on disk instead of in RAM for new collections
+	OnDisk bool `yaml:"on_disk,omitempty"`
+	// Quantization compresses vectors in new collections
+	Quantization QuantizationConfig `yaml:"quantization,omitempty"`
+	// MaxIndexedLabels caps how many labels are stored per issue payload (0 keeps all)
+	MaxIndexedLabels int `yaml:"max_indexed_labels,omitempty"`
+	// PriorityLabels are kept ahead of other labels when the cap applies
+	PriorityLabels []string `yaml:"priority_labels,omitempty"`
+}
+
+// QuantizationConfig enables int8 scalar quantization of stored vectors
+type QuantizationConfig struct {
+	Scalar bool `yaml:"scalar"`
+	// Quantile drops outliers when choosing the int8 range (0.5-1; Qdrant defaults to 1)
+	Quantile  float64 `yaml:"quantile,omitempty"`
+	AlwaysRAM bool    `yaml:"always_ram,omitempty"` // Keep quantized vectors in RAM even with on_disk
 }
 
 // EmbeddingConfig contains embedding provider settings
 type EmbeddingConfig struct {
-	Primary  ProviderConfig `yaml:"primary"`
-	Fallback ProviderConfig `yaml:"fallback"`
+	Primary     ProviderConfig `yaml:"primary"`
+	Fallback    ProviderConfig `yaml:"fallback"`
+	CacheDir    string         `yaml:"cache_dir,omitempty"`    // Optional on-disk embedding cache
+	TitleWeight int            `yaml:"title_weight,omitempty"` // Times the title is repeated in embedded text
+	EmbedLabels bool           `yaml:"embed_labels,omitempty"` // Add the issue's labels to embedded text
+	// TimeoutSeconds bounds each embedding call, so a hung primary provider
+	// falls through to the fallback instead of stalling the run
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+	// MultiVector embeds title and body separately and fuses their scores
+	// at search time; switching it on or off requires a reindex
+	MultiVector MultiVectorConfig `yaml:"multi_vector,omitempty"`
+}
+
+// MultiVectorConfig controls separate title/body embeddings
+type MultiVectorConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	TitleWeight float64 `yaml:"title_weight,omitempty"` // Weight of the title similarity; defaults to 0.5
+	BodyWeight  float64 `yaml:"body_weight,omitempty"`  // Weight of the body similarity; defaults to 0.5
 }
 
 // ProviderConfig contains settings for an embedding provider
@@ -85,23 +199,102 @@ type ProviderConfig struct {
 
 // DefaultsConfig contains default behavior settings
 type DefaultsConfig struct {
-	SimilarityThreshold  float64              `yaml:"similarity_threshold"`
-	MaxSimilarToShow     int                  `yaml:"max_similar_to_show"`
-	IncludeClosedIssues  bool                 `yaml:"include_closed_issues"`
-	ClosedIssueWeight    float64              `yaml:"closed_issue_weight"`
-	CrossRepoSearch      bool                 `yaml:"cross_repo_search"`
-	CommentCooldownHours int                  `yaml:"comment_cooldown_hours"`
-	DelayedActions       DelayedActionsConfig `yaml:"delayed_actions"`
+	SimilarityThreshold float64 `yaml:"similarity_threshold"`
+	// DisplayThreshold, when set, is the minimum score shown in comments;
+	// the search then runs at the lower of it and auto_close_threshold so
+	// duplicate detection sees matches the comment doesn't show
+	DisplayThreshold    float64 `yaml:"display_threshold,omitempty"`
+	MaxSimilarToShow    int     `yaml:"max_similar_to_show"`
+	MaxSimilarToFetch   int     `yaml:"max_similar_to_fetch,omitempty"` // Matches fetched for analysis; defaults to max_similar_to_show
+	SimilarSort         string  `yaml:"similar_sort,omitempty"`         // score (default), open-first, or recent
+	IncludeClosedIssues bool    `yaml:"include_closed_issues"`
+	ClosedIssueWeight   float64 `yaml:"closed_issue_weight"`
+	ClosedIssueStrategy string  `yaml:"closed_issue_strategy,omitempty"` // weight, demote, or separate
+	CrossRepoSearch     bool    `yaml:"cross_repo_search"`
+	// SameRepoBoost is added to same-repo matches' scores when ranking
+	// cross-repo results, so local duplicates win close calls; thresholds
+	// and displayed similarity still use the raw score
+	SameRepoBoost float64 `yaml:"same_repo_boost,omitempty"`
+	// CrossRepoExclude lists org/repo entries never returned as matches from other repos
+	CrossRepoExclude     []string `yaml:"cross_repo_exclude,omitempty"`
+	CommentCooldownHours int      `yaml:"comment_cooldown_hours"`
+	CommentOncePerIssue  bool     `yaml:"comment_once_per_issue,omitempty"` // Never comment again once any bot comment exists
+	// MinimizeOutdatedComments hides the previous summary as outdated when a new one is posted
+	MinimizeOutdatedComments bool `yaml:"minimize_outdated_comments,omitempty"`
+	// ActionCooldowns gates labels and transfer suggestions separately from
+	// comments; when set, the comment cooldown only holds back the comment
+	ActionCooldowns ActionCooldownsConfig `yaml:"action_cooldowns,omitempty"`
+	// PrivateMatches controls matches from private or internal repos on a
+	// public repo's issues: "redact" (default) hides their title and link,
+	// "drop" omits them, "show" renders them as-is
+	PrivateMatches string `yaml:"private_matches,omitempty"`
+	// CommentFooter replaces the "Powered by" footer on bot comments; nil keeps
+	// the default and an empty string removes it
+	CommentFooter *string `yaml:"comment_footer,omitempty"`
+	// EditDebounceMinutes skips edited events arriving within this many
+	// minutes of the last run when the title and body are unchanged; 0 disables
+	EditDebounceMinutes int `yaml:"edit_debounce_minutes,omitempty"`
+	// TransferOnLabel re-checks transfer and area rules when a label is added
+	// to an open issue, so routing picks up labels applied by hand
+	TransferOnLabel bool `yaml:"transfer_on_label,omitempty"`
+	// WriteRetry retries comment, label, and transfer writes that fail with
+	// transient GitHub errors
+	WriteRetry WriteRetryConfig `yaml:"write_retry,omitempty"`
+	// DeadLetterFile records comments and labels that still failed after
+	// retries, for retry-failed to replay; empty disables recording
+	DeadLetterFile string `yaml:"dead_letter_file,omitempty"`
+	// CommentWhenNothingFound posts the summary even when it has no matches,
+	// labels, transfer, duplicate, or quality concerns to report
+	CommentWhenNothingFound bool   `yaml:"comment_when_nothing_found,omitempty"`
+	CommentStyle            string `yaml:"comment_style,omitempty"` // emoji (default) or plain headers
+	// CommentApprovalRequired drafts the summary collapsed and only publishes
+	// it (and applies its actions) after a maintainer's approve reaction
+	CommentApprovalRequired bool `yaml:"comment_approval_required,omitempty"`
+	MinMatchAgeMinutes      int  `yaml:"min_match_age_minutes,omitempty"` // Ignore matches created within this window of the issue
+	// SimilarityFilters drops matches after the vector search
+	SimilarityFilters  SimilarityFiltersConfig `yaml:"similarity_filters,omitempty"`
+	ClaimWindowMinutes int                     `yaml:"claim_window_minutes,omitempty"` // Skip issues another run claimed within this window; 0 disables
+	DelayedActions     DelayedActionsConfig    `yaml:"delayed_actions"`
+	DryRun             DryRunConfig            `yaml:"dry_run,omitempty"` // Per-category dry-run for staged rollout
+	NoBot              NoBotConfig             `yaml:"no_bot,omitempty"`  // Per-issue opt-out label
+}
+
+// NoBotConfig lets maintainers quiet the bot on individual issues by label
+type NoBotConfig struct {
+	Label   string `yaml:"label"`    // Issues carrying this label get no bot comments; empty disables
+	SkipAll bool   `yaml:"skip_all"` // Also skip labeling, transfers and indexing
+}
+
+// DryRunConfig keeps individual action categories in dry-run while others run live
+type DryRunConfig struct {
+	Comments  bool `yaml:"comments"`
+	Labels    bool `yaml:"labels"`
+	Closes    bool `yaml:"closes"`
+	Transfers bool `yaml:"transfers"`
 }
 
 // DelayedActionsConfig contains settings for delayed actions
 type DelayedActionsConfig struct {
-	Enabled             bool   `yaml:"enabled"`
-	DelayHours          int    `yaml:"delay_hours"`
-	ApproveReaction     string `yaml:"approve_reaction"`
-	CancelReaction      string `yaml:"cancel_reaction"`
-	ExecuteOnApprove    bool   `yaml:"execute_on_approve"`
-	OptimisticTransfers bool   `yaml:"optimistic_transfers"`
+	Enabled             bool     `yaml:"enabled"`
+	DelayHours          int      `yaml:"delay_hours"`
+	ApproveReaction     string   `yaml:"approve_reaction"`
+	CancelReaction      string   `yaml:"cancel_reaction"`
+	ExtendReaction      string   `yaml:"extend_reaction,omitempty"`   // Pushes the deadline out once by delay_hours
+	ApproveReactions    []string `yaml:"approve_reactions,omitempty"` // Further reactions that also approve
+	CancelReactions     []string `yaml:"cancel_reactions,omitempty"`  // Further reactions that also cancel
+	ExecuteOnApprove    bool     `yaml:"execute_on_approve"`
+	OptimisticTransfers bool     `yaml:"optimistic_transfers"`
+	Concurrency         int      `yaml:"concurrency,omitempty"` // Pending actions processed in parallel per repo; defaults to 4
+}
+
+// Approvals returns approve_reaction followed by its synonyms
+func (d DelayedActionsConfig) Approvals() []string {
+	return append([]string{d.ApproveReaction}, d.ApproveReactions...)
+}
+
+// Cancellations returns cancel_reaction followed by its synonyms
+func (d DelayedActionsConfig) Cancellations() []string {
+	return append([]string{d.CancelReaction}, d.CancelReactions...)
 }
 
 // RepositoryConfig contains settings for a specific repository
@@ -111,6 +304,8 @@ type RepositoryConfig struct {
 	Enabled             bool           `yaml:"enabled"`
 	SimilarityThreshold float64        `yaml:"similarity_threshold,omitempty"`
 	TransferRules       []TransferRule `yaml:"transfer_rules,omitempty"`
+	AreaRules           []AreaRule     `yaml:"area_rules,omitempty"`
+	Language            string         `yaml:"language,omitempty"` // Language of bot comments, e.g. "de"; defaults to English
 }
 
 // TransferRule defines when to transfer an issue to another repo
@@ -118,6 +313,9 @@ type TransferRule struct {
 	Match    MatchCondition `yaml:"match"`
 	Target   string         `yaml:"target"`
 	Priority int            `yaml:"priority"`
+	// MinSimilarityToTarget requires an indexed issue in the target repo at
+	// least this similar before the rule fires; 0 disables the check
+	MinSimilarityToTarget float64 `yaml:"min_similarity_to_target,omitempty"`
 }
 
 // MatchCondition defines conditions for matching issues
@@ -126,6 +324,41 @@ type MatchCondition struct {
 	TitleContains []string `yaml:"title_contains,omitempty"`
 	BodyContains  []string `yaml:"body_contains,omitempty"`
 	Author        string   `yaml:"author,omitempty"`
+	IssueType     []string `yaml:"issue_type,omitempty"`   // GitHub native issue types
+	Milestone     []string `yaml:"milestone,omitempty"`    // Milestone titles
+	TitleRegex    []string `yaml:"title_regex,omitempty"`  // Go regular expressions, any may match
+	AuthorRegex   string   `yaml:"author_regex,omitempty"` // Go regular expression for the author login
+}
+
+// AreaRule maps issues that mention an area to its owning team (CODEOWNERS-style)
+type AreaRule struct {
+	Keywords []string `yaml:"keywords"`         // Case-insensitive, matched against title and body
+	Team     string   `yaml:"team"`             // org/team-slug to @-mention
+	Target   string   `yaml:"target,omitempty"` // Optional org/repo to route the issue to
+	Priority int      `yaml:"priority"`
+}
+
+// ActionCooldownsConfig sets per-action cooldowns in hours; 0 disables one
+type ActionCooldownsConfig struct {
+	LabelHours    int `yaml:"label_hours,omitempty"`    // Minimum gap between label updates
+	TransferHours int `yaml:"transfer_hours,omitempty"` // Minimum gap between transfer suggestions
+}
+
+// Enabled reports whether any per-action cooldown is configured
+func (c ActionCooldownsConfig) Enabled() bool {
+	return c.LabelHours > 0 || c.TransferHours > 0
+}
+
+// WriteRetryConfig bounds retries of GitHub writes; attempts of 1 disables them
+type WriteRetryConfig struct {
+	Attempts       int `yaml:"attempts,omitempty"`        // Total tries per write (default: 3)
+	BackoffSeconds int `yaml:"backoff_seconds,omitempty"` // Wait before the first retry, growing linearly (default: 2)
+}
+
+// SimilarityFiltersConfig configures the built-in similarity post-filters
+type SimilarityFiltersConfig struct {
+	SameRepoOnly  bool     `yaml:"same_repo_only,omitempty"` // Only match issues in the same repository
+	ExcludeLabels []string `yaml:"exclude_labels,omitempty"` // Never match issues carrying these labels
 }
 
 // RateLimitsConfig contains rate limiting settings
@@ -186,18 +419,48 @@ func FindConfigPath(explicit string) string {
 
 // applyDefaults sets default values for unset fields
 func applyDefaults(cfg *Config) {
+	// Excluded labels are matched against stored payloads, so they must
+	// survive the label cap
+	if cfg.Qdrant.MaxIndexedLabels > 0 {
+		for _, label := range cfg.Defaults.SimilarityFilters.ExcludeLabels {
+			if !slices.ContainsFunc(cfg.Qdrant.PriorityLabels, func(p string) bool { return strings.EqualFold(p, label) }) {
+				cfg.Qdrant.PriorityLabels = append(cfg.Qdrant.PriorityLabels, label)
+			}
+		}
+	}
 	if cfg.Defaults.SimilarityThreshold == 0 {
 		cfg.Defaults.SimilarityThreshold = 0.82
 	}
 	if cfg.Defaults.MaxSimilarToShow == 0 {
 		cfg.Defaults.MaxSimilarToShow = 5
 	}
+	if cfg.Defaults.MaxSimilarToFetch == 0 {
+		cfg.Defaults.MaxSimilarToFetch = cfg.Defaults.MaxSimilarToShow
+	}
 	if cfg.Defaults.ClosedIssueWeight == 0 {
 		cfg.Defaults.ClosedIssueWeight = 0.9
 	}
+	if cfg.Defaults.ClosedIssueStrategy == "" {
+		cfg.Defaults.ClosedIssueStrategy = "weight"
+	}
 	if cfg.Defaults.CommentCooldownHours == 0 {
 		cfg.Defaults.CommentCooldownHours = 1
 	}
+	if cfg.Defaults.CommentStyle == "" {
+		cfg.Defaults.CommentStyle = "emoji"
+	}
+	if cfg.Defaults.PrivateMatches == "" {
+		cfg.Defaults.PrivateMatches = "redact"
+	}
+	if cfg.Defaults.WriteRetry.Attempts == 0 {
+		cfg.Defaults.WriteRetry.Attempts = 3
+	}
+	if cfg.Defaults.WriteRetry.BackoffSeconds == 0 {
+		cfg.Defaults.WriteRetry.BackoffSeconds = 2
+	}
+	if cfg.Qdrant.CollectionScope == "" {
+		cfg.Qdrant.CollectionScope = "org"
+	}
 	if cfg.RateLimits.GitHubRPS == 0 {
 		cfg.RateLimits.GitHubRPS = 10
 	}
@@ -213,6 +476,13 @@ func applyDefaults(cfg *Config) {
 	if cfg.Embedding.Fallback.Dimensions == 0 {
 		cfg.Embedding.Fallback.Dimensions = 768
 	}
+	if cfg.Embedding.TitleWeight == 0 {
+		cfg.Embedding.TitleWeight = 1
+	}
+	if cfg.Embedding.MultiVector.TitleWeight == 0 && cfg.Embedding.MultiVector.BodyWeight == 0 {
+		cfg.Embedding.MultiVector.TitleWeight = 0.5
+		cfg.Embedding.MultiVector.BodyWeight = 0.5
+	}
 
 	// Triage defaults
 	if cfg.Triage.Classifier.MinConfidence == 0 {
@@ -227,6 +497,37 @@ func applyDefaults(cfg *Config) {
 	if cfg.Triage.Duplicate.AutoCloseThreshold == 0 {
 		cfg.Triage.Duplicate.AutoCloseThreshold = 0.95
 	}
+	if cfg.Triage.LLM.BreakerThreshold == 0 {
+		cfg.Triage.LLM.BreakerThreshold = 3
+	}
+	if cfg.Triage.LLM.BreakerCooldownSeconds == 0 {
+		cfg.Triage.LLM.BreakerCooldownSeconds = 60
+	}
+	if cfg.Triage.LLM.TimeoutSeconds == 0 {
+		cfg.Triage.LLM.TimeoutSeconds = 30
+	}
+	if cfg.Embedding.TimeoutSeconds == 0 {
+		cfg.Embedding.TimeoutSeconds = 30
+	}
+	if cfg.Triage.LLM.Temperature == nil {
+		cfg.Triage.LLM.Temperature = new(float64)
+		*cfg.Triage.LLM.Temperature = DefaultLLMTemperature
+	}
+	if cfg.Triage.MaxPromptBodyChars == 0 {
+		cfg.Triage.MaxPromptBodyChars = 2000
+	}
+	if cfg.Triage.Popularity.Label == "" {
+		cfg.Triage.Popularity.Label = "popular"
+	}
+	if cfg.Triage.Spam.Label == "" {
+		cfg.Triage.Spam.Label = "spam"
+	}
+	if cfg.Triage.Spam.Threshold == 0 {
+		cfg.Triage.Spam.Threshold = 0.8
+	}
+	if cfg.Triage.NeedsTriage.Label == "" {
+		cfg.Triage.NeedsTriage.Label = "needs-triage"
+	}
 
 	// Delayed actions defaults
 	if cfg.Defaults.DelayedActions.DelayHours == 0 {
@@ -238,6 +539,10 @@ func applyDefaults(cfg *Config) {
 	if cfg.Defaults.DelayedActions.CancelReaction == "" {
 		cfg.Defaults.DelayedActions.CancelReaction = "-1"
 	}
+	normalizeReactions(&cfg.Defaults.DelayedActions)
+	if cfg.Defaults.DelayedActions.Concurrency == 0 {
+		cfg.Defaults.DelayedActions.Concurrency = 4
+	}
 	// Enabled defaults to false (zero value) - must be explicitly enabled
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -104,11 +105,218 @@ func TestApplyDefaults(t *testing.T) {
 		t.Errorf("MaxSimilarToShow = %v, want 5", cfg.Defaults.MaxSimilarToShow)
 	}
 
+	if cfg.Defaults.MaxSimilarToFetch != cfg.Defaults.MaxSimilarToShow {
+		t.Errorf("MaxSimilarToFetch = %v, want it to default to MaxSimilarToShow", cfg.Defaults.MaxSimilarToFetch)
+	}
+
 	if cfg.Defaults.ClosedIssueWeight != 0.9 {
 		t.Errorf("ClosedIssueWeight = %v, want 0.9", cfg.Defaults.ClosedIssueWeight)
 	}
 
+	if got := cfg.Triage.LLM.GetTemperature(); got != 0.1 {
+		t.Errorf("Triage.LLM.Temperature = %v, want 0.1", got)
+	}
+
+	// An explicit zero is kept
+	zero := &Config{}
+	zero.Triage.LLM.Temperature = new(float64)
+	applyDefaults(zero)
+	if got := zero.Triage.LLM.GetTemperature(); got != 0 {
+		t.Errorf("Triage.LLM.Temperature = %v, want an explicit 0 kept", got)
+	}
+
 	if cfg.RateLimits.GitHubRPS != 10 {
 		t.Errorf("GitHubRPS = %v, want 10", cfg.RateLimits.GitHubRPS)
 	}
+
+	if cfg.Triage.MaxPromptBodyChars != 2000 {
+		t.Errorf("MaxPromptBodyChars = %v, want 2000", cfg.Triage.MaxPromptBodyChars)
+	}
+
+	if cfg.Qdrant.CollectionScope != "org" {
+		t.Errorf("CollectionScope = %q, want org", cfg.Qdrant.CollectionScope)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{}
+	cfg.Qdrant.APIKey = "qdrant-secret"
+	cfg.Embedding.Primary.APIKey = "gemini-secret"
+	cfg.Triage.LLM.APIKey = "llm-secret"
+
+	red := cfg.Redacted()
+
+	if red.Qdrant.APIKey != "***" || red.Embedding.Primary.APIKey != "***" || red.Triage.LLM.APIKey != "***" {
+		t.Errorf("API keys not masked: %+v", red)
+	}
+	if red.Embedding.Fallback.APIKey != "" {
+		t.Errorf("unset fallback key = %q, want empty", red.Embedding.Fallback.APIKey)
+	}
+	if cfg.Qdrant.APIKey != "qdrant-secret" {
+		t.Errorf("Redacted modified the original config")
+	}
+}
+
+func TestValidate_AutoCloseBelowSimilarityThreshold(t *testing.T) {
+	hasError := func(errs []error) bool {
+		for _, err := range errs {
+			if ve, ok := err.(ValidationError); ok && ve.Field == "triage.duplicate.auto_close_threshold" {
+				return true
+			}
+		}
+		return false
+	}
+
+	cfg := &Config{}
+	cfg.Triage.Enabled = true
+	cfg.Triage.Duplicate.Enabled = true
+	cfg.Triage.Duplicate.AutoCloseThreshold = 0.8
+	applyDefaults(cfg)
+
+	if !hasError(Validate(cfg)) {
+		t.Error("Validate() accepted auto_close_threshold below similarity_threshold")
+	}
+
+	cfg.Triage.Duplicate.IndependentSearch = true
+	if hasError(Validate(cfg)) {
+		t.Error("Validate() rejected auto_close_threshold with independent_search set")
+	}
+}
+
+func TestValidate_CollectionScope(t *testing.T) {
+	for _, tt := range []struct {
+		scope   string
+		wantErr bool
+	}{
+		{"org", false},
+		{"repo", false},
+		{"team", true},
+	} {
+		cfg := &Config{}
+		applyDefaults(cfg)
+		cfg.Qdrant.CollectionScope = tt.scope
+
+		gotErr := false
+		for _, err := range Validate(cfg) {
+			if ve, ok := err.(ValidationError); ok && ve.Field == "qdrant.collection_scope" {
+				gotErr = true
+			}
+		}
+		if gotErr != tt.wantErr {
+			t.Errorf("Validate() with collection_scope %q error = %v, want %v", tt.scope, gotErr, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_CollectionPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{"", false},
+		{"prod", false},
+		{"stag-1_eu", false},
+		{"_prod", true},
+		{"prod/eu", true},
+		{"prod eu", true},
+	} {
+		cfg := &Config{}
+		applyDefaults(cfg)
+		cfg.Qdrant.CollectionPrefix = tt.prefix
+
+		gotErr := false
+		for _, err := range Validate(cfg) {
+			if ve, ok := err.(ValidationError); ok && ve.Field == "qdrant.collection_prefix" {
+				gotErr = true
+			}
+		}
+		if gotErr != tt.wantErr {
+			t.Errorf("Validate() with collection_prefix %q error = %v, want %v", tt.prefix, gotErr, tt.wantErr)
+		}
+	}
+}
+
+func TestDisplayAndSearchThresholds(t *testing.T) {
+	cfg := &Config{}
+	cfg.Triage.Enabled = true
+	cfg.Triage.Duplicate.Enabled = true
+	cfg.Triage.Duplicate.AutoCloseThreshold = 0.95
+	applyDefaults(cfg)
+
+	// Unset: both follow similarity_threshold
+	if got := cfg.GetSearchThreshold("o", "r"); got != 0.82 {
+		t.Errorf("GetSearchThreshold() = %v, want 0.82", got)
+	}
+	if got := cfg.GetDisplayThreshold("o", "r"); got != 0.82 {
+		t.Errorf("GetDisplayThreshold() = %v, want 0.82", got)
+	}
+
+	cfg.Defaults.DisplayThreshold = 0.80
+	if got := cfg.GetSearchThreshold("o", "r"); got != 0.80 {
+		t.Errorf("GetSearchThreshold() = %v, want display threshold 0.80", got)
+	}
+
+	// Duplicates below the display threshold are still searched for
+	cfg.Triage.Duplicate.AutoCloseThreshold = 0.75
+	if got := cfg.GetSearchThreshold("o", "r"); got != 0.75 {
+		t.Errorf("GetSearchThreshold() = %v, want auto-close threshold 0.75", got)
+	}
+	for _, err := range Validate(cfg) {
+		if ve, ok := err.(ValidationError); ok && ve.Field == "triage.duplicate.auto_close_threshold" {
+			t.Errorf("Validate() rejected auto_close_threshold with display_threshold set: %v", err)
+		}
+	}
+
+	// A repo's similarity_threshold overrides display_threshold for that repo
+	cfg.Repositories = []RepositoryConfig{{Org: "o", Repo: "strict", Enabled: true, SimilarityThreshold: 0.90}}
+	if got := cfg.GetDisplayThreshold("o", "strict"); got != 0.90 {
+		t.Errorf("GetDisplayThreshold() = %v, want the repo override 0.90", got)
+	}
+	if got := cfg.GetSearchThreshold("o", "strict"); got != 0.75 {
+		t.Errorf("GetSearchThreshold() = %v, want auto-close threshold 0.75", got)
+	}
+	cfg.Triage.Duplicate.AutoCloseThreshold = 0.95
+	if got := cfg.GetSearchThreshold("o", "strict"); got != 0.90 {
+		t.Errorf("GetSearchThreshold() = %v, want the repo override 0.90", got)
+	}
+	if got := cfg.GetDisplayThreshold("o", "r"); got != 0.80 {
+		t.Errorf("GetDisplayThreshold() = %v, want display threshold 0.80 for other repos", got)
+	}
+}
+
+func TestApplyDefaults_ExcludeLabelsSurviveLabelCap(t *testing.T) {
+	cfg := &Config{}
+	cfg.Qdrant.MaxIndexedLabels = 5
+	cfg.Qdrant.PriorityLabels = []string{"bug"}
+	cfg.Defaults.SimilarityFilters.ExcludeLabels = []string{"Bug", "wontfix"}
+	applyDefaults(cfg)
+
+	want := []string{"bug", "wontfix"}
+	if !slices.Equal(cfg.Qdrant.PriorityLabels, want) {
+		t.Errorf("PriorityLabels = %v, want %v", cfg.Qdrant.PriorityLabels, want)
+	}
+}
+
+func TestReactionSynonyms(t *testing.T) {
+	cfg := &Config{}
+	cfg.Defaults.DelayedActions.ApproveReaction = "👍"
+	cfg.Defaults.DelayedActions.ApproveReactions = []string{"rocket", "tada"}
+	cfg.Defaults.DelayedActions.CancelReactions = []string{"thumbsdown", "confused"}
+	applyDefaults(cfg)
+
+	if got, want := cfg.Defaults.DelayedActions.Approvals(), []string{"+1", "rocket", "hooray"}; !slices.Equal(got, want) {
+		t.Errorf("Approvals() = %v, want %v", got, want)
+	}
+	if got, want := cfg.Defaults.DelayedActions.Cancellations(), []string{"-1", "-1", "confused"}; !slices.Equal(got, want) {
+		t.Errorf("Cancellations() = %v, want %v", got, want)
+	}
+	if errs := validateReactions(&cfg.Defaults.DelayedActions); len(errs) > 0 {
+		t.Errorf("validateReactions() = %v, want none", errs)
+	}
+
+	cfg.Defaults.DelayedActions.ApproveReactions = []string{"sparkles", "-1"}
+	cfg.Defaults.DelayedActions.ExtendReaction = "rocket"
+	if errs := validateReactions(&cfg.Defaults.DelayedActions); len(errs) != 2 {
+		t.Errorf("validateReactions() = %v, want unknown reaction and approve/cancel overlap", errs)
+	}
 }
@@ -0,0 +1,38 @@
+package config
+
+// DryRunPolicy is the effective dry-run setting for each action category.
+// The global --dry-run flag forces every category into dry-run.
+type DryRunPolicy struct {
+	global bool
+	cfg    DryRunConfig
+}
+
+// NewDryRunPolicy combines the global --dry-run flag with per-category config
+func NewDryRunPolicy(global bool, cfg DryRunConfig) DryRunPolicy {
+	return DryRunPolicy{global: global, cfg: cfg}
+}
+
+// All reports whether every write is skipped
+func (p DryRunPolicy) All() bool {
+	return p.global
+}
+
+// Comments reports whether posting comments is skipped
+func (p DryRunPolicy) Comments() bool {
+	return p.global || p.cfg.Comments
+}
+
+// Labels reports whether adding or removing labels is skipped
+func (p DryRunPolicy) Labels() bool {
+	return p.global || p.cfg.Labels
+}
+
+// Closes reports whether closing issues is skipped
+func (p DryRunPolicy) Closes() bool {
+	return p.global || p.cfg.Closes
+}
+
+// Transfers reports whether transferring issues is skipped
+func (p DryRunPolicy) Transfers() bool {
+	return p.global || p.cfg.Transfers
+}
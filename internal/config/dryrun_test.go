@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestDryRunPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		global bool
+		cfg    DryRunConfig
+		want   [5]bool // all, comments, labels, closes, transfers
+	}{
+		{"live", false, DryRunConfig{}, [5]bool{false, false, false, false, false}},
+		{"global", true, DryRunConfig{}, [5]bool{true, true, true, true, true}},
+		{"staged rollout", false, DryRunConfig{Closes: true, Transfers: true}, [5]bool{false, false, false, true, true}},
+		{"comments only", false, DryRunConfig{Comments: true}, [5]bool{false, true, false, false, false}},
+		{"global overrides config", true, DryRunConfig{Labels: true}, [5]bool{true, true, true, true, true}},
+	}
+
+	for _, tt := range tests {
+		p := NewDryRunPolicy(tt.global, tt.cfg)
+		got := [5]bool{p.All(), p.Comments(), p.Labels(), p.Closes(), p.Transfers()}
+		if got != tt.want {
+			t.Errorf("%s: policy = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
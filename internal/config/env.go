@@ -25,4 +25,5 @@ func expandConfigEnvVars(cfg *Config) {
 	cfg.Qdrant.APIKey = expandEnvVars(cfg.Qdrant.APIKey)
 	cfg.Embedding.Primary.APIKey = expandEnvVars(cfg.Embedding.Primary.APIKey)
 	cfg.Embedding.Fallback.APIKey = expandEnvVars(cfg.Embedding.Fallback.APIKey)
+	cfg.Embedding.CacheDir = expandEnvVars(cfg.Embedding.CacheDir)
 }
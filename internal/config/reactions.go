@@ -0,0 +1,53 @@
+package config
+
+import "slices"
+
+// Reaction contents GitHub accepts, as used by the reactions API
+var knownReactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// reactionAliases maps emoji and shortcode spellings to GitHub's names so
+// "thumbsup" or "👍" in config means "+1"
+var reactionAliases = map[string]string{
+	"👍":           "+1",
+	"thumbsup":    "+1",
+	"thumbs_up":   "+1",
+	"👎":           "-1",
+	"thumbsdown":  "-1",
+	"thumbs_down": "-1",
+	"😄":           "laugh",
+	"smile":       "laugh",
+	"😕":           "confused",
+	"❤️":          "heart",
+	"❤":           "heart",
+	"🎉":           "hooray",
+	"tada":        "hooray",
+	"🚀":           "rocket",
+	"👀":           "eyes",
+}
+
+// NormalizeReaction returns GitHub's name for a reaction spelled as an
+// emoji or shortcode, and other values unchanged
+func NormalizeReaction(name string) string {
+	if canonical, ok := reactionAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// IsKnownReaction reports whether name is a reaction GitHub supports
+func IsKnownReaction(name string) bool {
+	return slices.Contains(knownReactions, name)
+}
+
+// normalizeReactions rewrites every configured reaction to GitHub's name
+func normalizeReactions(d *DelayedActionsConfig) {
+	d.ApproveReaction = NormalizeReaction(d.ApproveReaction)
+	d.CancelReaction = NormalizeReaction(d.CancelReaction)
+	d.ExtendReaction = NormalizeReaction(d.ExtendReaction)
+	for i, r := range d.ApproveReactions {
+		d.ApproveReactions[i] = NormalizeReaction(r)
+	}
+	for i, r := range d.CancelReactions {
+		d.CancelReactions[i] = NormalizeReaction(r)
+	}
+}
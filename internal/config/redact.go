@@ -0,0 +1,22 @@
+package config
+
+// redactedSecret replaces secret values in printed configuration
+const redactedSecret = "***"
+
+// Redacted returns a copy of the config with API keys masked, safe to print
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Qdrant.APIKey = redact(out.Qdrant.APIKey)
+	out.Embedding.Primary.APIKey = redact(out.Embedding.Primary.APIKey)
+	out.Embedding.Fallback.APIKey = redact(out.Embedding.Fallback.APIKey)
+	out.Triage.LLM.APIKey = redact(out.Triage.LLM.APIKey)
+	return &out
+}
+
+// redact masks a non-empty secret, leaving unset values visibly empty
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedSecret
+}
@@ -2,9 +2,16 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/locale"
 )
 
+// collectionPrefixRegex matches prefixes that are safe in a collection name
+var collectionPrefixRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
 // ValidationError represents a configuration validation error
 type ValidationError struct {
 	Field   string
@@ -24,6 +31,24 @@ func Validate(cfg *Config) []error {
 		errs = append(errs, ValidationError{"qdrant.url", "required"})
 	}
 
+	switch cfg.Qdrant.CollectionScope {
+	case "", "org", "repo":
+	default:
+		errs = append(errs, ValidationError{"qdrant.collection_scope", "must be 'org' or 'repo'"})
+	}
+
+	if p := cfg.Qdrant.CollectionPrefix; p != "" && !collectionPrefixRegex.MatchString(p) {
+		errs = append(errs, ValidationError{"qdrant.collection_prefix", "must start with a letter or digit and contain only letters, digits, '_' or '-'"})
+	}
+
+	if q := cfg.Qdrant.Quantization.Quantile; q != 0 && (q < 0.5 || q > 1) {
+		errs = append(errs, ValidationError{"qdrant.quantization.quantile", "must be between 0.5 and 1"})
+	}
+
+	if cfg.Qdrant.MaxIndexedLabels < 0 {
+		errs = append(errs, ValidationError{"qdrant.max_indexed_labels", "must be 0 (no cap) or positive"})
+	}
+
 	// Validate embedding config
 	if cfg.Embedding.Primary.Provider == "" {
 		errs = append(errs, ValidationError{"embedding.primary.provider", "required"})
@@ -35,15 +60,89 @@ func Validate(cfg *Config) []error {
 		errs = append(errs, ValidationError{"embedding.primary.api_key", "required"})
 	}
 
+	if cfg.Embedding.TitleWeight < 0 {
+		errs = append(errs, ValidationError{"embedding.title_weight", "must be positive"})
+	}
+
+	if cfg.Embedding.TimeoutSeconds < 0 {
+		errs = append(errs, ValidationError{"embedding.timeout_seconds", "must be positive"})
+	}
+
+	if cfg.Embedding.MultiVector.TitleWeight < 0 || cfg.Embedding.MultiVector.BodyWeight < 0 {
+		errs = append(errs, ValidationError{"embedding.multi_vector", "weights must not be negative"})
+	}
+
 	// Validate defaults
 	if cfg.Defaults.SimilarityThreshold < 0 || cfg.Defaults.SimilarityThreshold > 1 {
 		errs = append(errs, ValidationError{"defaults.similarity_threshold", "must be between 0 and 1"})
 	}
 
+	if cfg.Defaults.DisplayThreshold < 0 || cfg.Defaults.DisplayThreshold > 1 {
+		errs = append(errs, ValidationError{"defaults.display_threshold", "must be between 0 and 1"})
+	}
+
+	if cfg.Defaults.SameRepoBoost < 0 || cfg.Defaults.SameRepoBoost > 1 {
+		errs = append(errs, ValidationError{"defaults.same_repo_boost", "must be between 0 and 1"})
+	}
+
+	if cfg.Defaults.MaxSimilarToFetch < cfg.Defaults.MaxSimilarToShow {
+		errs = append(errs, ValidationError{"defaults.max_similar_to_fetch", "must be at least max_similar_to_show"})
+	}
+
 	if cfg.Defaults.ClosedIssueWeight < 0 || cfg.Defaults.ClosedIssueWeight > 1 {
 		errs = append(errs, ValidationError{"defaults.closed_issue_weight", "must be between 0 and 1"})
 	}
 
+	if cfg.Defaults.ClaimWindowMinutes < 0 {
+		errs = append(errs, ValidationError{"defaults.claim_window_minutes", "must be non-negative"})
+	}
+
+	errs = append(errs, validateReactions(&cfg.Defaults.DelayedActions)...)
+
+	if cfg.Defaults.ActionCooldowns.LabelHours < 0 || cfg.Defaults.ActionCooldowns.TransferHours < 0 {
+		errs = append(errs, ValidationError{"defaults.action_cooldowns", "hours must not be negative"})
+	}
+
+	if cfg.Defaults.EditDebounceMinutes < 0 {
+		errs = append(errs, ValidationError{"defaults.edit_debounce_minutes", "must not be negative"})
+	}
+
+	if cfg.Defaults.WriteRetry.Attempts < 0 || cfg.Defaults.WriteRetry.BackoffSeconds < 0 {
+		errs = append(errs, ValidationError{"defaults.write_retry", "attempts and backoff_seconds must not be negative"})
+	}
+
+	if cfg.Defaults.DelayedActions.Concurrency < 0 {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.concurrency", "must not be negative"})
+	}
+
+	if cfg.Defaults.CommentApprovalRequired && !cfg.Defaults.DelayedActions.Enabled {
+		errs = append(errs, ValidationError{"defaults.comment_approval_required", "requires delayed_actions.enabled (approvals are processed by process-pending)"})
+	}
+
+	switch cfg.Defaults.SimilarSort {
+	case "", "score", "open-first", "recent":
+	default:
+		errs = append(errs, ValidationError{"defaults.similar_sort", "must be 'score', 'open-first', or 'recent'"})
+	}
+
+	switch cfg.Defaults.CommentStyle {
+	case "", "emoji", "plain":
+	default:
+		errs = append(errs, ValidationError{"defaults.comment_style", "must be 'emoji' or 'plain'"})
+	}
+
+	switch cfg.Defaults.PrivateMatches {
+	case "", "redact", "drop", "show":
+	default:
+		errs = append(errs, ValidationError{"defaults.private_matches", "must be 'redact', 'drop', or 'show'"})
+	}
+
+	switch cfg.Defaults.ClosedIssueStrategy {
+	case "", "weight", "demote", "separate":
+	default:
+		errs = append(errs, ValidationError{"defaults.closed_issue_strategy", "must be 'weight', 'demote', or 'separate'"})
+	}
+
 	// Validate triage config (only if enabled)
 	if cfg.Triage.Enabled {
 		if cfg.Triage.LLM.Provider == "" {
@@ -56,10 +155,34 @@ func Validate(cfg *Config) []error {
 			errs = append(errs, ValidationError{"triage.llm.api_key", "required when triage is enabled"})
 		}
 
+		if cfg.Triage.LLM.TimeoutSeconds < 0 {
+			errs = append(errs, ValidationError{"triage.llm.timeout_seconds", "must be positive"})
+		}
+
+		if t := cfg.Triage.LLM.GetTemperature(); t < 0 || t > 2 {
+			errs = append(errs, ValidationError{"triage.llm.temperature", "must be between 0 and 2"})
+		}
+
 		if cfg.Triage.Classifier.MinConfidence < 0 || cfg.Triage.Classifier.MinConfidence > 1 {
 			errs = append(errs, ValidationError{"triage.classifier.min_confidence", "must be between 0 and 1"})
 		}
 
+		if cfg.Triage.Classifier.RuleSmoothing < 0 {
+			errs = append(errs, ValidationError{"triage.classifier.rule_smoothing", "must be non-negative"})
+		}
+		if cfg.Triage.Classifier.RuleMaxConfidence < 0 || cfg.Triage.Classifier.RuleMaxConfidence > 1 {
+			errs = append(errs, ValidationError{"triage.classifier.rule_max_confidence", "must be between 0 and 1"})
+		}
+
+		for i, label := range cfg.Triage.Classifier.Labels {
+			if label.MinConfidence < 0 || label.MinConfidence > 1 {
+				errs = append(errs, ValidationError{fmt.Sprintf("triage.classifier.labels[%d].min_confidence", i), "must be between 0 and 1"})
+			}
+			if label.MinKeywordMatches < 0 || label.MinKeywordMatches > len(label.Keywords) {
+				errs = append(errs, ValidationError{fmt.Sprintf("triage.classifier.labels[%d].min_keyword_matches", i), "must be between 0 and the number of keywords"})
+			}
+		}
+
 		if cfg.Triage.Quality.MinScore < 0 || cfg.Triage.Quality.MinScore > 1 {
 			errs = append(errs, ValidationError{"triage.quality.min_score", "must be between 0 and 1"})
 		}
@@ -67,6 +190,34 @@ func Validate(cfg *Config) []error {
 		if cfg.Triage.Duplicate.AutoCloseThreshold < 0 || cfg.Triage.Duplicate.AutoCloseThreshold > 1 {
 			errs = append(errs, ValidationError{"triage.duplicate.auto_close_threshold", "must be between 0 and 1"})
 		}
+
+		// Matches between the two thresholds are never found, so they never
+		// auto-close; display_threshold searches low enough to find them
+		if cfg.Triage.Duplicate.Enabled && !cfg.Triage.Duplicate.IndependentSearch && cfg.Defaults.DisplayThreshold == 0 {
+			if threshold := maxSimilarityThreshold(cfg); cfg.Triage.Duplicate.AutoCloseThreshold < threshold {
+				errs = append(errs, ValidationError{"triage.duplicate.auto_close_threshold", fmt.Sprintf(
+					"%.2f is below similarity_threshold %.2f, so duplicates scoring in between are never found; raise it, set defaults.display_threshold, or set triage.duplicate.independent_search",
+					cfg.Triage.Duplicate.AutoCloseThreshold, threshold)})
+			}
+		}
+
+		if cfg.Triage.MaxPromptBodyChars < 0 {
+			errs = append(errs, ValidationError{"triage.max_prompt_body_chars", "must be positive"})
+		}
+
+		if cfg.Triage.Popularity.Threshold < 0 {
+			errs = append(errs, ValidationError{"triage.popularity.threshold", "must be positive"})
+		}
+
+		if cfg.Triage.Spam.Threshold < 0 || cfg.Triage.Spam.Threshold > 1 {
+			errs = append(errs, ValidationError{"triage.spam.threshold", "must be between 0 and 1"})
+		}
+	}
+
+	for i, entry := range cfg.Defaults.CrossRepoExclude {
+		if _, _, ok := strings.Cut(entry, "/"); !ok {
+			errs = append(errs, ValidationError{fmt.Sprintf("defaults.cross_repo_exclude[%d]", i), "must be in format 'org/repo'"})
+		}
 	}
 
 	// Validate repositories
@@ -80,6 +231,10 @@ func Validate(cfg *Config) []error {
 			errs = append(errs, ValidationError{prefix + ".repo", "required"})
 		}
 
+		if repo.Language != "" && !locale.Supported(repo.Language) {
+			errs = append(errs, ValidationError{prefix + ".language", fmt.Sprintf("unsupported language %q (supported: %s)", repo.Language, strings.Join(locale.Languages(), ", "))})
+		}
+
 		// Validate transfer rules
 		for j, rule := range repo.TransferRules {
 			rulePrefix := fmt.Sprintf("%s.transfer_rules[%d]", prefix, j)
@@ -90,13 +245,49 @@ func Validate(cfg *Config) []error {
 				errs = append(errs, ValidationError{rulePrefix + ".target", "must be in format 'org/repo'"})
 			}
 
+			if rule.MinSimilarityToTarget < 0 || rule.MinSimilarityToTarget > 1 {
+				errs = append(errs, ValidationError{rulePrefix + ".min_similarity_to_target", "must be between 0 and 1"})
+			}
+
 			// At least one match condition required
 			if len(rule.Match.Labels) == 0 &&
 				len(rule.Match.TitleContains) == 0 &&
 				len(rule.Match.BodyContains) == 0 &&
-				rule.Match.Author == "" {
+				rule.Match.Author == "" &&
+				len(rule.Match.IssueType) == 0 &&
+				len(rule.Match.Milestone) == 0 &&
+				len(rule.Match.TitleRegex) == 0 &&
+				rule.Match.AuthorRegex == "" {
 				errs = append(errs, ValidationError{rulePrefix + ".match", "at least one condition required"})
 			}
+
+			for k, pattern := range rule.Match.TitleRegex {
+				if _, err := regexp.Compile(pattern); err != nil {
+					errs = append(errs, ValidationError{fmt.Sprintf("%s.match.title_regex[%d]", rulePrefix, k), err.Error()})
+				}
+			}
+			if rule.Match.AuthorRegex != "" {
+				if _, err := regexp.Compile(rule.Match.AuthorRegex); err != nil {
+					errs = append(errs, ValidationError{rulePrefix + ".match.author_regex", err.Error()})
+				}
+			}
+		}
+
+		// Validate area rules
+		for j, rule := range repo.AreaRules {
+			rulePrefix := fmt.Sprintf("%s.area_rules[%d]", prefix, j)
+
+			if len(rule.Keywords) == 0 {
+				errs = append(errs, ValidationError{rulePrefix + ".keywords", "at least one keyword required"})
+			}
+			if rule.Team == "" {
+				errs = append(errs, ValidationError{rulePrefix + ".team", "required"})
+			} else if !strings.Contains(strings.TrimPrefix(rule.Team, "@"), "/") {
+				errs = append(errs, ValidationError{rulePrefix + ".team", "must be in format 'org/team'"})
+			}
+			if rule.Target != "" && !strings.Contains(rule.Target, "/") {
+				errs = append(errs, ValidationError{rulePrefix + ".target", "must be in format 'org/repo'"})
+			}
 		}
 	}
 
@@ -113,6 +304,18 @@ func (cfg *Config) GetRepoConfig(org, repo string) *RepositoryConfig {
 	return nil
 }
 
+// maxSimilarityThreshold returns the highest similarity threshold in use
+// across the defaults and enabled repositories
+func maxSimilarityThreshold(cfg *Config) float64 {
+	threshold := cfg.Defaults.SimilarityThreshold
+	for _, rc := range cfg.Repositories {
+		if rc.Enabled && rc.SimilarityThreshold > threshold {
+			threshold = rc.SimilarityThreshold
+		}
+	}
+	return threshold
+}
+
 // GetSimilarityThreshold returns the threshold for a repo (or default)
 func (cfg *Config) GetSimilarityThreshold(org, repo string) float64 {
 	if rc := cfg.GetRepoConfig(org, repo); rc != nil && rc.SimilarityThreshold > 0 {
@@ -120,3 +323,74 @@ func (cfg *Config) GetSimilarityThreshold(org, repo string) float64 {
 	}
 	return cfg.Defaults.SimilarityThreshold
 }
+
+// GetLanguage returns the comment language for a repo, or "" for English
+func (cfg *Config) GetLanguage(org, repo string) string {
+	if rc := cfg.GetRepoConfig(org, repo); rc != nil {
+		return rc.Language
+	}
+	return ""
+}
+
+// GetDisplayThreshold returns the minimum score of matches shown in comments:
+// the repo's similarity_threshold override, then display_threshold, then the
+// default similarity threshold
+func (cfg *Config) GetDisplayThreshold(org, repo string) float64 {
+	if rc := cfg.GetRepoConfig(org, repo); rc != nil && rc.SimilarityThreshold > 0 {
+		return rc.SimilarityThreshold
+	}
+	if cfg.Defaults.DisplayThreshold > 0 {
+		return cfg.Defaults.DisplayThreshold
+	}
+	return cfg.Defaults.SimilarityThreshold
+}
+
+// GetSearchThreshold returns the threshold similar issues are searched at:
+// the display threshold, or with display_threshold set, the lower of it and
+// the duplicate auto-close threshold
+func (cfg *Config) GetSearchThreshold(org, repo string) float64 {
+	threshold := cfg.GetDisplayThreshold(org, repo)
+	if cfg.Defaults.DisplayThreshold <= 0 {
+		return threshold
+	}
+	if dup := cfg.Triage.Duplicate; cfg.Triage.Enabled && dup.Enabled && dup.AutoCloseThreshold < threshold {
+		threshold = dup.AutoCloseThreshold
+	}
+	return threshold
+}
+
+// validateReactions checks reaction names against GitHub's set and that no
+// reaction means two different things
+func validateReactions(d *DelayedActionsConfig) []error {
+	var errs []error
+	const prefix = "defaults.delayed_actions."
+
+	fields := map[string][]string{
+		"approve_reaction":  {d.ApproveReaction},
+		"cancel_reaction":   {d.CancelReaction},
+		"approve_reactions": d.ApproveReactions,
+		"cancel_reactions":  d.CancelReactions,
+	}
+	if d.ExtendReaction != "" {
+		fields["extend_reaction"] = []string{d.ExtendReaction}
+	}
+	for _, field := range []string{"approve_reaction", "cancel_reaction", "extend_reaction", "approve_reactions", "cancel_reactions"} {
+		for _, r := range fields[field] {
+			if !IsKnownReaction(r) {
+				errs = append(errs, ValidationError{prefix + field, fmt.Sprintf("unknown reaction %q (want one of %s)", r, strings.Join(knownReactions, ", "))})
+			}
+		}
+	}
+
+	approvals, cancellations := d.Approvals(), d.Cancellations()
+	for _, r := range approvals {
+		if slices.Contains(cancellations, r) {
+			errs = append(errs, ValidationError{prefix + "approve_reactions", fmt.Sprintf("%q both approves and cancels", r)})
+		}
+	}
+	if r := d.ExtendReaction; r != "" && (slices.Contains(approvals, r) || slices.Contains(cancellations, r)) {
+		errs = append(errs, ValidationError{prefix + "extend_reaction", "must differ from the approve and cancel reactions"})
+	}
+
+	return errs
+}
@@ -0,0 +1,157 @@
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Action types recorded in the queue
+const (
+	ActionComment     = "comment"
+	ActionAddLabel    = "add_label"
+	ActionRemoveLabel = "remove_label"
+)
+
+// Entry is a side effect that failed and can be replayed later
+type Entry struct {
+	Org         string    `json:"org"`
+	Repo        string    `json:"repo"`
+	IssueNumber int       `json:"issue_number"`
+	Action      string    `json:"action"`
+	Label       string    `json:"label,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	Error       string    `json:"error"`
+	Attempts    int       `json:"attempts"`
+	FailedAt    time.Time `json:"failed_at"`
+}
+
+// Queue is an append-only JSON lines file of failed actions
+type Queue struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewQueue creates a queue backed by the file at path
+func NewQueue(path string) *Queue {
+	return &Queue{path: path}
+}
+
+// Path returns the backing file path
+func (q *Queue) Path() string {
+	return q.path
+}
+
+// Add appends a failed action to the queue
+func (q *Queue) Add(entry Entry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if entry.FailedAt.IsZero() {
+		entry.FailedAt = time.Now()
+	}
+	if entry.Attempts == 0 {
+		entry.Attempts = 1
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead-letter entry: %w", err)
+	}
+
+	f, err := os.OpenFile(q.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead-letter entry: %w", err)
+	}
+	return nil
+}
+
+// Load reads every entry; a missing file is an empty queue
+func (q *Queue) Load() ([]Entry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.load()
+}
+
+// Replace rewrites the queue with entries, removing the file when none remain
+func (q *Queue) Replace(entries []Entry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.write(entries)
+}
+
+// Consume replaces the first n entries, the ones a caller loaded and
+// replayed, with remaining. Entries appended since the load are kept.
+func (q *Queue) Consume(n int, remaining []Entry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	current, err := q.load()
+	if err != nil {
+		return err
+	}
+	n = min(max(n, 0), len(current))
+	return q.write(append(remaining, current[n:]...))
+}
+
+// load reads the queue file; callers hold mu
+func (q *Queue) load() ([]Entry, error) {
+	f, err := os.Open(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // comment bodies can be long
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse dead-letter line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dead-letter file: %w", err)
+	}
+	return entries, nil
+}
+
+// write rewrites the queue file; callers hold mu
+func (q *Queue) write(entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(q.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove dead-letter file: %w", err)
+		}
+		return nil
+	}
+
+	var buf []byte
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal dead-letter entry: %w", err)
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+	if err := os.WriteFile(q.path, buf, 0644); err != nil {
+		return fmt.Errorf("failed to write dead-letter file: %w", err)
+	}
+	return nil
+}
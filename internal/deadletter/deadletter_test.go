@@ -0,0 +1,85 @@
+package deadletter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestQueue_AddLoadReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	q := NewQueue(path)
+
+	entries, err := q.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() on missing file = %v, %v; want empty, nil", entries, err)
+	}
+
+	if err := q.Add(Entry{Org: "org", Repo: "repo", IssueNumber: 1, Action: ActionComment, Body: "line one\nline two", Error: "boom"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := q.Add(Entry{Org: "org", Repo: "repo", IssueNumber: 2, Action: ActionAddLabel, Label: "bug", Error: "boom"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	entries, err = q.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Load() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Body != "line one\nline two" || entries[0].Attempts != 1 || entries[0].FailedAt.IsZero() {
+		t.Errorf("entry 0 = %+v", entries[0])
+	}
+	if entries[1].Label != "bug" {
+		t.Errorf("entry 1 label = %q, want bug", entries[1].Label)
+	}
+
+	if err := q.Replace(entries[1:]); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	entries, _ = q.Load()
+	if len(entries) != 1 || entries[0].IssueNumber != 2 {
+		t.Errorf("after Replace, entries = %+v", entries)
+	}
+
+	if err := q.Replace(nil); err != nil {
+		t.Fatalf("Replace(nil) error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file should be removed once the queue is empty, stat err = %v", err)
+	}
+}
+
+func TestQueue_ConsumeKeepsNewEntries(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "dead-letter.jsonl"))
+	for i := 1; i <= 2; i++ {
+		if err := q.Add(Entry{Org: "org", Repo: "repo", IssueNumber: i, Action: ActionComment}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loaded, err := q.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another run records a failure while the loaded entries are replayed
+	if err := q.Add(Entry{Org: "org", Repo: "repo", IssueNumber: 3, Action: ActionAddLabel, Label: "bug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := loaded[1]
+	failed.Attempts++
+	if err := q.Consume(len(loaded), []Entry{failed}); err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	entries, err := q.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].IssueNumber != 2 || entries[0].Attempts != 2 || entries[1].IssueNumber != 3 {
+		t.Errorf("after Consume, entries = %+v, want #2 retried and #3 kept", entries)
+	}
+}
@@ -0,0 +1,90 @@
+package embedding
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+// DiskCache stores embeddings on disk so reruns don't re-embed unchanged text
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a cache rooted at dir, creating it if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// CacheKey derives a cache key from the provider settings and sanitized text
+func CacheKey(cfg *config.ProviderConfig, text string) string {
+	data := fmt.Sprintf("%s\x00%s\x00%d\x00%s", cfg.Provider, cfg.Model, cfg.Dimensions, CleanText(text))
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
+
+// Get returns a cached embedding if present
+func (c *DiskCache) Get(key string) ([]float32, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var vector []float32
+	if err := json.Unmarshal(data, &vector); err != nil {
+		return nil, false
+	}
+	return vector, true
+}
+
+// Put writes an embedding to the cache
+func (c *DiskCache) Put(key string, vector []float32) error {
+	data, err := json.Marshal(vector)
+	if err != nil {
+		return fmt.Errorf("failed to marshal embedding: %w", err)
+	}
+
+	// Write to a temp file first so a crashed run never leaves a partial entry
+	tmp := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp, c.path(key))
+}
+
+// Clear removes all cached embeddings and returns how many were deleted
+func (c *DiskCache) Clear() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cache dir: %w", err)
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", e.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// path returns the file path for a cache key
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
@@ -0,0 +1,49 @@
+package embedding
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestDiskCache_RoundTrip(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	key := CacheKey(&config.ProviderConfig{Provider: "gemini", Model: "m", Dimensions: 3}, "hello")
+	if _, ok := cache.Get(key); ok {
+		t.Fatalf("Get() on empty cache returned a hit")
+	}
+
+	want := []float32{0.1, 0.2, 0.3}
+	if err := cache.Put(key, want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, ok := cache.Get(key)
+	if !ok || len(got) != len(want) {
+		t.Fatalf("Get() = %v, %v; want %v", got, ok, want)
+	}
+
+	removed, err := cache.Clear()
+	if err != nil || removed != 1 {
+		t.Fatalf("Clear() = %d, %v; want 1, nil", removed, err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Errorf("Get() after Clear() returned a hit")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	gemini := &config.ProviderConfig{Provider: "gemini", Model: "m", Dimensions: 768}
+	openai := &config.ProviderConfig{Provider: "openai", Model: "m", Dimensions: 768}
+
+	if CacheKey(gemini, "text") != CacheKey(gemini, "  text\n\n") {
+		t.Errorf("CacheKey should ignore surrounding whitespace")
+	}
+	if CacheKey(gemini, "text") == CacheKey(openai, "text") {
+		t.Errorf("CacheKey should differ across providers")
+	}
+}
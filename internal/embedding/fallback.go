@@ -2,39 +2,68 @@ package embedding
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 )
 
 // FallbackProvider wraps primary and fallback providers
 type FallbackProvider struct {
-	primary  Provider
-	fallback Provider
+	primary     Provider
+	fallback    Provider
+	primaryCfg  config.ProviderConfig
+	fallbackCfg config.ProviderConfig
+	cache       *DiskCache
+	timeout     time.Duration // Per-call limit; 0 means none
 }
 
 // NewFallbackProvider creates a provider with primary and optional fallback
 func NewFallbackProvider(cfg *config.EmbeddingConfig) (*FallbackProvider, error) {
+	// Mixed dimensions would corrupt a collection the first time the fallback kicks in
+	if hasFallback(cfg) && cfg.Primary.Dimensions != cfg.Fallback.Dimensions {
+		return nil, fmt.Errorf("embedding dimensions differ: primary %s has %d, fallback %s has %d",
+			cfg.Primary.Provider, cfg.Primary.Dimensions, cfg.Fallback.Provider, cfg.Fallback.Dimensions)
+	}
+
 	primary, err := createProvider(&cfg.Primary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create primary provider: %w", err)
 	}
 
 	var fallback Provider
-	if cfg.Fallback.Provider != "" && cfg.Fallback.APIKey != "" {
+	if hasFallback(cfg) {
 		fallback, err = createProvider(&cfg.Fallback)
 		if err != nil {
-			log.Printf("Warning: failed to create fallback provider: %v", err)
+			logging.Warnf("failed to create fallback provider: %v", err)
+		}
+	}
+
+	var cache *DiskCache
+	if cfg.CacheDir != "" {
+		cache, err = NewDiskCache(cfg.CacheDir)
+		if err != nil {
+			logging.Warnf("embedding cache disabled: %v", err)
 		}
 	}
 
 	return &FallbackProvider{
-		primary:  primary,
-		fallback: fallback,
+		primary:     primary,
+		fallback:    fallback,
+		primaryCfg:  cfg.Primary,
+		fallbackCfg: cfg.Fallback,
+		cache:       cache,
+		timeout:     time.Duration(cfg.TimeoutSeconds) * time.Second,
 	}, nil
 }
 
+// hasFallback reports whether a fallback provider is configured
+func hasFallback(cfg *config.EmbeddingConfig) bool {
+	return cfg.Fallback.Provider != "" && cfg.Fallback.APIKey != ""
+}
+
 // createProvider creates a provider based on config
 func createProvider(cfg *config.ProviderConfig) (Provider, error) {
 	switch cfg.Provider {
@@ -49,8 +78,9 @@ func createProvider(cfg *config.ProviderConfig) (Provider, error) {
 
 // Embed generates an embedding with fallback on failure
 func (p *FallbackProvider) Embed(ctx context.Context, text string) ([]float32, error) {
-	embedding, err := p.primary.Embed(ctx, text)
+	embedding, err := p.embedCached(ctx, p.primary, &p.primaryCfg, text)
 	if err == nil {
+		logging.Debugf("Embedded %d chars with %s: %d dimensions", len(text), p.primaryCfg.Provider, len(embedding))
 		return embedding, nil
 	}
 
@@ -58,13 +88,33 @@ func (p *FallbackProvider) Embed(ctx context.Context, text string) ([]float32, e
 		return nil, fmt.Errorf("primary embedding failed (no fallback): %w", err)
 	}
 
-	log.Printf("Primary embedding failed, trying fallback: %v", err)
-	return p.fallback.Embed(ctx, text)
+	logging.Warnf("Primary embedding failed, trying fallback: %v", err)
+	return p.embedCached(ctx, p.fallback, &p.fallbackCfg, text)
+}
+
+// Probe embeds text without the cache and reports which provider answered
+// ("primary" or "fallback"). Used for preflight checks of credentials.
+func (p *FallbackProvider) Probe(ctx context.Context, text string) ([]float32, string, error) {
+	vector, err := p.embed(ctx, p.primary, text)
+	if err == nil {
+		return vector, "primary", nil
+	}
+
+	if p.fallback == nil {
+		return nil, "", fmt.Errorf("primary embedding failed (no fallback): %w", err)
+	}
+
+	logging.Warnf("Primary embedding failed, trying fallback: %v", err)
+	vector, fbErr := p.embed(ctx, p.fallback, text)
+	if fbErr != nil {
+		return nil, "", fmt.Errorf("primary and fallback embedding failed: %v; %w", err, fbErr)
+	}
+	return vector, "fallback", nil
 }
 
 // EmbedBatch generates embeddings for multiple texts with fallback
 func (p *FallbackProvider) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
-	embeddings, err := p.primary.EmbedBatch(ctx, texts)
+	embeddings, err := p.embedBatchCached(ctx, p.primary, &p.primaryCfg, texts)
 	if err == nil {
 		return embeddings, nil
 	}
@@ -73,8 +123,114 @@ func (p *FallbackProvider) EmbedBatch(ctx context.Context, texts []string) ([][]
 		return nil, fmt.Errorf("primary embedding failed (no fallback): %w", err)
 	}
 
-	log.Printf("Primary batch embedding failed, trying fallback: %v", err)
-	return p.fallback.EmbedBatch(ctx, texts)
+	logging.Warnf("Primary batch embedding failed, trying fallback: %v", err)
+	return p.embedBatchCached(ctx, p.fallback, &p.fallbackCfg, texts)
+}
+
+// embed calls provider within the per-call timeout
+func (p *FallbackProvider) embed(ctx context.Context, provider Provider, text string) ([]float32, error) {
+	ctx, cancel := p.callContext(ctx)
+	defer cancel()
+
+	vector, err := provider.Embed(ctx, text)
+	if err != nil {
+		return nil, p.callError(ctx, err)
+	}
+	return vector, nil
+}
+
+// embedBatch calls provider within the per-call timeout
+func (p *FallbackProvider) embedBatch(ctx context.Context, provider Provider, texts []string) ([][]float32, error) {
+	ctx, cancel := p.callContext(ctx)
+	defer cancel()
+
+	vectors, err := provider.EmbedBatch(ctx, texts)
+	if err != nil {
+		return nil, p.callError(ctx, err)
+	}
+	return vectors, nil
+}
+
+// callContext bounds ctx by the per-call timeout, if any
+func (p *FallbackProvider) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.timeout)
+}
+
+// callError reports a call that ran out of time as a timeout
+func (p *FallbackProvider) callError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("embedding call timed out after %s: %w", p.timeout, err)
+	}
+	return err
+}
+
+// embedCached checks the disk cache before calling the provider
+func (p *FallbackProvider) embedCached(ctx context.Context, provider Provider, cfg *config.ProviderConfig, text string) ([]float32, error) {
+	if p.cache == nil {
+		return p.embed(ctx, provider, text)
+	}
+
+	key := CacheKey(cfg, text)
+	if vector, ok := p.cache.Get(key); ok {
+		logging.Debugf("Embedding cache hit (%s)", cfg.Provider)
+		return vector, nil
+	}
+
+	vector, err := p.embed(ctx, provider, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cache.Put(key, vector); err != nil {
+		logging.Warnf("failed to cache embedding: %v", err)
+	}
+	return vector, nil
+}
+
+// embedBatchCached embeds only the texts missing from the disk cache
+func (p *FallbackProvider) embedBatchCached(ctx context.Context, provider Provider, cfg *config.ProviderConfig, texts []string) ([][]float32, error) {
+	if p.cache == nil {
+		return p.embedBatch(ctx, provider, texts)
+	}
+
+	results := make([][]float32, len(texts))
+	keys := make([]string, len(texts))
+	var missTexts []string
+	var missIdx []int
+
+	for i, text := range texts {
+		keys[i] = CacheKey(cfg, text)
+		if vector, ok := p.cache.Get(keys[i]); ok {
+			results[i] = vector
+			continue
+		}
+		missTexts = append(missTexts, text)
+		missIdx = append(missIdx, i)
+	}
+
+	if len(missTexts) == 0 {
+		return results, nil
+	}
+
+	vectors, err := p.embedBatch(ctx, provider, missTexts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(missTexts) {
+		return nil, fmt.Errorf("provider returned %d embeddings for %d texts", len(vectors), len(missTexts))
+	}
+
+	for j, i := range missIdx {
+		results[i] = vectors[j]
+		if err := p.cache.Put(keys[i], vectors[j]); err != nil {
+			logging.Warnf("failed to cache embedding: %v", err)
+		}
+	}
+
+	return results, nil
 }
 
 // Close releases resources
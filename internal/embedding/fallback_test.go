@@ -0,0 +1,90 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestNewFallbackProvider_DimensionMismatch(t *testing.T) {
+	cfg := &config.EmbeddingConfig{
+		Primary:  config.ProviderConfig{Provider: "gemini", APIKey: "key", Dimensions: 768},
+		Fallback: config.ProviderConfig{Provider: "openai", APIKey: "key", Dimensions: 1536},
+	}
+
+	_, err := NewFallbackProvider(cfg)
+	if err == nil || !strings.Contains(err.Error(), "dimensions differ") {
+		t.Fatalf("NewFallbackProvider() error = %v, want a dimension mismatch", err)
+	}
+}
+
+func TestHasFallback(t *testing.T) {
+	tests := []struct {
+		fallback config.ProviderConfig
+		want     bool
+	}{
+		{config.ProviderConfig{}, false},
+		{config.ProviderConfig{Provider: "openai"}, false}, // no key: fallback is skipped, so its dimensions don't matter
+		{config.ProviderConfig{Provider: "openai", APIKey: "key"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := hasFallback(&config.EmbeddingConfig{Fallback: tt.fallback}); got != tt.want {
+			t.Errorf("hasFallback(%+v) = %v, want %v", tt.fallback, got, tt.want)
+		}
+	}
+}
+
+// hangingProvider blocks until its context ends, or answers when not hanging
+type hangingProvider struct {
+	hang bool
+}
+
+func (h *hangingProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := h.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (h *hangingProvider) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if h.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	vectors := make([][]float32, len(texts))
+	for i := range texts {
+		vectors[i] = []float32{1, 0}
+	}
+	return vectors, nil
+}
+
+func (h *hangingProvider) Close() error { return nil }
+
+func TestFallbackProvider_Timeout(t *testing.T) {
+	p := &FallbackProvider{
+		primary:  &hangingProvider{hang: true},
+		fallback: &hangingProvider{},
+		timeout:  10 * time.Millisecond,
+	}
+
+	// A hung primary times out and the fallback answers within its own budget
+	vector, err := p.Embed(context.Background(), "text")
+	if err != nil || len(vector) != 2 {
+		t.Fatalf("Embed() = %v, %v, want the fallback's vector", vector, err)
+	}
+	if _, err := p.EmbedBatch(context.Background(), []string{"a", "b"}); err != nil {
+		t.Fatalf("EmbedBatch() error = %v, want the fallback's vectors", err)
+	}
+
+	p.fallback = nil
+	_, err = p.Embed(context.Background(), "text")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out after 10ms") {
+		t.Errorf("Embed() error = %v, want a timeout", err)
+	}
+}
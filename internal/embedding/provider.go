@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 // Provider defines the interface for embedding generation
@@ -15,7 +18,56 @@ type Provider interface {
 
 // PrepareIssueText combines title and body for embedding
 func PrepareIssueText(title, body string) string {
-	text := fmt.Sprintf("Title: %s\n\nBody: %s", title, body)
+	return prepareWeightedText(title, "", body, 1)
+}
+
+// PrepareIssueTextWithConfig builds the embedding text for an issue honoring
+// embedding settings such as title weighting. Indexing and querying must use
+// the same settings for scores to be comparable.
+func PrepareIssueTextWithConfig(cfg *config.EmbeddingConfig, issue *models.Issue) string {
+	return prepareWeightedText(issue.Title, labelLine(cfg, issue), issue.Body, cfg.TitleWeight)
+}
+
+// PrepareViewTexts builds the separate title and body texts embedded in
+// multi-vector mode; labels, when embedded, go with the title. An empty body
+// falls back to the title so both vectors exist.
+func PrepareViewTexts(cfg *config.EmbeddingConfig, issue *models.Issue) (title, body string) {
+	title = fmt.Sprintf("Title: %s", issue.Title)
+	if labels := labelLine(cfg, issue); labels != "" {
+		title += "\n" + labels
+	}
+	body = strings.TrimSpace(issue.Body)
+	if body == "" {
+		return title, title
+	}
+	return title, TruncateText(body, 6000)
+}
+
+// labelLine renders the issue's labels for the embedded text, or "" when
+// label embedding is off or the issue has none
+func labelLine(cfg *config.EmbeddingConfig, issue *models.Issue) string {
+	if !cfg.EmbedLabels || len(issue.Labels) == 0 {
+		return ""
+	}
+	return "Labels: " + strings.Join(issue.Labels, ", ")
+}
+
+// prepareWeightedText repeats the title titleWeight times ahead of the body
+// so short but precise titles pull more weight in the embedding. The label
+// line, if any, sits before the body so truncation never drops it.
+func prepareWeightedText(title, labels, body string, titleWeight int) string {
+	if titleWeight < 1 {
+		titleWeight = 1
+	}
+
+	var sb strings.Builder
+	for i := 0; i < titleWeight; i++ {
+		sb.WriteString(fmt.Sprintf("Title: %s\n", title))
+	}
+	if labels != "" {
+		sb.WriteString(labels + "\n")
+	}
+	text := fmt.Sprintf("%s\nBody: %s", sb.String(), body)
 
 	// Truncate to ~6000 chars (~1500 tokens) to stay within limits
 	if len(text) > 6000 {
@@ -0,0 +1,24 @@
+package embedding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestPrepareIssueTextWithConfig_EmbedLabels(t *testing.T) {
+	issue := &models.Issue{Title: "Timeouts", Body: "Requests hang", Labels: []string{"kind/bug", "area/networking"}}
+
+	plain := PrepareIssueTextWithConfig(&config.EmbeddingConfig{TitleWeight: 1}, issue)
+	if strings.Contains(plain, "Labels:") {
+		t.Errorf("labels embedded without embed_labels:\n%s", plain)
+	}
+
+	text := PrepareIssueTextWithConfig(&config.EmbeddingConfig{TitleWeight: 1, EmbedLabels: true}, issue)
+	want := "Title: Timeouts\nLabels: kind/bug, area/networking\n\nBody: Requests hang"
+	if text != want {
+		t.Errorf("PrepareIssueTextWithConfig() = %q, want %q", text, want)
+	}
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Bot action kinds tracked for per-action cooldowns
+const (
+	ActionLabels   = "labels"
+	ActionTransfer = "transfer"
+)
+
+var actionLogRegex = regexp.MustCompile(`<!-- simili-actions: (\{.*?\}) -->`)
+
+// ActionLog records when the bot last performed each kind of action on an
+// issue. It is kept as a hidden marker in the bot's comments.
+type ActionLog map[string]time.Time
+
+// Within reports whether action was performed less than hours ago
+func (l ActionLog) Within(action string, hours int) bool {
+	at, ok := l[action]
+	return ok && hours > 0 && time.Since(at) < time.Duration(hours)*time.Hour
+}
+
+// ParseActionLog reads the action log marker from a comment body
+func ParseActionLog(body string) ActionLog {
+	m := actionLogRegex.FindStringSubmatch(body)
+	if m == nil {
+		return nil
+	}
+	var log ActionLog
+	if err := json.Unmarshal([]byte(m[1]), &log); err != nil {
+		return nil
+	}
+	return log
+}
+
+// WithActionLog returns body with its action log marker replaced by log,
+// appending the marker when body has none
+func WithActionLog(body string, log ActionLog) string {
+	data, err := json.Marshal(log)
+	if err != nil {
+		return body
+	}
+	marker := "<!-- simili-actions: " + string(data) + " -->"
+	if loc := actionLogRegex.FindStringIndex(body); loc != nil {
+		return body[:loc[0]] + marker + body[loc[1]:]
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + marker
+}
+
+// RecentBotActions merges the action logs of the bot's comments on an issue,
+// keeping the latest time per action, and returns the ID of the most recent
+// bot comment (0 when there is none)
+func (c *Client) RecentBotActions(ctx context.Context, org, repo string, number int) (ActionLog, int, error) {
+	comments, err := c.ListComments(ctx, org, repo, number)
+	if err != nil {
+		return nil, 0, err
+	}
+	log, lastID := BotActions(comments)
+	return log, lastID, nil
+}
+
+// BotActions is RecentBotActions over comments that were already listed
+func BotActions(comments []Comment) (ActionLog, int) {
+	log := ActionLog{}
+	lastID := 0
+	for _, comment := range comments {
+		if !strings.Contains(comment.Body, botSignature) {
+			continue
+		}
+		lastID = comment.ID
+		for action, at := range ParseActionLog(comment.Body) {
+			if at.After(log[action]) {
+				log[action] = at
+			}
+		}
+	}
+
+	return log, lastID
+}
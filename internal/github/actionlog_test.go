@@ -0,0 +1,35 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActionLog_RoundTrip(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	body := "Summary\n\n---\nPowered by Simili"
+
+	marked := WithActionLog(body, ActionLog{ActionLabels: now})
+	got := ParseActionLog(marked)
+	if !got[ActionLabels].Equal(now) {
+		t.Fatalf("ParseActionLog() = %v, want labels at %v", got, now)
+	}
+
+	// A second write replaces the marker instead of stacking another
+	remarked := WithActionLog(marked, ActionLog{ActionLabels: now, ActionTransfer: now})
+	if n := len(actionLogRegex.FindAllString(remarked, -1)); n != 1 {
+		t.Errorf("found %d markers, want 1", n)
+	}
+
+	log := ParseActionLog(remarked)
+	if !log.Within(ActionTransfer, 1) {
+		t.Errorf("Within(transfer, 1h) = false for an action just now")
+	}
+	if log.Within(ActionTransfer, 0) {
+		t.Errorf("Within(transfer, 0) = true, want cooldown disabled")
+	}
+	log[ActionLabels] = now.Add(-2 * time.Hour)
+	if log.Within(ActionLabels, 1) {
+		t.Errorf("Within(labels, 1h) = true for an action 2h ago")
+	}
+}
@@ -2,18 +2,31 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // Client wraps GitHub API operations
 type Client struct {
 	rest    *api.RESTClient
-	graphql *api.GraphQLClient
+	graphql *api.GraphQLClient // nil when it could not be created; see graphQL
+	gqlErr  error              // Why the GraphQL client is missing
+
+	// pageInterval is the minimum gap between paginated list requests
+	pageInterval time.Duration
+
+	visibilityMu sync.Mutex
+	visibility   map[string]string // Repository visibility by owner/repo
+
+	issues issueCache // Recent GetIssue results; writes to an issue invalidate it
 }
 
 // NewClient creates a new GitHub client using default token (GITHUB_TOKEN env)
@@ -23,14 +36,17 @@ func NewClient() (*Client, error) {
 		return nil, fmt.Errorf("failed to create REST client: %w", err)
 	}
 
-	graphql, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+	// Only transfers and GraphQL listing need this; REST-only workflows
+	// keep working without it
+	graphql, gqlErr := api.DefaultGraphQLClient()
+	if gqlErr != nil {
+		logging.Warnf("GraphQL client unavailable, GraphQL operations will fail: %v", gqlErr)
 	}
 
 	return &Client{
 		rest:    rest,
 		graphql: graphql,
+		gqlErr:  gqlErr,
 	}, nil
 }
 
@@ -47,19 +63,57 @@ func NewClientWithToken(token string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create REST client with token: %w", err)
 	}
 
-	graphql, err := api.NewGraphQLClient(api.ClientOptions{
+	graphql, gqlErr := api.NewGraphQLClient(api.ClientOptions{
 		AuthToken: token,
 	})
+	if gqlErr != nil {
+		logging.Warnf("GraphQL client unavailable, GraphQL operations will fail: %v", gqlErr)
+	}
+
+	return &Client{
+		rest:    rest,
+		graphql: graphql,
+		gqlErr:  gqlErr,
+	}, nil
+}
+
+// NewClientWithTransport creates a client for github.com that sends every
+// request through transport, such as a recording or replaying round tripper
+func NewClientWithTransport(token string, transport http.RoundTripper) (*Client, error) {
+	opts := api.ClientOptions{Host: "github.com", AuthToken: token, Transport: transport}
+
+	rest, err := api.NewRESTClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client with token: %w", err)
+		return nil, fmt.Errorf("failed to create REST client: %w", err)
 	}
+	graphql, gqlErr := api.NewGraphQLClient(opts)
 
 	return &Client{
 		rest:    rest,
 		graphql: graphql,
+		gqlErr:  gqlErr,
 	}, nil
 }
 
+// graphQL returns the GraphQL client, or ErrGraphQLUnavailable when it
+// could not be created
+func (c *Client) graphQL() (*api.GraphQLClient, error) {
+	if c.graphql == nil {
+		return nil, fmt.Errorf("%w: %v", ErrGraphQLUnavailable, c.gqlErr)
+	}
+	return c.graphql, nil
+}
+
+// SetRequestsPerSecond paces paginated listing to at most rps requests per
+// second; zero or negative disables pacing
+func (c *Client) SetRequestsPerSecond(rps int) {
+	if rps <= 0 {
+		c.pageInterval = 0
+		return
+	}
+	c.pageInterval = time.Second / time.Duration(rps)
+}
+
 // Close releases resources
 func (c *Client) Close() error {
 	return nil
@@ -76,15 +130,21 @@ func ParseRepo(fullRepo string) (string, string, error) {
 
 // Issue represents a GitHub issue from the API
 type Issue struct {
-	Number    int       `json:"number"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	State     string    `json:"state"`
-	HTMLURL   string    `json:"html_url"`
-	User      User      `json:"user"`
-	Labels    []Label   `json:"labels"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	HTMLURL   string     `json:"html_url"`
+	RepoURL   string     `json:"repository_url"`
+	User      User       `json:"user"`
+	Labels    []Label    `json:"labels"`
+	Type      *IssueType `json:"type"`
+	Milestone *Milestone `json:"milestone"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// PullRequest is set only on pull requests listed by the issues endpoint
+	PullRequest *struct{} `json:"pull_request,omitempty"`
 }
 
 // User represents a GitHub user
@@ -97,12 +157,40 @@ type Label struct {
 	Name string `json:"name"`
 }
 
+// IssueType represents a GitHub native issue type
+type IssueType struct {
+	Name string `json:"name"`
+}
+
+// Milestone represents a GitHub milestone
+type Milestone struct {
+	Title string `json:"title"`
+}
+
+// milestoneTitle returns the milestone title, or empty when the issue has none
+func milestoneTitle(m *Milestone) string {
+	if m == nil {
+		return ""
+	}
+	return m.Title
+}
+
+// issueTypeName returns the type name, or empty when the issue has no type
+func issueTypeName(t *IssueType) string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
 // Comment represents a GitHub comment
 type Comment struct {
 	ID        int       `json:"id"`
+	NodeID    string    `json:"node_id"`
 	Body      string    `json:"body"`
 	User      User      `json:"user"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // ToModel converts API Issue to models.Issue
@@ -122,6 +210,8 @@ func (i *Issue) ToModel(org, repo string) *models.Issue {
 		Labels:    labels,
 		Author:    i.User.Login,
 		URL:       i.HTMLURL,
+		IssueType: issueTypeName(i.Type),
+		Milestone: milestoneTitle(i.Milestone),
 		CreatedAt: i.CreatedAt,
 		UpdatedAt: i.UpdatedAt,
 	}
@@ -132,10 +222,83 @@ func (c *Client) RepoExists(ctx context.Context, org, repo string) (bool, error)
 	var result struct{}
 	err := c.rest.Get(fmt.Sprintf("repos/%s/%s", org, repo), &result)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		err = wrapError(err)
+		if errors.Is(err, ErrNotFound) {
 			return false, nil
 		}
 		return false, err
 	}
 	return true, nil
 }
+
+// Repository visibilities reported by GetRepoVisibility
+const (
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityInternal = "internal"
+)
+
+// GetRepoVisibility returns whether a repository is public, private, or
+// internal. Results are cached for the life of the client.
+func (c *Client) GetRepoVisibility(ctx context.Context, org, repo string) (string, error) {
+	key := org + "/" + repo
+
+	c.visibilityMu.Lock()
+	cached, ok := c.visibility[key]
+	c.visibilityMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var result struct {
+		Private    bool   `json:"private"`
+		Visibility string `json:"visibility"`
+	}
+	if err := c.rest.Get(fmt.Sprintf("repos/%s/%s", org, repo), &result); err != nil {
+		return "", fmt.Errorf("failed to get visibility of %s: %w", key, wrapError(err))
+	}
+
+	// Older GitHub Enterprise versions only report the private flag
+	visibility := result.Visibility
+	if visibility == "" {
+		visibility = VisibilityPublic
+		if result.Private {
+			visibility = VisibilityPrivate
+		}
+	}
+
+	c.visibilityMu.Lock()
+	if c.visibility == nil {
+		c.visibility = make(map[string]string)
+	}
+	c.visibility[key] = visibility
+	c.visibilityMu.Unlock()
+
+	return visibility, nil
+}
+
+// CurrentUser returns the login of the authenticated token's user
+func (c *Client) CurrentUser(ctx context.Context) (string, error) {
+	var user User
+	if err := c.rest.Get("user", &user); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", wrapError(err))
+	}
+	return user.Login, nil
+}
+
+// HasWriteAccess reports whether a user can push to the repository
+// (write, maintain, or admin permission)
+func (c *Client) HasWriteAccess(ctx context.Context, org, repo, user string) (bool, error) {
+	var result struct {
+		Permission string `json:"permission"`
+	}
+	endpoint := fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", org, repo, user)
+	if err := c.rest.Get(endpoint, &result); err != nil {
+		err = wrapError(err)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get permission for %s: %w", user, err)
+	}
+	return result.Permission == "admin" || result.Permission == "write", nil
+}
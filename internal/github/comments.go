@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/profile"
 )
 
 const botSignature = "Simili"
@@ -17,7 +19,7 @@ func (c *Client) ListComments(ctx context.Context, org, repo string, number int)
 
 	var comments []Comment
 	if err := c.rest.Get(endpoint, &comments); err != nil {
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		return nil, fmt.Errorf("failed to list comments: %w", wrapError(err))
 	}
 
 	return comments, nil
@@ -25,6 +27,9 @@ func (c *Client) ListComments(ctx context.Context, org, repo string, number int)
 
 // PostComment adds a comment to an issue
 func (c *Client) PostComment(ctx context.Context, org, repo string, number int, body string) error {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/comments", org, repo, number)
 
 	payload := map[string]string{"body": body}
@@ -34,28 +39,143 @@ func (c *Client) PostComment(ctx context.Context, org, repo string, number int,
 	}
 
 	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
-		return fmt.Errorf("failed to post comment: %w", err)
+		return fmt.Errorf("failed to post comment: %w", wrapError(err))
 	}
 
 	return nil
 }
 
-// ShouldSkipComment checks if bot recently commented (within cooldown period)
-func (c *Client) ShouldSkipComment(ctx context.Context, org, repo string, number int, cooldownHours int) (bool, error) {
+// CreateComment adds a comment to an issue and returns the created comment
+func (c *Client) CreateComment(ctx context.Context, org, repo string, number int, body string) (*Comment, error) {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/comments", org, repo, number)
+
+	payload := map[string]string{"body": body}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), &comment); err != nil {
+		return nil, fmt.Errorf("failed to post comment: %w", wrapError(err))
+	}
+
+	return &comment, nil
+}
+
+// GetComment fetches a single issue comment by ID
+func (c *Client) GetComment(ctx context.Context, org, repo string, commentID int) (*Comment, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%d", org, repo, commentID)
+
+	var comment Comment
+	if err := c.rest.Get(endpoint, &comment); err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", wrapError(err))
+	}
+
+	return &comment, nil
+}
+
+// Classifiers accepted by MinimizeComment
+const (
+	MinimizeOutdated = "OUTDATED"
+	MinimizeResolved = "RESOLVED"
+)
+
+// MinimizeComment hides the comment with the given GraphQL node ID behind
+// the given classifier, keeping it in the issue history
+func (c *Client) MinimizeComment(ctx context.Context, nodeID string, reason string) error {
+	defer profile.Track(ctx, "github_write")()
+
+	if nodeID == "" {
+		return fmt.Errorf("cannot minimize a comment without its node ID")
+	}
+	gql, err := c.graphQL()
+	if err != nil {
+		return err
+	}
+
+	mutation := `
+		mutation MinimizeComment($subjectId: ID!, $classifier: ReportedContentClassifiers!) {
+			minimizeComment(input: {subjectId: $subjectId, classifier: $classifier}) {
+				minimizedComment {
+					isMinimized
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"subjectId":  nodeID,
+		"classifier": reason,
+	}
+
+	if err := gql.Do(mutation, variables, nil); err != nil {
+		return fmt.Errorf("failed to minimize comment: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// UpdateComment replaces the body of an existing comment
+func (c *Client) UpdateComment(ctx context.Context, org, repo string, commentID int, body string) error {
+	defer profile.Track(ctx, "github_write")()
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%d", org, repo, commentID)
+
+	payload := map[string]string{"body": body}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Patch(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to update comment: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// FindBotComment returns the most recent bot comment containing marker, or nil if none
+func (c *Client) FindBotComment(ctx context.Context, org, repo string, number int, marker string) (*Comment, error) {
+	comments, err := c.ListComments(ctx, org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		if strings.Contains(comments[i].Body, botSignature) && strings.Contains(comments[i].Body, marker) {
+			return &comments[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ShouldSkipComment checks if bot recently commented (within cooldown period).
+// With once set, any bot comment counts regardless of age.
+func (c *Client) ShouldSkipComment(ctx context.Context, org, repo string, number int, cooldownHours int, once bool) (bool, error) {
 	comments, err := c.ListComments(ctx, org, repo, number)
 	if err != nil {
 		return false, err
 	}
+	return CommentCooldownActive(comments, cooldownHours, once), nil
+}
 
+// CommentCooldownActive reports whether comments include a bot comment within
+// the cooldown period (or any bot comment, with once set)
+func CommentCooldownActive(comments []Comment, cooldownHours int, once bool) bool {
 	cutoff := time.Now().Add(-time.Duration(cooldownHours) * time.Hour)
 
 	for _, comment := range comments {
-		if strings.Contains(comment.Body, botSignature) && comment.CreatedAt.After(cutoff) {
-			return true, nil
+		if strings.Contains(comment.Body, botSignature) && (once || comment.CreatedAt.After(cutoff)) {
+			return true
 		}
 	}
 
-	return false, nil
+	return false
 }
 
 // WasAlreadyTransferred checks if issue was already transferred by bot
@@ -97,3 +217,14 @@ func (c *Client) PostCommentWithID(ctx context.Context, org, repo string, number
 
 	return 0, fmt.Errorf("failed to find posted comment")
 }
+
+// DeleteComment removes an issue comment
+func (c *Client) DeleteComment(ctx context.Context, org, repo string, commentID int) error {
+	defer profile.Track(ctx, "github_write")()
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%d", org, repo, commentID)
+	if err := c.rest.Delete(endpoint, nil); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", wrapError(err))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMinimizeComment(t *testing.T) {
+	c, rt := newRecordingClient(t)
+
+	if err := c.MinimizeComment(context.Background(), "IC_kwDOA1", MinimizeOutdated); err != nil {
+		t.Fatalf("MinimizeComment() error = %v", err)
+	}
+
+	if len(rt.requests) != 1 {
+		t.Fatalf("sent %d requests, want a single GraphQL mutation: %+v", len(rt.requests), rt.requests)
+	}
+	req := rt.requests[0]
+	if req.method != http.MethodPost || req.path != "/graphql" {
+		t.Errorf("request = %s %s, want POST /graphql", req.method, req.path)
+	}
+	variables, _ := req.body["variables"].(map[string]any)
+	if variables["subjectId"] != "IC_kwDOA1" || variables["classifier"] != MinimizeOutdated {
+		t.Errorf("variables = %v, want the node ID and OUTDATED", variables)
+	}
+}
+
+func TestMinimizeComment_Errors(t *testing.T) {
+	c, rt := newRecordingClient(t)
+	if err := c.MinimizeComment(context.Background(), "", MinimizeOutdated); err == nil {
+		t.Error("MinimizeComment() without a node ID succeeded, want error")
+	}
+
+	c.graphql, c.gqlErr = nil, errors.New("no token")
+	if err := c.MinimizeComment(context.Background(), "IC_kwDOA1", MinimizeOutdated); !errors.Is(err, ErrGraphQLUnavailable) {
+		t.Errorf("MinimizeComment() without GraphQL error = %v, want ErrGraphQLUnavailable", err)
+	}
+	if len(rt.requests) != 0 {
+		t.Errorf("sent %+v, want no requests", rt.requests)
+	}
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// graphQLDiscussion mirrors the discussion fields selected by ListDiscussions
+type graphQLDiscussion struct {
+	Number    int
+	Title     string
+	Body      string
+	URL       string
+	Closed    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Author    *struct {
+		Login string
+	}
+	Category *struct {
+		Name string
+	}
+	Labels struct {
+		Nodes []struct {
+			Name string
+		}
+	}
+}
+
+// ListDiscussions fetches a repository's discussions through GraphQL, 100 per
+// round-trip, stopping after maxDiscussions when it is positive. Discussions
+// are returned as issues with Kind set to models.KindDiscussion.
+func (c *Client) ListDiscussions(ctx context.Context, org, repo string, maxDiscussions int) ([]*models.Issue, error) {
+	gql, err := c.graphQL()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		query ListDiscussions($owner: String!, $repo: String!, $first: Int!, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				discussions(first: $first, after: $after, orderBy: {field: CREATED_AT, direction: ASC}) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						number
+						title
+						body
+						url
+						closed
+						createdAt
+						updatedAt
+						author {
+							login
+						}
+						category {
+							name
+						}
+						labels(first: 100) {
+							nodes {
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	var all []*models.Issue
+	var cursor *string
+
+	for {
+		pageSize := graphQLIssuePageSize
+		if remaining := maxDiscussions - len(all); maxDiscussions > 0 && remaining < pageSize {
+			pageSize = remaining
+		}
+
+		var result struct {
+			Repository struct {
+				Discussions struct {
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+					Nodes []graphQLDiscussion
+				}
+			}
+		}
+
+		variables := map[string]interface{}{
+			"owner": org,
+			"repo":  repo,
+			"first": pageSize,
+			"after": cursor,
+		}
+
+		if err := gql.Do(query, variables, &result); err != nil {
+			return nil, fmt.Errorf("failed to list discussions: %w", wrapError(err))
+		}
+
+		for _, node := range result.Repository.Discussions.Nodes {
+			all = append(all, node.toModel(org, repo))
+		}
+
+		page := result.Repository.Discussions.PageInfo
+		if !page.HasNextPage || (maxDiscussions > 0 && len(all) >= maxDiscussions) {
+			break
+		}
+		endCursor := page.EndCursor
+		cursor = &endCursor
+	}
+
+	return all, nil
+}
+
+// toModel converts a GraphQL discussion node to models.Issue
+func (d *graphQLDiscussion) toModel(org, repo string) *models.Issue {
+	labels := make([]string, len(d.Labels.Nodes))
+	for j, l := range d.Labels.Nodes {
+		labels[j] = l.Name
+	}
+
+	var author, category string
+	if d.Author != nil {
+		author = d.Author.Login
+	}
+	if d.Category != nil {
+		category = d.Category.Name
+	}
+
+	state := "open"
+	if d.Closed {
+		state = "closed"
+	}
+
+	return &models.Issue{
+		Org:       org,
+		Repo:      repo,
+		Number:    d.Number,
+		Title:     d.Title,
+		Body:      d.Body,
+		State:     state,
+		Labels:    labels,
+		Author:    author,
+		URL:       d.URL,
+		IssueType: category, // The category plays the role of the issue type
+		Kind:      models.KindDiscussion,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Sentinel errors classifying GitHub API failures; match them with errors.Is
+var (
+	ErrNotFound     = errors.New("github: not found")
+	ErrRateLimited  = errors.New("github: rate limited")
+	ErrForbidden    = errors.New("github: forbidden")
+	ErrUnauthorized = errors.New("github: unauthorized")
+	// ErrGraphQLUnavailable is returned by GraphQL-only operations when the
+	// GraphQL client could not be created
+	ErrGraphQLUnavailable = errors.New("github: GraphQL client unavailable")
+	// ErrTransferred is returned when an issue now lives in another repository
+	ErrTransferred = errors.New("github: issue transferred")
+)
+
+// TransferredError reports where a transferred issue lives now
+type TransferredError struct {
+	Org    string
+	Repo   string
+	Number int
+	URL    string
+}
+
+func (e *TransferredError) Error() string {
+	return fmt.Sprintf("github: issue transferred to %s/%s#%d", e.Org, e.Repo, e.Number)
+}
+
+// Unwrap lets errors.Is match ErrTransferred
+func (e *TransferredError) Unwrap() error {
+	return ErrTransferred
+}
+
+// APIError is a GitHub API failure tagged with its classification
+type APIError struct {
+	StatusCode int
+	Kind       error
+	Err        error
+	RetryAfter time.Duration // Wait requested by the Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes both the classification sentinel and the underlying error
+func (e *APIError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// wrapError classifies a REST or GraphQL error into an APIError.
+// Errors that don't map to a known class are returned unchanged.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if kind := classifyHTTPError(httpErr); kind != nil {
+			return &APIError{
+				StatusCode: httpErr.StatusCode,
+				Kind:       kind,
+				Err:        err,
+				RetryAfter: parseRetryAfter(httpErr.Headers.Get("Retry-After")),
+			}
+		}
+		return err
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, item := range gqlErr.Errors {
+			var kind error
+			switch item.Type {
+			case "NOT_FOUND":
+				kind = ErrNotFound
+			case "RATE_LIMITED":
+				kind = ErrRateLimited
+			case "FORBIDDEN":
+				kind = ErrForbidden
+			}
+			if kind != nil {
+				return &APIError{Kind: kind, Err: err}
+			}
+		}
+	}
+
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// classifyHTTPError maps a REST response status to a sentinel error
+func classifyHTTPError(err *api.HTTPError) error {
+	switch err.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		// GitHub reports both primary and secondary (abuse) rate limits as 403
+		message := strings.ToLower(err.Message)
+		if err.Headers.Get("X-RateLimit-Remaining") == "0" ||
+			strings.Contains(message, "rate limit") || strings.Contains(message, "abuse") {
+			return ErrRateLimited
+		}
+		return ErrForbidden
+	}
+	return nil
+}
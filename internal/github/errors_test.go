@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "not found",
+			err:  &api.HTTPError{StatusCode: http.StatusNotFound},
+			want: ErrNotFound,
+		},
+		{
+			name: "too many requests",
+			err:  &api.HTTPError{StatusCode: http.StatusTooManyRequests},
+			want: ErrRateLimited,
+		},
+		{
+			name: "secondary rate limit",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden, Message: "You have exceeded a secondary rate limit"},
+			want: ErrRateLimited,
+		},
+		{
+			name: "abuse detection",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden, Message: "You have triggered an abuse detection mechanism"},
+			want: ErrRateLimited,
+		},
+		{
+			name: "forbidden",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden, Message: "Resource not accessible by integration"},
+			want: ErrForbidden,
+		},
+		{
+			name: "graphql not found",
+			err:  &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "NOT_FOUND"}}},
+			want: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed: %w", wrapError(tt.err))
+			if !errors.Is(wrapped, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false", wrapped, tt.want)
+			}
+			if !errors.Is(wrapped, tt.err) {
+				t.Errorf("wrapped error lost the original cause")
+			}
+		})
+	}
+}
+
+func TestWrapError_Unclassified(t *testing.T) {
+	err := &api.HTTPError{StatusCode: http.StatusInternalServerError}
+	if got := wrapError(err); got != error(err) {
+		t.Errorf("wrapError() should return unclassified errors unchanged")
+	}
+}
+
+func TestWrapError_RetryAfter(t *testing.T) {
+	err := &api.HTTPError{
+		StatusCode: http.StatusForbidden,
+		Message:    "You have exceeded a secondary rate limit",
+		Headers:    http.Header{"Retry-After": []string{"30"}},
+	}
+
+	var apiErr *APIError
+	if !errors.As(wrapError(err), &apiErr) {
+		t.Fatalf("wrapError() did not return an APIError")
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", apiErr.RetryAfter)
+	}
+}
+
+func TestGraphQLUnavailable(t *testing.T) {
+	c := &Client{gqlErr: errors.New("no token")}
+
+	_, err := c.TransferIssue(context.Background(), "org", "repo", 1, "org/other")
+	if !errors.Is(err, ErrGraphQLUnavailable) {
+		t.Errorf("TransferIssue() error = %v, want ErrGraphQLUnavailable", err)
+	}
+}
+
+func TestTransferredError(t *testing.T) {
+	var err error = &TransferredError{Org: "org", Repo: "other", Number: 7}
+	wrapped := fmt.Errorf("failed to get issue: %w", err)
+
+	if !errors.Is(wrapped, ErrTransferred) {
+		t.Error("errors.Is(err, ErrTransferred) = false, want true")
+	}
+	var te *TransferredError
+	if !errors.As(wrapped, &te) || te.Repo != "other" || te.Number != 7 {
+		t.Errorf("errors.As() = %+v, want other#7", te)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
@@ -15,17 +16,30 @@ type Event struct {
 	Comment *EventComment `json:"comment"`
 	Repo    *EventRepo    `json:"repository"`
 	Sender  *EventSender  `json:"sender"`
+	Changes *EventChanges `json:"changes"`
+	Label   *Label        `json:"label"` // The label added or removed by labeled/unlabeled events
+}
+
+// EventChanges holds the changes reported by an event. Transferred events
+// carry the issue's new location here.
+type EventChanges struct {
+	NewIssue      *EventIssue `json:"new_issue"`
+	NewRepository *EventRepo  `json:"new_repository"`
 }
 
 // EventIssue represents issue data in an event
 type EventIssue struct {
-	Number  int          `json:"number"`
-	Title   string       `json:"title"`
-	Body    string       `json:"body"`
-	State   string       `json:"state"`
-	HTMLURL string       `json:"html_url"`
-	User    *EventSender `json:"user"`
-	Labels  []Label      `json:"labels"`
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	HTMLURL   string       `json:"html_url"`
+	User      *EventSender `json:"user"`
+	Labels    []Label      `json:"labels"`
+	Type      *IssueType   `json:"type"`
+	Milestone *Milestone   `json:"milestone"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 // EventRepo represents repository data in an event
@@ -74,15 +88,19 @@ func (e *Event) ToIssue() *models.Issue {
 	}
 
 	return &models.Issue{
-		Org:    e.Repo.Owner.Login,
-		Repo:   e.Repo.Name,
-		Number: e.Issue.Number,
-		Title:  e.Issue.Title,
-		Body:   e.Issue.Body,
-		State:  e.Issue.State,
-		Labels: labels,
-		Author: author,
-		URL:    e.Issue.HTMLURL,
+		Org:       e.Repo.Owner.Login,
+		Repo:      e.Repo.Name,
+		Number:    e.Issue.Number,
+		Title:     e.Issue.Title,
+		Body:      e.Issue.Body,
+		State:     e.Issue.State,
+		Labels:    labels,
+		Author:    author,
+		URL:       e.Issue.HTMLURL,
+		IssueType: issueTypeName(e.Issue.Type),
+		Milestone: milestoneTitle(e.Issue.Milestone),
+		CreatedAt: e.Issue.CreatedAt,
+		UpdatedAt: e.Issue.UpdatedAt,
 	}
 }
 
@@ -116,6 +134,26 @@ func (e *Event) IsReopenedEvent() bool {
 	return e.Action == "reopened"
 }
 
+// IsTransferredEvent checks if this is an issue transferred event
+func (e *Event) IsTransferredEvent() bool {
+	return e.Action == "transferred"
+}
+
+// IsLabeledEvent checks if this is an issue labeled event
+func (e *Event) IsLabeledEvent() bool {
+	return e.Action == "labeled"
+}
+
+// TransferredIssue returns the issue at its new location for a transferred
+// event, or nil when the event doesn't carry it
+func (e *Event) TransferredIssue() *models.Issue {
+	if e.Changes == nil || e.Changes.NewIssue == nil || e.Changes.NewRepository == nil {
+		return nil
+	}
+	moved := &Event{Issue: e.Changes.NewIssue, Repo: e.Changes.NewRepository}
+	return moved.ToIssue()
+}
+
 // IsIssueCommentEvent checks if this is an issue comment event
 func (e *Event) IsIssueCommentEvent() bool {
 	return e.Comment != nil
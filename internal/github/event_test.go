@@ -0,0 +1,37 @@
+package github
+
+import "testing"
+
+func TestEvent_IsLabeledEvent(t *testing.T) {
+	for action, want := range map[string]bool{"labeled": true, "unlabeled": false, "opened": false} {
+		if got := (&Event{Action: action}).IsLabeledEvent(); got != want {
+			t.Errorf("IsLabeledEvent(%q) = %v, want %v", action, got, want)
+		}
+	}
+}
+
+func TestEvent_TransferredIssue(t *testing.T) {
+	newRepo := &EventRepo{Name: "other"}
+	newRepo.Owner.Login = "octo"
+	event := &Event{
+		Action: "transferred",
+		Changes: &EventChanges{
+			NewIssue:      &EventIssue{Number: 7, Title: "Moved", HTMLURL: "https://github.com/octo/other/issues/7"},
+			NewRepository: newRepo,
+		},
+	}
+
+	if !event.IsTransferredEvent() {
+		t.Error("IsTransferredEvent() = false, want true")
+	}
+	moved := event.TransferredIssue()
+	if moved == nil || moved.Org != "octo" || moved.Repo != "other" || moved.Number != 7 || moved.Title != "Moved" {
+		t.Errorf("TransferredIssue() = %+v, want octo/other#7", moved)
+	}
+
+	for _, changes := range []*EventChanges{nil, {NewIssue: &EventIssue{Number: 7}}, {NewRepository: newRepo}} {
+		if got := (&Event{Action: "transferred", Changes: changes}).TransferredIssue(); got != nil {
+			t.Errorf("TransferredIssue() with changes %+v = %+v, want nil", changes, got)
+		}
+	}
+}
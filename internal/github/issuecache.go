@@ -0,0 +1,125 @@
+package github
+
+import (
+	"container/list"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// GetIssue results are kept this long, for at most this many issues, so
+// repeated reads within one run don't re-hit the API
+const (
+	issueCacheSize = 256
+	issueCacheTTL  = 5 * time.Minute
+)
+
+// issueCache is a small LRU of fetched issues. The zero value is ready to use.
+type issueCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   list.List // Most recently used at the front
+	now     func() time.Time
+
+	// gen counts invalidations, so a fetch that raced a write can tell its
+	// result may predate the write
+	gen uint64
+}
+
+type issueCacheEntry struct {
+	key     string
+	issue   models.Issue
+	fetched time.Time
+}
+
+func issueKey(org, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", org, repo, number)
+}
+
+func (ic *issueCache) clock() time.Time {
+	if ic.now != nil {
+		return ic.now()
+	}
+	return time.Now()
+}
+
+// get returns a copy of the cached issue, or nil when missing or stale
+func (ic *issueCache) get(key string) *models.Issue {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	el, ok := ic.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := el.Value.(*issueCacheEntry)
+	if ic.clock().Sub(entry.fetched) > issueCacheTTL {
+		ic.order.Remove(el)
+		delete(ic.entries, key)
+		return nil
+	}
+	ic.order.MoveToFront(el)
+	return cloneIssue(&entry.issue)
+}
+
+// generation returns the current invalidation count; pass it to put
+func (ic *issueCache) generation() uint64 {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return ic.gen
+}
+
+// put stores a copy of issue, evicting the least recently used entry when
+// full. gen is the generation read before the issue was fetched; when an
+// invalidation happened since, the issue may be stale and is not stored.
+func (ic *issueCache) put(key string, issue *models.Issue, gen uint64) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if gen != ic.gen {
+		return
+	}
+
+	entry := &issueCacheEntry{key: key, issue: *cloneIssue(issue), fetched: ic.clock()}
+	if el, ok := ic.entries[key]; ok {
+		el.Value = entry
+		ic.order.MoveToFront(el)
+		return
+	}
+	if ic.entries == nil {
+		ic.entries = make(map[string]*list.Element)
+	}
+	ic.entries[key] = ic.order.PushFront(entry)
+	if ic.order.Len() > issueCacheSize {
+		oldest := ic.order.Back()
+		ic.order.Remove(oldest)
+		delete(ic.entries, oldest.Value.(*issueCacheEntry).key)
+	}
+}
+
+// invalidate drops the issue so the next GetIssue sees a mutation's effect
+func (ic *issueCache) invalidate(key string) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	ic.gen++
+	if el, ok := ic.entries[key]; ok {
+		ic.order.Remove(el)
+		delete(ic.entries, key)
+	}
+}
+
+// cloneIssue copies issue so callers can't modify cached labels
+func cloneIssue(issue *models.Issue) *models.Issue {
+	clone := *issue
+	clone.Labels = slices.Clone(issue.Labels)
+	return &clone
+}
+
+// forgetIssue invalidates the cached copy of an issue after a write to it
+func (c *Client) forgetIssue(org, repo string, number int) {
+	c.issues.invalidate(issueKey(org, repo, number))
+}
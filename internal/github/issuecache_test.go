@@ -0,0 +1,83 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestIssueCache(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ic := &issueCache{now: func() time.Time { return now }}
+	key := issueKey("org", "repo", 1)
+
+	if ic.get(key) != nil {
+		t.Fatal("empty cache returned an issue")
+	}
+
+	ic.put(key, &models.Issue{Number: 1, Labels: []string{"bug"}}, ic.generation())
+	got := ic.get(key)
+	if got == nil || got.Number != 1 {
+		t.Fatalf("get() = %+v, want issue #1", got)
+	}
+
+	// Callers may modify what they get back without touching the cache
+	got.Labels[0] = "changed"
+	if again := ic.get(key); again.Labels[0] != "bug" {
+		t.Errorf("cached labels were modified: %v", again.Labels)
+	}
+
+	ic.invalidate(key)
+	if ic.get(key) != nil {
+		t.Error("invalidated issue still cached")
+	}
+
+	ic.put(key, &models.Issue{Number: 1}, ic.generation())
+	now = now.Add(issueCacheTTL + time.Second)
+	if ic.get(key) != nil {
+		t.Error("stale issue still cached")
+	}
+}
+
+func TestIssueCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ic := &issueCache{}
+	for i := 0; i < issueCacheSize; i++ {
+		ic.put(issueKey("org", "repo", i), &models.Issue{Number: i}, ic.generation())
+	}
+
+	// Touch #0 so #1 becomes the oldest
+	ic.get(issueKey("org", "repo", 0))
+	ic.put(issueKey("org", "repo", issueCacheSize), &models.Issue{Number: issueCacheSize}, ic.generation())
+
+	if ic.get(issueKey("org", "repo", 1)) != nil {
+		t.Error("least recently used issue was not evicted")
+	}
+	for _, n := range []int{0, 2, issueCacheSize} {
+		if ic.get(issueKey("org", "repo", n)) == nil {
+			t.Errorf("issue #%d was evicted", n)
+		}
+	}
+	if len(ic.entries) != issueCacheSize {
+		t.Errorf("cache holds %d entries, want %d", len(ic.entries), issueCacheSize)
+	}
+}
+
+func TestIssueCacheSkipsFetchThatRacedAWrite(t *testing.T) {
+	ic := &issueCache{}
+	key := issueKey("org", "repo", 1)
+
+	// A fetch starts, a write to the issue lands and invalidates, then the
+	// fetch finishes with what it read before the write
+	gen := ic.generation()
+	ic.invalidate(key)
+	ic.put(key, &models.Issue{Number: 1, Labels: []string{"stale"}}, gen)
+	if got := ic.get(key); got != nil {
+		t.Errorf("get() = %+v, want the raced fetch not cached", got)
+	}
+
+	ic.put(key, &models.Issue{Number: 1}, ic.generation())
+	if ic.get(key) == nil {
+		t.Error("fetch after the write was not cached")
+	}
+}
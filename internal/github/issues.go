@@ -1,12 +1,18 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/profile"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
@@ -20,6 +26,14 @@ type ListOptions struct {
 
 // ListIssues fetches issues from a repository
 func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOptions) ([]*models.Issue, error) {
+	issues, _, err := c.listIssuesPage(ctx, org, repo, opts)
+	return issues, err
+}
+
+// listIssuesPage fetches one page of issues, also returning how many
+// entries the page held before pull requests were dropped so callers can
+// tell a short page from the last one
+func (c *Client) listIssuesPage(ctx context.Context, org, repo string, opts ListOptions) ([]*models.Issue, int, error) {
 	if opts.PerPage == 0 {
 		opts.PerPage = 100
 	}
@@ -44,7 +58,7 @@ func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOpti
 
 	var apiIssues []Issue
 	if err := c.rest.Get(endpoint, &apiIssues); err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
+		return nil, 0, fmt.Errorf("failed to list issues: %w", wrapError(err))
 	}
 
 	issues := make([]*models.Issue, 0, len(apiIssues))
@@ -56,28 +70,96 @@ func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOpti
 		issues = append(issues, ai.ToModel(org, repo))
 	}
 
-	return issues, nil
+	return issues, len(apiIssues), nil
 }
 
-// GetIssue fetches a single issue
+// GetIssue fetches a single issue. Results are briefly cached per client and
+// dropped when the client writes to the issue.
 func (c *Client) GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error) {
+	key := issueKey(org, repo, number)
+	if cached := c.issues.get(key); cached != nil {
+		return cached, nil
+	}
+	gen := c.issues.generation()
+
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, number)
 
 	var ai Issue
 	if err := c.rest.Get(endpoint, &ai); err != nil {
-		return nil, fmt.Errorf("failed to get issue: %w", err)
+		return nil, fmt.Errorf("failed to get issue: %w", wrapError(err))
+	}
+
+	// GitHub redirects requests for transferred issues to their new home
+	if owner, name, ok := repoFromURL(ai.RepoURL); ok && !strings.EqualFold(owner+"/"+name, org+"/"+repo) {
+		return nil, &TransferredError{Org: owner, Repo: name, Number: ai.Number, URL: ai.HTMLURL}
+	}
+
+	issue := ai.ToModel(org, repo)
+	c.issues.put(key, issue, gen)
+	return issue, nil
+}
+
+// CreateIssue opens a new issue and returns it, including its number and URL
+func (c *Client) CreateIssue(ctx context.Context, org, repo, title, body string, labels []string) (*models.Issue, error) {
+	defer profile.Track(ctx, "github_write")()
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues", org, repo)
+
+	payload := struct {
+		Title  string   `json:"title"`
+		Body   string   `json:"body,omitempty"`
+		Labels []string `json:"labels,omitempty"`
+	}{Title: title, Body: body, Labels: labels}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var ai Issue
+	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), &ai); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", wrapError(err))
 	}
 
 	return ai.ToModel(org, repo), nil
 }
 
-// ListAllIssues fetches all issues using pagination
-func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state string, batchSize int) ([]*models.Issue, error) {
+// ParseIssueURL splits an issue URL such as https://github.com/org/repo/issues/42
+func ParseIssueURL(issueURL string) (org, repo string, number int, err error) {
+	parts := strings.Split(strings.TrimSuffix(issueURL, "/"), "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "issues" {
+		return "", "", 0, fmt.Errorf("invalid issue URL: %s", issueURL)
+	}
+	number, err = strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue URL: %s", issueURL)
+	}
+	return parts[len(parts)-4], parts[len(parts)-3], number, nil
+}
+
+// repoFromURL extracts the owner and name from a repository API URL such as
+// https://api.github.com/repos/owner/name
+func repoFromURL(repoURL string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSuffix(repoURL, "/"), "/")
+	if len(parts) < 3 || parts[len(parts)-3] != "repos" {
+		return "", "", false
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], true
+}
+
+// ListAllIssues fetches all issues using pagination, stopping after
+// maxIssues when it is positive
+func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state string, batchSize, maxIssues int) ([]*models.Issue, error) {
 	var allIssues []*models.Issue
 	page := 1
 
 	for {
-		issues, err := c.ListIssues(ctx, org, repo, ListOptions{
+		if page > 1 {
+			if err := sleepCtx(ctx, c.pageInterval); err != nil {
+				return nil, err
+			}
+		}
+
+		issues, fetched, err := c.listIssuesWithBackoff(ctx, org, repo, ListOptions{
 			State:   state,
 			PerPage: batchSize,
 			Page:    page,
@@ -86,13 +168,18 @@ func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state stri
 			return nil, err
 		}
 
-		if len(issues) == 0 {
+		if fetched == 0 {
 			break
 		}
 
 		allIssues = append(allIssues, issues...)
 
-		if len(issues) < batchSize {
+		if maxIssues > 0 && len(allIssues) >= maxIssues {
+			allIssues = allIssues[:maxIssues]
+			break
+		}
+
+		if fetched < batchSize {
 			break
 		}
 		page++
@@ -101,14 +188,65 @@ func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state stri
 	return allIssues, nil
 }
 
-// isPullRequest checks if an issue is actually a pull request.
-// NOTE: The GitHub /issues endpoint includes pull requests, but the go-gh Issue
-// struct does not expose the "pull_request" field from the API response.
-// As a result, this function always returns false and PRs will be indexed
-// alongside issues. This is acceptable for similarity search purposes since
-// PRs often contain relevant context about code changes.
+// rateLimitRetries is how many times a rate-limited page is retried
+const rateLimitRetries = 3
+
+// defaultRateLimitWait is used when a rate-limited response has no
+// Retry-After; GitHub asks clients to wait at least a minute
+const defaultRateLimitWait = time.Minute
+
+// listIssuesWithBackoff fetches one page, sleeping and retrying when GitHub
+// answers with a (secondary) rate limit
+func (c *Client) listIssuesWithBackoff(ctx context.Context, org, repo string, opts ListOptions) ([]*models.Issue, int, error) {
+	var issues []*models.Issue
+	var fetched int
+	err := withRateLimitBackoff(ctx, fmt.Sprintf("%s/%s page %d", org, repo, opts.Page), func() error {
+		var err error
+		issues, fetched, err = c.listIssuesPage(ctx, org, repo, opts)
+		return err
+	})
+	return issues, fetched, err
+}
+
+// withRateLimitBackoff runs one listing request, sleeping for the requested
+// Retry-After (or a minute) and retrying when GitHub rate limits it
+func withRateLimitBackoff(ctx context.Context, what string, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || !errors.Is(err, ErrRateLimited) || attempt >= rateLimitRetries {
+			return err
+		}
+
+		wait := defaultRateLimitWait
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		logging.Warnf("rate limited listing %s, retrying in %s (attempt %d/%d)",
+			what, wait, attempt+1, rateLimitRetries)
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepCtx waits for d or until ctx is cancelled
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// isPullRequest reports whether an entry from the /issues endpoint, which
+// also lists pull requests, is actually a pull request
 func (i *Issue) isPullRequest() bool {
-	return false
+	return i.PullRequest != nil
 }
 
 // ListIssuesByLabel fetches issues with a specific label with pagination
@@ -130,7 +268,7 @@ func (c *Client) ListIssuesByLabel(ctx context.Context, org, repo, label string)
 
 		var apiIssues []Issue
 		if err := c.rest.Get(endpoint, &apiIssues); err != nil {
-			return nil, fmt.Errorf("failed to list issues by label: %w", err)
+			return nil, fmt.Errorf("failed to list issues by label: %w", wrapError(err))
 		}
 
 		if len(apiIssues) == 0 {
@@ -138,6 +276,7 @@ func (c *Client) ListIssuesByLabel(ctx context.Context, org, repo, label string)
 		}
 
 		for _, ai := range apiIssues {
+			// Label listings drive re-triage, which must never act on pull requests
 			if ai.isPullRequest() {
 				continue
 			}
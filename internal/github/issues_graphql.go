@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// graphQLIssuePageSize is the maximum page size for the issues connection
+const graphQLIssuePageSize = 100
+
+// graphQLIssue mirrors the issue fields selected by ListAllIssuesGraphQL
+type graphQLIssue struct {
+	Number    int
+	Title     string
+	Body      string
+	State     string
+	URL       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Author    *struct {
+		Login string
+	}
+	IssueType *struct {
+		Name string
+	}
+	Milestone *struct {
+		Title string
+	}
+	Labels struct {
+		Nodes []struct {
+			Name string
+		}
+	}
+}
+
+// ListAllIssuesGraphQL fetches all issues through the GraphQL issues
+// connection, 100 per round-trip, stopping after maxIssues when it is
+// positive. Unlike the REST endpoint it never returns pull requests.
+func (c *Client) ListAllIssuesGraphQL(ctx context.Context, org, repo string, state string, maxIssues int) ([]*models.Issue, error) {
+	issues, _, err := c.ListIssuesGraphQLAfter(ctx, org, repo, state, "", maxIssues)
+	return issues, err
+}
+
+// ListIssuesGraphQLAfter is ListAllIssuesGraphQL starting after the issue
+// at cursor (from the start when empty). Issues come oldest first, and the
+// returned cursors hold each issue's position in the same order, so a later
+// listing can continue from any of them.
+func (c *Client) ListIssuesGraphQLAfter(ctx context.Context, org, repo string, state string, after string, maxIssues int) ([]*models.Issue, []string, error) {
+	gql, err := c.graphQL()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		query ListIssues($owner: String!, $repo: String!, $states: [IssueState!], $first: Int!, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: $first, after: $after, states: $states, orderBy: {field: CREATED_AT, direction: ASC}) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					edges {
+						cursor
+						node {
+							number
+							title
+							body
+							state
+							url
+							createdAt
+							updatedAt
+							author {
+								login
+							}
+							issueType {
+								name
+							}
+							milestone {
+								title
+							}
+							labels(first: 100) {
+								nodes {
+									name
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	var states []string
+	switch state {
+	case "open":
+		states = []string{"OPEN"}
+	case "closed":
+		states = []string{"CLOSED"}
+	}
+
+	var allIssues []*models.Issue
+	var cursors []string
+	var cursor *string
+	if after != "" {
+		cursor = &after
+	}
+
+	for page := 1; ; page++ {
+		if page > 1 {
+			if err := sleepCtx(ctx, c.pageInterval); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		pageSize := graphQLIssuePageSize
+		if remaining := maxIssues - len(allIssues); maxIssues > 0 && remaining < pageSize {
+			pageSize = remaining
+		}
+
+		var result struct {
+			Repository struct {
+				Issues struct {
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+					Edges []struct {
+						Cursor string
+						Node   graphQLIssue
+					}
+				}
+			}
+		}
+
+		variables := map[string]interface{}{
+			"owner":  org,
+			"repo":   repo,
+			"states": states,
+			"first":  pageSize,
+			"after":  cursor,
+		}
+
+		err := withRateLimitBackoff(ctx, fmt.Sprintf("%s/%s page %d via GraphQL", org, repo, page), func() error {
+			return wrapError(gql.Do(query, variables, &result))
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list issues via GraphQL: %w", err)
+		}
+
+		for _, edge := range result.Repository.Issues.Edges {
+			allIssues = append(allIssues, edge.Node.toModel(org, repo))
+			cursors = append(cursors, edge.Cursor)
+		}
+
+		page := result.Repository.Issues.PageInfo
+		if !page.HasNextPage || (maxIssues > 0 && len(allIssues) >= maxIssues) {
+			break
+		}
+		endCursor := page.EndCursor
+		cursor = &endCursor
+	}
+
+	return allIssues, cursors, nil
+}
+
+// toModel converts a GraphQL issue node to models.Issue
+func (i *graphQLIssue) toModel(org, repo string) *models.Issue {
+	labels := make([]string, len(i.Labels.Nodes))
+	for j, l := range i.Labels.Nodes {
+		labels[j] = l.Name
+	}
+
+	var author, issueType, milestone string
+	if i.Author != nil {
+		author = i.Author.Login
+	}
+	if i.IssueType != nil {
+		issueType = i.IssueType.Name
+	}
+	if i.Milestone != nil {
+		milestone = i.Milestone.Title
+	}
+
+	return &models.Issue{
+		Org:       org,
+		Repo:      repo,
+		Number:    i.Number,
+		Title:     i.Title,
+		Body:      i.Body,
+		State:     strings.ToLower(i.State),
+		Labels:    labels,
+		Author:    author,
+		URL:       i.URL,
+		IssueType: issueType,
+		Milestone: milestone,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// issuePagesAPI serves the GraphQL issues connection one issue per page,
+// recording the cursor each request started after. The first limited
+// requests are rate limited with a one-second Retry-After.
+type issuePagesAPI struct {
+	numbers []int
+	afters  []any
+	limited int
+	times   []time.Time
+}
+
+func (a *issuePagesAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	a.times = append(a.times, time.Now())
+	if a.limited > 0 {
+		a.limited--
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Content-Type": []string{"application/json"}, "Retry-After": []string{"1"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "secondary rate limit"}`)),
+			Request:    req,
+		}, nil
+	}
+
+	var payload struct {
+		Variables map[string]any `json:"variables"`
+	}
+	data, _ := io.ReadAll(req.Body)
+	_ = json.Unmarshal(data, &payload)
+	after := payload.Variables["after"]
+	a.afters = append(a.afters, after)
+
+	// Cursor "cN" points at the Nth issue
+	start := 0
+	if s, ok := after.(string); ok {
+		n, _ := strconv.Atoi(strings.TrimPrefix(s, "c"))
+		start = n + 1
+	}
+	cursor := fmt.Sprintf("c%d", start)
+	edges := []map[string]any{}
+	if start < len(a.numbers) {
+		edges = append(edges, map[string]any{
+			"cursor": cursor,
+			"node":   map[string]any{"number": a.numbers[start], "state": "OPEN"},
+		})
+	}
+	body, _ := json.Marshal(map[string]any{"data": map[string]any{"repository": map[string]any{"issues": map[string]any{
+		"pageInfo": map[string]any{"hasNextPage": start+1 < len(a.numbers), "endCursor": cursor},
+		"edges":    edges,
+	}}}})
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(body))),
+		Request:    req,
+	}, nil
+}
+
+func TestListIssuesGraphQLAfter(t *testing.T) {
+	api := &issuePagesAPI{numbers: []int{1, 2, 4, 5}}
+	c, err := NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, cursors, err := c.ListIssuesGraphQLAfter(context.Background(), "octo", "app", "all", "c1", 0)
+	if err != nil {
+		t.Fatalf("ListIssuesGraphQLAfter() error = %v", err)
+	}
+
+	if api.afters[0] != "c1" {
+		t.Errorf("first page requested after %v, want the saved cursor c1", api.afters[0])
+	}
+	if len(issues) != 2 || issues[0].Number != 4 || issues[1].Number != 5 {
+		t.Errorf("issues = %+v, want #4 and #5", issues)
+	}
+	if len(cursors) != 2 || cursors[0] != "c2" || cursors[1] != "c3" {
+		t.Errorf("cursors = %v, want [c2 c3]", cursors)
+	}
+	if issues[0].State != "open" || issues[0].Org != "octo" {
+		t.Errorf("issue = %+v, want an open octo/app issue", issues[0])
+	}
+}
+
+func TestListIssuesGraphQLAfter_Pacing(t *testing.T) {
+	api := &issuePagesAPI{numbers: []int{1, 2, 3}}
+	c, err := NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetRequestsPerSecond(20)
+
+	if _, _, err := c.ListIssuesGraphQLAfter(context.Background(), "octo", "app", "all", "", 0); err != nil {
+		t.Fatalf("ListIssuesGraphQLAfter() error = %v", err)
+	}
+	if len(api.times) != 3 {
+		t.Fatalf("made %d requests, want 3", len(api.times))
+	}
+	for i := 1; i < len(api.times); i++ {
+		if gap := api.times[i].Sub(api.times[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("page %d requested %s after the previous one, want at least 50ms", i+1, gap)
+		}
+	}
+}
+
+func TestListIssuesGraphQLAfter_RateLimited(t *testing.T) {
+	api := &issuePagesAPI{numbers: []int{1, 2}, limited: 1}
+	c, err := NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, _, err := c.ListIssuesGraphQLAfter(context.Background(), "octo", "app", "all", "", 0)
+	if err != nil {
+		t.Fatalf("ListIssuesGraphQLAfter() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("got %d issues, want 2", len(issues))
+	}
+	if len(api.times) != 3 {
+		t.Fatalf("made %d requests, want 3 (one retried)", len(api.times))
+	}
+	if gap := api.times[1].Sub(api.times[0]); gap < time.Second {
+		t.Errorf("retried after %s, want the 1s Retry-After honored", gap)
+	}
+}
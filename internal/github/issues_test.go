@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueURL(t *testing.T) {
+	org, repo, number, err := ParseIssueURL("https://github.com/octo/hello/issues/42")
+	if err != nil || org != "octo" || repo != "hello" || number != 42 {
+		t.Errorf("ParseIssueURL() = %q, %q, %d, %v, want octo, hello, 42", org, repo, number, err)
+	}
+
+	for _, bad := range []string{"", "https://github.com/octo/hello", "https://github.com/octo/hello/pull/42", "https://github.com/octo/hello/issues/x"} {
+		if _, _, _, err := ParseIssueURL(bad); err == nil {
+			t.Errorf("ParseIssueURL(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestRepoFromURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantOwner string
+		wantName  string
+		wantOK    bool
+	}{
+		{"https://api.github.com/repos/octo/hello", "octo", "hello", true},
+		{"https://ghe.example.com/api/v3/repos/octo/hello/", "octo", "hello", true},
+		{"", "", "", false},
+		{"https://github.com/octo/hello", "", "", false},
+	}
+
+	for _, tt := range tests {
+		owner, name, ok := repoFromURL(tt.url)
+		if owner != tt.wantOwner || name != tt.wantName || ok != tt.wantOK {
+			t.Errorf("repoFromURL(%q) = %q, %q, %v, want %q, %q, %v", tt.url, owner, name, ok, tt.wantOwner, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+// restIssuePagesAPI serves the REST issues listing from pages, a JSON array
+// per page number
+type restIssuePagesAPI struct {
+	pages map[string]string
+}
+
+func (a *restIssuePagesAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := a.pages[req.URL.Query().Get("page")]
+	if !ok {
+		body = "[]"
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestListAllIssues_SkipsPullRequests(t *testing.T) {
+	api := &restIssuePagesAPI{pages: map[string]string{
+		"1": `[{"number": 1}, {"number": 2, "pull_request": {"url": "https://api.github.com/repos/octo/app/pulls/2"}}]`,
+		"2": `[{"number": 3}]`,
+	}}
+	c, err := NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := c.ListAllIssues(context.Background(), "octo", "app", "all", 2, 0)
+	if err != nil {
+		t.Fatalf("ListAllIssues() error = %v", err)
+	}
+	var got []int
+	for _, issue := range issues {
+		got = append(got, issue.Number)
+	}
+	// The pull request must not end paging early either
+	if want := []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("ListAllIssues() numbers = %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/profile"
 )
 
 // AddLabels adds labels to an issue
@@ -12,6 +15,8 @@ func (c *Client) AddLabels(ctx context.Context, org, repo string, number int, la
 	if len(labels) == 0 {
 		return nil
 	}
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
 
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/labels", org, repo, number)
 
@@ -22,7 +27,7 @@ func (c *Client) AddLabels(ctx context.Context, org, repo string, number int, la
 	}
 
 	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
-		return fmt.Errorf("failed to add labels: %w", err)
+		return fmt.Errorf("failed to add labels: %w", wrapError(err))
 	}
 
 	return nil
@@ -30,10 +35,13 @@ func (c *Client) AddLabels(ctx context.Context, org, repo string, number int, la
 
 // RemoveLabel removes a label from an issue
 func (c *Client) RemoveLabel(ctx context.Context, org, repo string, number int, label string) error {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", org, repo, number, label)
 
 	if err := c.rest.Delete(endpoint, nil); err != nil {
-		return fmt.Errorf("failed to remove label: %w", err)
+		return fmt.Errorf("failed to remove label: %w", wrapError(err))
 	}
 
 	return nil
@@ -41,6 +49,9 @@ func (c *Client) RemoveLabel(ctx context.Context, org, repo string, number int,
 
 // CloseIssue closes an issue with an optional reason
 func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int, reason string) error {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, number)
 
 	payload := map[string]string{"state": "closed"}
@@ -54,7 +65,99 @@ func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int, r
 	}
 
 	if err := c.rest.Patch(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
-		return fmt.Errorf("failed to close issue: %w", err)
+		return fmt.Errorf("failed to close issue: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// Lock reasons accepted by LockIssue
+const (
+	LockOffTopic  = "off-topic"
+	LockTooHeated = "too heated"
+	LockResolved  = "resolved"
+	LockSpam      = "spam"
+)
+
+// ValidLockReason reports whether GitHub accepts reason; empty means none given
+func ValidLockReason(reason string) bool {
+	switch reason {
+	case "", LockOffTopic, LockTooHeated, LockResolved, LockSpam:
+		return true
+	}
+	return false
+}
+
+// LockIssue locks an issue's conversation so only collaborators can comment
+func (c *Client) LockIssue(ctx context.Context, org, repo string, number int, reason string) error {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
+	if !ValidLockReason(reason) {
+		return fmt.Errorf("invalid lock reason %q", reason)
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/lock", org, repo, number)
+
+	payload := map[string]string{}
+	if reason != "" {
+		payload["lock_reason"] = reason
+	}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Put(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to lock issue: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+// MarkAsDuplicate closes an issue as a duplicate of the issue at originalURL,
+// so GitHub links the two. Without GraphQL, or when originalURL isn't an
+// issue URL, it falls back to a REST close with the duplicate reason, which
+// doesn't record the original.
+func (c *Client) MarkAsDuplicate(ctx context.Context, org, repo string, number int, originalURL string) error {
+	origOrg, origRepo, origNumber, err := ParseIssueURL(originalURL)
+	if err != nil {
+		logging.Warnf("%s/%s#%d: %v, closing as duplicate without linking the original", org, repo, number, err)
+		return c.CloseIssue(ctx, org, repo, number, "duplicate")
+	}
+	gql, err := c.graphQL()
+	if err != nil {
+		return c.CloseIssue(ctx, org, repo, number, "duplicate")
+	}
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
+	issueID, err := c.getIssueNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+	originalID, err := c.getIssueNodeID(ctx, origOrg, origRepo, origNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get original issue node ID: %w", err)
+	}
+
+	mutation := `
+		mutation MarkAsDuplicate($issueId: ID!, $duplicateIssueId: ID!) {
+			closeIssue(input: {issueId: $issueId, stateReason: DUPLICATE, duplicateIssueId: $duplicateIssueId}) {
+				issue {
+					number
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"issueId":          issueID,
+		"duplicateIssueId": originalID,
+	}
+
+	if err := gql.Do(mutation, variables, nil); err != nil {
+		return fmt.Errorf("failed to mark issue as duplicate: %w", wrapError(err))
 	}
 
 	return nil
@@ -62,6 +165,9 @@ func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int, r
 
 // ReopenIssue reopens a closed issue
 func (c *Client) ReopenIssue(ctx context.Context, org, repo string, number int) error {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, number)
 
 	payload := map[string]string{"state": "open"}
@@ -71,7 +177,7 @@ func (c *Client) ReopenIssue(ctx context.Context, org, repo string, number int)
 	}
 
 	if err := c.rest.Patch(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
-		return fmt.Errorf("failed to reopen issue: %w", err)
+		return fmt.Errorf("failed to reopen issue: %w", wrapError(err))
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingTransport answers every request with an empty JSON object and
+// remembers what was sent
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	method, path string
+	body         map[string]any
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := recordedRequest{method: req.Method, path: req.URL.Path}
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &rec.body)
+	}
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, rec)
+	rt.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func newRecordingClient(t *testing.T) (*Client, *recordingTransport) {
+	t.Helper()
+	rt := &recordingTransport{}
+	c, err := NewClientWithTransport("test", rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, rt
+}
+
+func TestMarkAsDuplicate_InvalidURLFallsBackToClose(t *testing.T) {
+	c, rt := newRecordingClient(t)
+
+	if err := c.MarkAsDuplicate(context.Background(), "octo", "hello", 5, "not-an-issue-url"); err != nil {
+		t.Fatalf("MarkAsDuplicate() error = %v", err)
+	}
+
+	if len(rt.requests) != 1 {
+		t.Fatalf("sent %d requests, want a single REST close: %+v", len(rt.requests), rt.requests)
+	}
+	req := rt.requests[0]
+	if req.method != http.MethodPatch || req.path != "/repos/octo/hello/issues/5" {
+		t.Errorf("request = %s %s, want PATCH /repos/octo/hello/issues/5", req.method, req.path)
+	}
+	if req.body["state"] != "closed" || req.body["state_reason"] != "duplicate" {
+		t.Errorf("body = %v, want closed as duplicate", req.body)
+	}
+}
+
+func TestMarkAsDuplicate_WithoutGraphQLFallsBackToClose(t *testing.T) {
+	c, rt := newRecordingClient(t)
+	c.graphql, c.gqlErr = nil, errors.New("no token")
+
+	if err := c.MarkAsDuplicate(context.Background(), "octo", "hello", 5, "https://github.com/octo/hello/issues/1"); err != nil {
+		t.Fatalf("MarkAsDuplicate() error = %v", err)
+	}
+	if len(rt.requests) != 1 || rt.requests[0].method != http.MethodPatch {
+		t.Errorf("requests = %+v, want a single REST close", rt.requests)
+	}
+}
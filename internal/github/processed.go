@@ -0,0 +1,65 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+var processedRegex = regexp.MustCompile(`\n*<!-- simili-processed: (\{.*?\}) -->`)
+
+// ProcessedMarker records when the bot last processed an issue and the
+// content it saw. It is kept as a hidden marker in the summary comment.
+type ProcessedMarker struct {
+	At   time.Time `json:"at"`
+	Hash string    `json:"hash"`
+}
+
+// NewProcessedMarker stamps issue's current title and body as processed now
+func NewProcessedMarker(issue *models.Issue) ProcessedMarker {
+	return ProcessedMarker{At: time.Now().UTC(), Hash: ContentHash(issue)}
+}
+
+// ContentHash fingerprints the title and body of an issue
+func ContentHash(issue *models.Issue) string {
+	h := sha256.Sum256([]byte(issue.Title + "\x00" + issue.Body))
+	return hex.EncodeToString(h[:8])
+}
+
+// Covers reports whether the marker was stamped less than window ago for
+// the issue's current content
+func (m *ProcessedMarker) Covers(issue *models.Issue, window time.Duration) bool {
+	return m != nil && window > 0 && time.Since(m.At) < window && m.Hash == ContentHash(issue)
+}
+
+// ParseProcessedMarker reads the processed marker from a comment body
+func ParseProcessedMarker(body string) *ProcessedMarker {
+	m := processedRegex.FindStringSubmatch(body)
+	if m == nil {
+		return nil
+	}
+	var marker ProcessedMarker
+	if err := json.Unmarshal([]byte(m[1]), &marker); err != nil {
+		return nil
+	}
+	return &marker
+}
+
+// WithProcessedMarker returns body with its processed marker replaced by marker
+func WithProcessedMarker(body string, marker ProcessedMarker) string {
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return body
+	}
+	return strings.TrimRight(StripProcessedMarker(body), "\n") + "\n\n<!-- simili-processed: " + string(data) + " -->"
+}
+
+// StripProcessedMarker removes the processed marker from a comment body
+func StripProcessedMarker(body string) string {
+	return processedRegex.ReplaceAllString(body, "")
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestProcessedMarker_RoundTrip(t *testing.T) {
+	issue := &models.Issue{Title: "Crash", Body: "Steps"}
+	body := "Summary\n\n---\nPowered by Simili"
+
+	marked := WithProcessedMarker(body, NewProcessedMarker(issue))
+	marker := ParseProcessedMarker(marked)
+	if !marker.Covers(issue, time.Minute) {
+		t.Fatalf("fresh marker %+v should cover the unchanged issue", marker)
+	}
+
+	// Restamping replaces the marker instead of stacking another
+	remarked := WithProcessedMarker(marked, NewProcessedMarker(issue))
+	if n := strings.Count(remarked, "simili-processed"); n != 1 {
+		t.Errorf("found %d markers, want 1", n)
+	}
+	if StripProcessedMarker(remarked) != body {
+		t.Errorf("StripProcessedMarker() = %q, want %q", StripProcessedMarker(remarked), body)
+	}
+
+	edited := &models.Issue{Title: "Crash on start", Body: "Steps"}
+	if marker.Covers(edited, time.Minute) {
+		t.Error("marker covers an issue whose title changed")
+	}
+	if marker.Covers(issue, 0) {
+		t.Error("marker covers with debounce disabled")
+	}
+	old := &ProcessedMarker{At: time.Now().Add(-time.Hour), Hash: ContentHash(issue)}
+	if old.Covers(issue, time.Minute) {
+		t.Error("marker covers outside the window")
+	}
+	if ParseProcessedMarker(body) != nil {
+		t.Error("ParseProcessedMarker() found a marker in an unmarked body")
+	}
+}
@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/logging"
+)
+
+// lowBudgetFraction is the remaining share of the hourly budget that triggers a warning
+const lowBudgetFraction = 0.10
+
+// RateLimitResource is the budget for one API (core REST or GraphQL)
+type RateLimitResource struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Used      int   `json:"used"`
+	Reset     int64 `json:"reset"` // Unix seconds
+}
+
+// ResetTime returns when the budget refills
+func (r RateLimitResource) ResetTime() time.Time {
+	return time.Unix(r.Reset, 0)
+}
+
+// RateLimit holds the token's current API budgets
+type RateLimit struct {
+	Core    RateLimitResource `json:"core"`
+	GraphQL RateLimitResource `json:"graphql"`
+}
+
+// GetRateLimit fetches the token's remaining budgets. The call itself is free.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	var resp struct {
+		Resources RateLimit `json:"resources"`
+	}
+	if err := c.rest.Get("rate_limit", &resp); err != nil {
+		return nil, fmt.Errorf("failed to get rate limit: %w", wrapError(err))
+	}
+	return &resp.Resources, nil
+}
+
+// EnsureBudget makes sure at least need requests remain on both the core
+// and GraphQL budgets before a bulk loop, warning when a budget runs low
+// and sleeping until reset when it can't cover need. Failure to read the
+// budget is logged and ignored so it never blocks a run.
+func (c *Client) EnsureBudget(ctx context.Context, need int) error {
+	limits, err := c.GetRateLimit(ctx)
+	if err != nil {
+		logging.Warnf("could not check GitHub rate limit: %v", err)
+		return nil
+	}
+
+	for _, res := range []struct {
+		name string
+		r    RateLimitResource
+	}{
+		{"core", limits.Core},
+		{"graphql", limits.GraphQL},
+	} {
+		if res.r.Limit == 0 {
+			continue
+		}
+
+		if float64(res.r.Remaining) < float64(res.r.Limit)*lowBudgetFraction {
+			logging.Warnf("GitHub %s rate limit low: %d/%d remaining, resets at %s",
+				res.name, res.r.Remaining, res.r.Limit, res.r.ResetTime().Format(time.RFC3339))
+		}
+
+		if res.r.Remaining >= need {
+			continue
+		}
+
+		wait := time.Until(res.r.ResetTime()) + time.Second
+		if wait <= 0 {
+			continue
+		}
+		logging.Warnf("GitHub %s budget exhausted (%d remaining, need %d), pausing %s until reset",
+			res.name, res.r.Remaining, need, wait.Round(time.Second))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"slices"
 )
 
 // Reaction represents a GitHub reaction
@@ -22,7 +23,7 @@ func (c *Client) ListCommentReactions(ctx context.Context, org, repo string, com
 
 		var reactions []Reaction
 		if err := c.rest.Get(endpoint, &reactions); err != nil {
-			return nil, fmt.Errorf("failed to list comment reactions: %w", err)
+			return nil, fmt.Errorf("failed to list comment reactions: %w", wrapError(err))
 		}
 
 		if len(reactions) == 0 {
@@ -40,15 +41,44 @@ func (c *Client) ListCommentReactions(ctx context.Context, org, repo string, com
 	return allReactions, nil
 }
 
-// HasReaction checks if a comment has a specific reaction type from any user
-func (c *Client) HasReaction(ctx context.Context, org, repo string, commentID int, reactionType string) (bool, error) {
+// GetIssueReactions fetches reactions on the issue itself with pagination
+func (c *Client) GetIssueReactions(ctx context.Context, org, repo string, number int) ([]Reaction, error) {
+	var allReactions []Reaction
+	page := 1
+	perPage := 100
+
+	for {
+		endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/reactions?per_page=%d&page=%d", org, repo, number, perPage, page)
+
+		var reactions []Reaction
+		if err := c.rest.Get(endpoint, &reactions); err != nil {
+			return nil, fmt.Errorf("failed to list issue reactions: %w", wrapError(err))
+		}
+
+		if len(reactions) == 0 {
+			break
+		}
+
+		allReactions = append(allReactions, reactions...)
+
+		if len(reactions) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allReactions, nil
+}
+
+// HasReaction checks if a comment has any of the reaction types from any user
+func (c *Client) HasReaction(ctx context.Context, org, repo string, commentID int, reactionTypes ...string) (bool, error) {
 	reactions, err := c.ListCommentReactions(ctx, org, repo, commentID)
 	if err != nil {
 		return false, err
 	}
 
 	for _, r := range reactions {
-		if r.Content == reactionType {
+		if slices.Contains(reactionTypes, r.Content) {
 			return true, nil
 		}
 	}
@@ -56,8 +86,8 @@ func (c *Client) HasReaction(ctx context.Context, org, repo string, commentID in
 	return false, nil
 }
 
-// GetReactionUsers returns all users who reacted with a specific reaction type
-func (c *Client) GetReactionUsers(ctx context.Context, org, repo string, commentID int, reactionType string) ([]string, error) {
+// GetReactionUsers returns all users who reacted with any of the reaction types
+func (c *Client) GetReactionUsers(ctx context.Context, org, repo string, commentID int, reactionTypes ...string) ([]string, error) {
 	reactions, err := c.ListCommentReactions(ctx, org, repo, commentID)
 	if err != nil {
 		return nil, err
@@ -65,7 +95,7 @@ func (c *Client) GetReactionUsers(ctx context.Context, org, repo string, comment
 
 	var users []string
 	for _, r := range reactions {
-		if r.Content == reactionType {
+		if slices.Contains(reactionTypes, r.Content) {
 			users = append(users, r.User.Login)
 		}
 	}
@@ -73,10 +103,12 @@ func (c *Client) GetReactionUsers(ctx context.Context, org, repo string, comment
 	return users, nil
 }
 
-// CheckReactionDecision checks reactions and returns decision: "approve", "cancel", or "none"
-// approveReaction is typically "+1" (thumbs up)
-// cancelReaction is typically "-1" (thumbs down)
-func (c *Client) CheckReactionDecision(ctx context.Context, org, repo string, commentID int, approveReaction, cancelReaction string) (string, error) {
+// CheckReactionDecision checks reactions and returns decision: "approve", "cancel", "extend", or "none"
+// approveReactions typically holds "+1" (thumbs up) plus any synonyms
+// cancelReactions typically holds "-1" (thumbs down) plus any synonyms
+// extendReaction (e.g. "eyes") asks for more time; empty disables it.
+// Cancel takes precedence, then extend, then approve.
+func (c *Client) CheckReactionDecision(ctx context.Context, org, repo string, commentID int, approveReactions, cancelReactions []string, extendReaction string) (string, error) {
 	reactions, err := c.ListCommentReactions(ctx, org, repo, commentID)
 	if err != nil {
 		return "", err
@@ -84,20 +116,27 @@ func (c *Client) CheckReactionDecision(ctx context.Context, org, repo string, co
 
 	hasApprove := false
 	hasCancel := false
+	hasExtend := false
 
 	for _, r := range reactions {
-		if r.Content == approveReaction {
+		if slices.Contains(approveReactions, r.Content) {
 			hasApprove = true
 		}
-		if r.Content == cancelReaction {
+		if slices.Contains(cancelReactions, r.Content) {
 			hasCancel = true
 		}
+		if extendReaction != "" && r.Content == extendReaction {
+			hasExtend = true
+		}
 	}
 
 	// Cancel takes precedence
 	if hasCancel {
 		return "cancel", nil
 	}
+	if hasExtend {
+		return "extend", nil
+	}
 	if hasApprove {
 		return "approve", nil
 	}
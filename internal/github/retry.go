@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// RetryPolicy bounds how often a write to GitHub is retried on transient failure
+type RetryPolicy struct {
+	Attempts int           // Total tries including the first; below 2 disables retries
+	Backoff  time.Duration // Wait before the second try, growing linearly after that
+}
+
+// IsTransient reports whether err is worth retrying: rate limits, 5xx
+// responses, and network errors. Context cancellation is never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Do runs op, retrying transient failures until the policy is exhausted.
+// A Retry-After requested by GitHub overrides the backoff when longer.
+func (p RetryPolicy) Do(ctx context.Context, what string, op func() error) error {
+	attempts := max(p.Attempts, 1)
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = op(); err == nil || !IsTransient(err) || attempt == attempts {
+			return err
+		}
+
+		wait := time.Duration(attempt) * p.Backoff
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > wait {
+			wait = apiErr.RetryAfter
+		}
+		logging.Warnf("%s failed (attempt %d/%d), retrying in %s: %v", what, attempt, attempts, wait, err)
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+	return err
+}
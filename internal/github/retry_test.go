@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"server error", fmt.Errorf("failed: %w", &api.HTTPError{StatusCode: http.StatusBadGateway}), true},
+		{"rate limited", wrapError(&api.HTTPError{StatusCode: http.StatusTooManyRequests}), true},
+		{"not found", wrapError(&api.HTTPError{StatusCode: http.StatusNotFound}), false},
+		{"validation", &api.HTTPError{StatusCode: http.StatusUnprocessableEntity}, false},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"cancelled", context.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("IsTransient(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	policy := RetryPolicy{Attempts: 3}
+	transient := &api.HTTPError{StatusCode: http.StatusServiceUnavailable}
+
+	calls := 0
+	err := policy.Do(context.Background(), "post", func() error {
+		calls++
+		if calls < 3 {
+			return transient
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Do() = %v after %d calls, want success after 3", err, calls)
+	}
+
+	calls = 0
+	permanent := &api.HTTPError{StatusCode: http.StatusUnprocessableEntity}
+	err = policy.Do(context.Background(), "post", func() error {
+		calls++
+		return permanent
+	})
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want permanent error after 1", err, calls)
+	}
+
+	calls = 0
+	err = policy.Do(context.Background(), "post", func() error {
+		calls++
+		return transient
+	})
+	if !errors.Is(err, transient) || calls != 3 {
+		t.Errorf("Do() = %v after %d calls, want transient error after 3", err, calls)
+	}
+}
@@ -3,13 +3,30 @@ package github
 import (
 	"context"
 	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/profile"
 )
 
-// TransferIssue transfers an issue to another repository
-func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int, targetRepo string) error {
+// TransferredIssue is the new location of a transferred issue
+type TransferredIssue struct {
+	Number int
+	URL    string
+}
+
+// TransferIssue transfers an issue to another repository and returns its
+// number and URL there
+func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int, targetRepo string) (*TransferredIssue, error) {
+	defer profile.Track(ctx, "github_write")()
+	defer c.forgetIssue(org, repo, number)
+
 	targetOrg, targetRepoName, err := ParseRepo(targetRepo)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	gql, err := c.graphQL()
+	if err != nil {
+		return nil, err
 	}
 
 	// Use GraphQL mutation for issue transfer
@@ -17,6 +34,7 @@ func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int
 		TransferIssue struct {
 			Issue struct {
 				Number int
+				URL    string
 			}
 		} `graphql:"transferIssue(input: $input)"`
 	}
@@ -24,13 +42,13 @@ func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int
 	// First, get the issue node ID
 	nodeID, err := c.getIssueNodeID(ctx, org, repo, number)
 	if err != nil {
-		return fmt.Errorf("failed to get issue node ID: %w", err)
+		return nil, fmt.Errorf("failed to get issue node ID: %w", err)
 	}
 
 	// Get target repo node ID
 	targetRepoID, err := c.getRepoNodeID(ctx, targetOrg, targetRepoName)
 	if err != nil {
-		return fmt.Errorf("failed to get target repo node ID: %w", err)
+		return nil, fmt.Errorf("failed to get target repo node ID: %w", err)
 	}
 
 	query := `
@@ -38,6 +56,7 @@ func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int
 			transferIssue(input: {issueId: $issueId, repositoryId: $repositoryId}) {
 				issue {
 					number
+					url
 				}
 			}
 		}
@@ -48,15 +67,23 @@ func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int
 		"repositoryId": targetRepoID,
 	}
 
-	if err := c.graphql.Do(query, variables, &mutation); err != nil {
-		return fmt.Errorf("failed to transfer issue: %w", err)
+	if err := gql.Do(query, variables, &mutation); err != nil {
+		return nil, fmt.Errorf("failed to transfer issue: %w", wrapError(err))
 	}
 
-	return nil
+	return &TransferredIssue{
+		Number: mutation.TransferIssue.Issue.Number,
+		URL:    mutation.TransferIssue.Issue.URL,
+	}, nil
 }
 
 // getIssueNodeID fetches the GraphQL node ID for an issue
 func (c *Client) getIssueNodeID(ctx context.Context, org, repo string, number int) (string, error) {
+	gql, err := c.graphQL()
+	if err != nil {
+		return "", err
+	}
+
 	query := `
 		query GetIssueID($owner: String!, $repo: String!, $number: Int!) {
 			repository(owner: $owner, name: $repo) {
@@ -81,8 +108,8 @@ func (c *Client) getIssueNodeID(ctx context.Context, org, repo string, number in
 		"number": number,
 	}
 
-	if err := c.graphql.Do(query, variables, &result); err != nil {
-		return "", err
+	if err := gql.Do(query, variables, &result); err != nil {
+		return "", wrapError(err)
 	}
 
 	return result.Repository.Issue.ID, nil
@@ -90,6 +117,11 @@ func (c *Client) getIssueNodeID(ctx context.Context, org, repo string, number in
 
 // getRepoNodeID fetches the GraphQL node ID for a repository
 func (c *Client) getRepoNodeID(ctx context.Context, org, repo string) (string, error) {
+	gql, err := c.graphQL()
+	if err != nil {
+		return "", err
+	}
+
 	query := `
 		query GetRepoID($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
@@ -109,8 +141,8 @@ func (c *Client) getRepoNodeID(ctx context.Context, org, repo string) (string, e
 		"repo":  repo,
 	}
 
-	if err := c.graphql.Do(query, variables, &result); err != nil {
-		return "", err
+	if err := gql.Do(query, variables, &result); err != nil {
+		return "", wrapError(err)
 	}
 
 	return result.Repository.ID, nil
@@ -0,0 +1,173 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/logging"
+)
+
+// ErrCircuitOpen is returned while the breaker is short-circuiting calls
+var ErrCircuitOpen = errors.New("llm: circuit open")
+
+// CircuitBreaker wraps a Provider and stops calling it after consecutive
+// failures, so an outage costs one cooldown window instead of a timeout per issue
+type CircuitBreaker struct {
+	provider  Provider
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker opens after threshold consecutive failures and retries
+// a single probe call once cooldown has elapsed
+func NewCircuitBreaker(provider Provider, threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 3
+	}
+	if cooldown <= 0 {
+		cooldown = time.Minute
+	}
+	return &CircuitBreaker{
+		provider:  provider,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// Complete generates a completion unless the circuit is open
+func (b *CircuitBreaker) Complete(ctx context.Context, prompt string) (string, error) {
+	return b.call(ctx, func() (string, error) {
+		return b.provider.Complete(ctx, prompt)
+	})
+}
+
+// CompleteWithSystem generates a completion with a system prompt unless the circuit is open
+func (b *CircuitBreaker) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	return b.call(ctx, func() (string, error) {
+		return b.provider.CompleteWithSystem(ctx, system, prompt)
+	})
+}
+
+// CompleteWithSystemStream streams a completion unless the circuit is open.
+// The outcome is recorded when the stream ends, so failures part-way
+// through count toward the breaker like failed calls.
+func (b *CircuitBreaker) CompleteWithSystemStream(ctx context.Context, system, prompt string) (<-chan Chunk, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	chunks, err := CompleteWithSystemStream(ctx, b.provider, system, prompt)
+	if err != nil {
+		b.finish(ctx, err)
+		return nil, err
+	}
+
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		var streamErr error
+		for chunk := range chunks {
+			// Record the failure before the caller sees it, so a caller that
+			// stops reading at the error still finds the breaker updated
+			if chunk.Err != nil && streamErr == nil {
+				streamErr = chunk.Err
+				b.finish(ctx, streamErr)
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+			}
+		}
+		if streamErr == nil {
+			b.finish(ctx, nil)
+		}
+	}()
+	return out, nil
+}
+
+// Close releases the wrapped provider
+func (b *CircuitBreaker) Close() error {
+	return b.provider.Close()
+}
+
+// call runs fn if the breaker allows it and records the outcome
+func (b *CircuitBreaker) call(ctx context.Context, fn func() (string, error)) (string, error) {
+	if err := b.allow(); err != nil {
+		return "", err
+	}
+
+	resp, err := fn()
+	b.finish(ctx, err)
+	if err != nil {
+		return "", err
+	}
+	return resp, nil
+}
+
+// finish records a call's outcome; a caller cancelling its own context says
+// nothing about provider health
+func (b *CircuitBreaker) finish(ctx context.Context, err error) {
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return
+	}
+	b.record(err)
+}
+
+// allow reports whether a call may proceed, admitting one probe after cooldown
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.probing || time.Now().Before(b.openUntil) {
+		return fmt.Errorf("%w (retry after %s)", ErrCircuitOpen, b.openUntil.Format(time.RFC3339))
+	}
+
+	logging.Infof("LLM circuit breaker half-open, probing provider")
+	b.probing = true
+	return nil
+}
+
+// release clears an in-flight probe without recording an outcome
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// record updates breaker state after a call
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbing := b.probing
+	b.probing = false
+
+	if err == nil {
+		if b.failures >= b.threshold {
+			logging.Infof("LLM circuit breaker closed, provider recovered")
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		if wasProbing || b.failures == b.threshold {
+			logging.Warnf("LLM circuit breaker open after %d consecutive failures, skipping LLM calls for %s: %v",
+				b.failures, b.cooldown, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeProvider fails while fail is set and counts calls
+type fakeProvider struct {
+	fail  bool
+	calls int
+}
+
+func (f *fakeProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return f.CompleteWithSystem(ctx, "", prompt)
+}
+
+func (f *fakeProvider) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	f.calls++
+	if f.fail {
+		return "", errors.New("provider down")
+	}
+	return "ok", nil
+}
+
+func (f *fakeProvider) Close() error { return nil }
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeProvider{fail: true}
+	b := NewCircuitBreaker(fake, 2, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := b.Complete(ctx, "x"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected provider error, got %v", i, err)
+		}
+	}
+
+	if _, err := b.Complete(ctx, "x"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("provider called %d times, want 2", fake.calls)
+	}
+}
+
+func TestCircuitBreaker_ProbeClosesCircuit(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeProvider{fail: true}
+	b := NewCircuitBreaker(fake, 1, time.Millisecond)
+
+	_, _ = b.Complete(ctx, "x")
+	time.Sleep(5 * time.Millisecond)
+
+	fake.fail = false
+	if resp, err := b.Complete(ctx, "x"); err != nil || resp != "ok" {
+		t.Fatalf("probe call = %q, %v; want ok", resp, err)
+	}
+	if _, err := b.Complete(ctx, "x"); err != nil {
+		t.Fatalf("expected closed circuit, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 
 	"google.golang.org/genai"
 )
@@ -11,10 +13,11 @@ import (
 type GeminiProvider struct {
 	client *genai.Client
 	model  string
+	opts   Options
 }
 
 // NewGeminiProvider creates a new Gemini chat provider
-func NewGeminiProvider(apiKey, model string) (*GeminiProvider, error) {
+func NewGeminiProvider(apiKey, model string, opts Options) (*GeminiProvider, error) {
 	ctx := context.Background()
 
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
@@ -32,6 +35,7 @@ func NewGeminiProvider(apiKey, model string) (*GeminiProvider, error) {
 	return &GeminiProvider{
 		client: client,
 		model:  model,
+		opts:   opts,
 	}, nil
 }
 
@@ -42,9 +46,73 @@ func (p *GeminiProvider) Complete(ctx context.Context, prompt string) (string, e
 
 // CompleteWithSystem generates a completion with a system prompt
 func (p *GeminiProvider) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	ctx, cancel := p.opts.callContext(ctx)
+	defer cancel()
+
+	result, err := p.client.Models.GenerateContent(ctx, p.model, p.contents(prompt), p.config(system))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", p.opts.callError(ctx, err))
+	}
+
+	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no content generated")
+	}
+
+	return result.Candidates[0].Content.Parts[0].Text, nil
+}
+
+// CompleteWithSystemStream streams a completion with a system prompt. The
+// first response is awaited before returning, so a request that fails to
+// start is reported as an error rather than through the channel.
+func (p *GeminiProvider) CompleteWithSystemStream(ctx context.Context, system, prompt string) (<-chan Chunk, error) {
+	ctx, cancel := p.opts.callContext(ctx)
+
+	next, stop := iter.Pull2(p.client.Models.GenerateContentStream(ctx, p.model, p.contents(prompt), p.config(system)))
+	result, err, ok := next()
+	if err != nil {
+		stop()
+		cancel()
+		return nil, fmt.Errorf("failed to generate content stream: %w", p.opts.callError(ctx, err))
+	}
+
+	chunks := make(chan Chunk)
+	go func() {
+		defer close(chunks)
+		defer cancel()
+		defer stop()
+
+		for ; ok; result, err, ok = next() {
+			if err != nil {
+				if !errors.Is(ctx.Err(), context.Canceled) {
+					streamFailed(ctx, chunks, "Gemini", p.opts.callError(ctx, err))
+				}
+				return
+			}
+			if len(result.Candidates) == 0 || result.Candidates[0].Content == nil {
+				continue
+			}
+			for _, part := range result.Candidates[0].Content.Parts {
+				if part.Thought {
+					continue
+				}
+				if !sendChunk(ctx, chunks, part.Text) {
+					return
+				}
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// config builds the generation config shared by the streaming and
+// non-streaming calls
+func (p *GeminiProvider) config(system string) *genai.GenerateContentConfig {
 	config := &genai.GenerateContentConfig{
 		MaxOutputTokens: genai.Ptr(int32(1024)),
-		Temperature:     genai.Ptr(float32(0.3)),
+		Temperature:     genai.Ptr(p.opts.Temperature),
+	}
+	if p.opts.Seed != 0 {
+		config.Seed = genai.Ptr(int32(p.opts.Seed))
 	}
 
 	if system != "" {
@@ -52,22 +120,17 @@ func (p *GeminiProvider) CompleteWithSystem(ctx context.Context, system, prompt
 			Parts: []*genai.Part{{Text: system}},
 		}
 	}
+	return config
+}
 
-	result, err := p.client.Models.GenerateContent(ctx, p.model, []*genai.Content{
+// contents wraps prompt as the single user turn
+func (p *GeminiProvider) contents(prompt string) []*genai.Content {
+	return []*genai.Content{
 		{
 			Role:  "user",
 			Parts: []*genai.Part{{Text: prompt}},
 		},
-	}, config)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
-
-	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("no content generated")
-	}
-
-	return result.Candidates[0].Content.Parts[0].Text, nil
 }
 
 // Close releases resources
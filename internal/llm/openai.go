@@ -2,7 +2,10 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -11,10 +14,11 @@ import (
 type OpenAIProvider struct {
 	client *openai.Client
 	model  string
+	opts   Options
 }
 
 // NewOpenAIProvider creates a new OpenAI chat provider
-func NewOpenAIProvider(apiKey, model string) (*OpenAIProvider, error) {
+func NewOpenAIProvider(apiKey, model string, opts Options) (*OpenAIProvider, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("OpenAI API key is required")
 	}
@@ -28,6 +32,7 @@ func NewOpenAIProvider(apiKey, model string) (*OpenAIProvider, error) {
 	return &OpenAIProvider{
 		client: client,
 		model:  model,
+		opts:   opts,
 	}, nil
 }
 
@@ -38,6 +43,59 @@ func (p *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, e
 
 // CompleteWithSystem generates a completion with a system prompt
 func (p *OpenAIProvider) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	ctx, cancel := p.opts.callContext(ctx)
+	defer cancel()
+
+	resp, err := p.client.CreateChatCompletion(ctx, p.request(system, prompt))
+	if err != nil {
+		return "", fmt.Errorf("failed to create chat completion: %w", p.opts.callError(ctx, err))
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no completion choices returned")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+// CompleteWithSystemStream streams a completion with a system prompt
+func (p *OpenAIProvider) CompleteWithSystemStream(ctx context.Context, system, prompt string) (<-chan Chunk, error) {
+	ctx, cancel := p.opts.callContext(ctx)
+
+	stream, err := p.client.CreateChatCompletionStream(ctx, p.request(system, prompt))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create chat completion stream: %w", p.opts.callError(ctx, err))
+	}
+
+	chunks := make(chan Chunk)
+	go func() {
+		defer close(chunks)
+		defer cancel()
+		defer stream.Close()
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if !errors.Is(ctx.Err(), context.Canceled) {
+					streamFailed(ctx, chunks, "OpenAI", p.opts.callError(ctx, err))
+				}
+				return
+			}
+			if len(resp.Choices) > 0 && !sendChunk(ctx, chunks, resp.Choices[0].Delta.Content) {
+				return
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// request builds the chat completion request shared by the streaming and
+// non-streaming calls
+func (p *OpenAIProvider) request(system, prompt string) openai.ChatCompletionRequest {
 	messages := []openai.ChatCompletionMessage{}
 
 	if system != "" {
@@ -52,21 +110,21 @@ func (p *OpenAIProvider) CompleteWithSystem(ctx context.Context, system, prompt
 		Content: prompt,
 	})
 
-	resp, err := p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	req := openai.ChatCompletionRequest{
 		Model:       p.model,
 		Messages:    messages,
 		MaxTokens:   1024,
-		Temperature: 0.3,
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to create chat completion: %w", err)
+		Temperature: p.opts.Temperature,
 	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no completion choices returned")
+	// go-openai omits a zero temperature, which the API reads as its default
+	// of 1; send the smallest nonzero value so an explicit 0 stays greedy
+	if req.Temperature == 0 {
+		req.Temperature = math.SmallestNonzeroFloat32
 	}
-
-	return resp.Choices[0].Message.Content, nil
+	if p.opts.Seed != 0 {
+		req.Seed = &p.opts.Seed
+	}
+	return req
 }
 
 // Close releases resources
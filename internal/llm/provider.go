@@ -2,6 +2,9 @@ package llm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 )
 
 // Provider defines the interface for LLM chat completion
@@ -11,6 +14,29 @@ type Provider interface {
 	Close() error
 }
 
+// Options controls sampling for chat completions
+type Options struct {
+	Temperature float32
+	Seed        int           // 0 leaves the seed to the provider
+	Timeout     time.Duration // Per-call limit; 0 means none
+}
+
+// callContext bounds ctx by the per-call timeout, if any
+func (o Options) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.Timeout)
+}
+
+// callError reports a call that ran out of time as a timeout
+func (o Options) callError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("LLM call timed out after %s: %w", o.Timeout, err)
+	}
+	return err
+}
+
 // Message represents a chat message
 type Message struct {
 	Role    string
@@ -0,0 +1,28 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptions_CallContext(t *testing.T) {
+	opts := Options{Timeout: 10 * time.Millisecond}
+
+	ctx, cancel := opts.callContext(context.Background())
+	defer cancel()
+	<-ctx.Done()
+
+	err := opts.callError(ctx, ctx.Err())
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out after 10ms") {
+		t.Errorf("callError() = %v, want a timeout error", err)
+	}
+
+	ctx, cancel = Options{}.callContext(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("callContext() without a timeout set a deadline")
+	}
+}
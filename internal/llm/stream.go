@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Chunk is one piece of a streamed completion. A stream that fails after it
+// started ends with a chunk carrying Err and no text.
+type Chunk struct {
+	Text string
+	Err  error
+}
+
+// Streamer is implemented by providers that can deliver a completion as it
+// is generated. Batch callers such as the triage checkers don't need it;
+// interactive flows use CompleteWithSystemStream to show text sooner.
+type Streamer interface {
+	// CompleteWithSystemStream returns an error when the completion can't
+	// be started, and otherwise sends it in chunks and closes the channel
+	// when it ends. A later failure is sent as a final chunk with Err set.
+	CompleteWithSystemStream(ctx context.Context, system, prompt string) (<-chan Chunk, error)
+}
+
+// CompleteWithSystemStream streams a completion from p when it implements
+// Streamer, and otherwise sends the whole CompleteWithSystem response as one chunk
+func CompleteWithSystemStream(ctx context.Context, p Provider, system, prompt string) (<-chan Chunk, error) {
+	if s, ok := p.(Streamer); ok {
+		return s.CompleteWithSystemStream(ctx, system, prompt)
+	}
+
+	resp, err := p.CompleteWithSystem(ctx, system, prompt)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(chan Chunk, 1)
+	chunks <- Chunk{Text: resp}
+	close(chunks)
+	return chunks, nil
+}
+
+// Collect reads a stream to its end, returning the text received and the
+// error that cut it short, if any
+func Collect(chunks <-chan Chunk) (string, error) {
+	var sb strings.Builder
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			return sb.String(), chunk.Err
+		}
+		sb.WriteString(chunk.Text)
+	}
+	return sb.String(), nil
+}
+
+// sendChunk delivers a chunk unless the caller has gone away
+func sendChunk(ctx context.Context, chunks chan<- Chunk, text string) bool {
+	if text == "" {
+		return true
+	}
+	select {
+	case chunks <- Chunk{Text: text}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// streamFailed sends the error that cut a stream short as its final chunk
+func streamFailed(ctx context.Context, chunks chan<- Chunk, provider string, err error) {
+	select {
+	case chunks <- Chunk{Err: fmt.Errorf("%s stream ended early: %w", provider, err)}:
+	case <-ctx.Done():
+	}
+}
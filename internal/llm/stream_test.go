@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"google.golang.org/genai"
+)
+
+// fakeStreamer streams its chunks, failing to start while fail is set
+type fakeStreamer struct {
+	fakeProvider
+	chunks []Chunk
+}
+
+func (f *fakeStreamer) CompleteWithSystemStream(ctx context.Context, system, prompt string) (<-chan Chunk, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("provider down")
+	}
+	chunks := make(chan Chunk, len(f.chunks))
+	for _, c := range f.chunks {
+		chunks <- c
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+func TestCompleteWithSystemStream_Fallback(t *testing.T) {
+	chunks, err := CompleteWithSystemStream(context.Background(), &fakeProvider{}, "system", "prompt")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "ok" || err != nil {
+		t.Errorf("streamed %q, %v, want %q", got, err, "ok")
+	}
+
+	if _, err := CompleteWithSystemStream(context.Background(), &fakeProvider{fail: true}, "", "x"); err == nil {
+		t.Error("expected the provider error")
+	}
+}
+
+func TestCircuitBreaker_Stream(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeStreamer{fakeProvider: fakeProvider{fail: true}}
+	b := NewCircuitBreaker(fake, 1, time.Hour)
+
+	if _, err := b.CompleteWithSystemStream(ctx, "", "x"); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	fake.fail = false
+	if _, err := b.CompleteWithSystemStream(ctx, "", "x"); !errors.Is(err, ErrCircuitOpen) || fake.calls != 1 {
+		t.Errorf("open breaker let a stream through: err = %v, calls = %d", err, fake.calls)
+	}
+}
+
+func TestCircuitBreaker_StreamFailsPartWay(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeStreamer{chunks: []Chunk{{Text: "Hel"}, {Err: errors.New("connection reset")}}}
+	b := NewCircuitBreaker(fake, 1, time.Hour)
+
+	chunks, err := b.CompleteWithSystemStream(ctx, "", "x")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "Hel" || err == nil {
+		t.Errorf("Collect() = %q, %v, want the partial text and an error", got, err)
+	}
+	if _, err := b.CompleteWithSystemStream(ctx, "", "x"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("failure part-way did not open the breaker: err = %v", err)
+	}
+}
+
+func TestSendChunk_CallerGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if sendChunk(ctx, make(chan Chunk), "text") {
+		t.Error("sendChunk() delivered to a cancelled caller")
+	}
+}
+
+// sseServer answers every request with status and, for 200, the SSE lines
+func sseServer(t *testing.T, status int, lines ...string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"error": {"code": 400, "message": "bad request", "status": "INVALID_ARGUMENT"}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, line := range lines {
+			fmt.Fprintf(w, "%s\n\n", line)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenAIProvider_Stream(t *testing.T) {
+	newProvider := func(srv *httptest.Server) *OpenAIProvider {
+		cfg := openai.DefaultConfig("key")
+		cfg.BaseURL = srv.URL + "/v1"
+		return &OpenAIProvider{client: openai.NewClientWithConfig(cfg), model: "gpt-4o-mini"}
+	}
+	delta := func(text string) string {
+		return fmt.Sprintf(`data: {"choices": [{"index": 0, "delta": {"content": %q}}]}`, text)
+	}
+
+	chunks, err := newProvider(sseServer(t, http.StatusOK, delta("Hel"), delta("lo"), "data: [DONE]")).
+		CompleteWithSystemStream(context.Background(), "system", "prompt")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "Hello" || err != nil {
+		t.Errorf("Collect() = %q, %v, want %q", got, err, "Hello")
+	}
+
+	if _, err := newProvider(sseServer(t, http.StatusBadRequest)).CompleteWithSystemStream(context.Background(), "", "x"); err == nil {
+		t.Error("a failed start returned no error")
+	}
+
+	chunks, err = newProvider(sseServer(t, http.StatusOK, delta("Hel"), "data: {not json")).
+		CompleteWithSystemStream(context.Background(), "", "x")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "Hel" || err == nil {
+		t.Errorf("Collect() = %q, %v, want the partial text and an error", got, err)
+	}
+}
+
+func TestGeminiProvider_Stream(t *testing.T) {
+	newProvider := func(srv *httptest.Server) *GeminiProvider {
+		client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+			APIKey:      "key",
+			Backend:     genai.BackendGeminiAPI,
+			HTTPOptions: genai.HTTPOptions{BaseURL: srv.URL + "/"},
+		})
+		if err != nil {
+			t.Fatalf("genai.NewClient() error = %v", err)
+		}
+		return &GeminiProvider{client: client, model: "gemini-1.5-flash"}
+	}
+	part := func(text string) string {
+		return fmt.Sprintf(`data: {"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}}]}`, text)
+	}
+
+	chunks, err := newProvider(sseServer(t, http.StatusOK, part("Hel"), part("lo"))).
+		CompleteWithSystemStream(context.Background(), "system", "prompt")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "Hello" || err != nil {
+		t.Errorf("Collect() = %q, %v, want %q", got, err, "Hello")
+	}
+
+	if _, err := newProvider(sseServer(t, http.StatusBadRequest)).CompleteWithSystemStream(context.Background(), "", "x"); err == nil {
+		t.Error("a failed start returned no error")
+	}
+
+	chunks, err = newProvider(sseServer(t, http.StatusOK, part("Hel"), "oops: cut off")).
+		CompleteWithSystemStream(context.Background(), "", "x")
+	if err != nil {
+		t.Fatalf("CompleteWithSystemStream() error = %v", err)
+	}
+	if got, err := Collect(chunks); got != "Hel" || err == nil {
+		t.Errorf("Collect() = %q, %v, want the partial text and an error", got, err)
+	}
+}
@@ -0,0 +1,544 @@
+package locale
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Default is the language used when none is configured and for keys a
+// catalog doesn't translate
+const Default = "en"
+
+// Key identifies a static comment string
+type Key string
+
+// Keys for the static strings in bot comments. Values containing verbs are
+// fmt format strings and keep the same verbs in every language.
+const (
+	SummaryHeading      Key = "summary.heading"
+	SummaryIntro        Key = "summary.intro"
+	RelatedIssues       Key = "related.heading"
+	RelatedPrompt       Key = "related.prompt"
+	ColumnIssue         Key = "column.issue"
+	ColumnRepository    Key = "column.repository"
+	ColumnSimilarity    Key = "column.similarity"
+	ColumnStatus        Key = "column.status"
+	StateOpen           Key = "state.open"
+	StateClosed         Key = "state.closed"
+	LikelySpam          Key = "spam.heading"
+	Confidence          Key = "spam.confidence"
+	SuggestedLabels     Key = "labels.heading"
+	LabelConfidence     Key = "labels.confidence"
+	QualityScore        Key = "quality.heading"
+	QualityMissing      Key = "quality.missing"
+	QualityGood         Key = "quality.good"
+	PotentialDuplicate  Key = "duplicate.heading"
+	Similarity          Key = "duplicate.similarity"
+	Original            Key = "duplicate.original"
+	AreaOwners          Key = "area.heading"
+	AreaMention         Key = "area.mention"
+	TransferHeading     Key = "transfer.heading"
+	TransferBelongs     Key = "transfer.belongs"
+	TransferIn          Key = "transfer.in"
+	ReactPrompt         Key = "react.prompt"
+	TransferApprove     Key = "transfer.approve"
+	TransferCancel      Key = "transfer.cancel"
+	Deadline            Key = "deadline"
+	TransferAuto        Key = "transfer.auto"
+	TransferNow         Key = "transfer.now"
+	PoweredBy           Key = "footer.powered_by"
+	SimilarThanks       Key = "similar.thanks"
+	SimilarFound        Key = "similar.found"
+	SimilarPrompt       Key = "similar.prompt"
+	TriageHeading       Key = "triage.heading"
+	LabelsApplied       Key = "triage.labels_applied"
+	LabelsHeading       Key = "triage.labels"
+	LabelsNone          Key = "triage.labels_none"
+	SimilarIssues       Key = "triage.similar"
+	SimilarNone         Key = "triage.similar_none"
+	DuplicateClosed     Key = "duplicate.closed"
+	DuplicateFlagged    Key = "duplicate.flagged"
+	OriginalIssue       Key = "duplicate.original_issue"
+	DuplicateSimilarity Key = "duplicate.similarity_line"
+	DuplicateReopen     Key = "duplicate.reopen"
+	DuplicateReview     Key = "duplicate.review"
+	CloseIn             Key = "close.in"
+	CloseApprove        Key = "close.approve"
+	CloseCancel         Key = "close.cancel"
+	CloseAuto           Key = "close.auto"
+	CloseCancelled      Key = "close.cancelled"
+	CloseKeptLabeled    Key = "close.kept_labeled"
+	SpamCloseIn         Key = "spam_close.in"
+	SpamConfidence      Key = "spam_close.confidence"
+	SpamCloseCancel     Key = "spam_close.cancel"
+	IssueKept           Key = "close.kept"
+	TransferDone        Key = "transfer.done"
+	TransferMoving      Key = "transfer.moving"
+	MatchedRule         Key = "transfer.matched_rule"
+	RoutingRules        Key = "transfer.routing_rules"
+	TransferContinue    Key = "transfer.continue"
+	ContinuedAt         Key = "transfer.continued_at"
+	TransferWarning     Key = "transfer.warning"
+	TransferRevert      Key = "transfer.revert"
+	TransferCancelled   Key = "transfer.cancelled"
+	TransferKept        Key = "transfer.kept"
+	DraftPending        Key = "draft.pending"
+	DraftSummary        Key = "draft.summary"
+	DeadlineExtended    Key = "pending.extended"
+	ActionCancelled     Key = "pending.cancelled"
+	ScheduledAction     Key = "pending.action"
+	ScheduledTransfer   Key = "pending.transfer"
+	ScheduledDuplicate  Key = "pending.close_duplicate"
+	ScheduledNotPlanned Key = "pending.close_not_planned"
+	ScheduledDraft      Key = "pending.draft"
+	DuplicateLinked     Key = "duplicate.linked"
+	RedactedMatch       Key = "match.redacted"
+	StateDiscussion     Key = "state.discussion"
+	DiscussionClosed    Key = "state.discussion_closed"
+)
+
+var catalogs = map[string]map[Key]string{
+	"en": {
+		SummaryHeading:      "Issue Intelligence Summary",
+		SummaryIntro:        "Thanks for opening this issue! Here's what I found:",
+		RelatedIssues:       "Related Issues",
+		RelatedPrompt:       "If any of these address your problem, please let us know!",
+		ColumnIssue:         "Issue",
+		ColumnRepository:    "Repository",
+		ColumnSimilarity:    "Similarity",
+		ColumnStatus:        "Status",
+		StateOpen:           "Open",
+		StateClosed:         "Closed",
+		LikelySpam:          "Likely Spam",
+		Confidence:          "Confidence: %.0f%%",
+		SuggestedLabels:     "Suggested Labels",
+		LabelConfidence:     "%.0f%% confidence",
+		QualityScore:        "Quality Score: %.0f%%",
+		QualityMissing:      "Missing: %s",
+		QualityGood:         "Issue is well-documented",
+		PotentialDuplicate:  "Potential Duplicate",
+		Similarity:          "Similarity: %.0f%%",
+		Original:            "Original: %s",
+		AreaOwners:          "Area Owners",
+		AreaMention:         "cc %s - this issue mentions an area you own.",
+		TransferHeading:     "Transfer Suggestion",
+		TransferBelongs:     "This issue appears to belong in **%s**.",
+		TransferIn:          "This issue will be transferred in %d hours.",
+		ReactPrompt:         "React to this comment:",
+		TransferApprove:     "to approve and proceed with transfer",
+		TransferCancel:      "to cancel this transfer",
+		Deadline:            "Deadline",
+		TransferAuto:        "If no reaction is provided, the transfer will proceed automatically.",
+		TransferNow:         "Transfer will be executed immediately.",
+		PoweredBy:           "Powered by %s",
+		SimilarThanks:       "Thanks for opening this issue!",
+		SimilarFound:        "I found some potentially related issues that might be helpful:",
+		SimilarPrompt:       "If any of these address your problem, please let us know and we can close this as a duplicate.",
+		TriageHeading:       "Triage Summary",
+		LabelsApplied:       "Labels Applied",
+		LabelsHeading:       "Labels",
+		LabelsNone:          "No labels applied (no confident matches found)",
+		SimilarIssues:       "Similar Issues",
+		SimilarNone:         "No similar issues found",
+		DuplicateClosed:     "This issue has been automatically closed as a duplicate.",
+		DuplicateFlagged:    "This issue appears to be a duplicate.",
+		OriginalIssue:       "**Original issue:** %s",
+		DuplicateSimilarity: "**Similarity:** %.0f%%",
+		DuplicateReopen:     "If you believe this is not a duplicate, please comment and we will reopen it.",
+		DuplicateReview:     "Please review the linked issue. If it addresses your concern, consider closing this issue and following the original.",
+		CloseIn:             "This issue will be closed as a duplicate in %d hours",
+		CloseApprove:        "to approve and proceed with closing",
+		CloseCancel:         "to cancel and add potential-duplicate label instead",
+		CloseAuto:           "If no reaction is provided, the issue will be closed automatically.",
+		CloseCancelled:      "Auto-close has been cancelled based on your reaction.",
+		CloseKeptLabeled:    "The issue will remain open and has been labeled as `potential-duplicate` for maintainer review.",
+		SpamCloseIn:         "This issue looks like spam and will be closed as not planned in %d hours",
+		SpamConfidence:      "**Confidence:** %.0f%%",
+		SpamCloseCancel:     "to cancel and keep the issue open",
+		IssueKept:           "The issue will remain open.",
+		TransferDone:        "This issue has been automatically transferred to **%s** because it matches our routing rules.",
+		TransferMoving:      "This issue is being automatically transferred to **%s** because it matches our routing rules.",
+		MatchedRule:         "**Matched rule:** %s",
+		RoutingRules:        "routing rules",
+		TransferContinue:    "The discussion will continue there. Thanks for your report!",
+		ContinuedAt:         "**Continued at:** %s",
+		TransferWarning:     "This issue will be transferred to %s in %d hours",
+		TransferRevert:      "**Mistake?** React with %s to this comment to revert this transfer.",
+		TransferCancelled:   "Transfer to **%s** has been cancelled based on your reaction.",
+		TransferKept:        "The issue will remain in this repository.",
+		DraftPending:        "**Pending maintainer approval.** A maintainer can react %s to publish this summary and apply its actions.",
+		DraftSummary:        "Draft summary (pending maintainer approval)",
+		DeadlineExtended:    "Deadline extended by a maintainer. %s will now happen after %s.",
+		ActionCancelled:     "%s has been cancelled by a maintainer.",
+		ScheduledAction:     "The scheduled action",
+		ScheduledTransfer:   "The transfer to **%s**",
+		ScheduledDuplicate:  "Closing this issue as a duplicate",
+		ScheduledNotPlanned: "Closing this issue as not planned",
+		ScheduledDraft:      "The drafted comment",
+		DuplicateLinked:     "A possible duplicate was opened: %s (%.0f%% similar)",
+		RedactedMatch:       "a related internal issue",
+		StateDiscussion:     "Discussion",
+		DiscussionClosed:    "Discussion (closed)",
+	},
+	"es": {
+		SummaryHeading:      "Resumen de la incidencia",
+		SummaryIntro:        "¡Gracias por abrir esta incidencia! Esto es lo que encontré:",
+		RelatedIssues:       "Incidencias relacionadas",
+		RelatedPrompt:       "Si alguna de estas resuelve tu problema, ¡avísanos!",
+		ColumnIssue:         "Incidencia",
+		ColumnRepository:    "Repositorio",
+		ColumnSimilarity:    "Similitud",
+		ColumnStatus:        "Estado",
+		StateOpen:           "Abierta",
+		StateClosed:         "Cerrada",
+		LikelySpam:          "Posible spam",
+		Confidence:          "Confianza: %.0f%%",
+		SuggestedLabels:     "Etiquetas sugeridas",
+		LabelConfidence:     "%.0f%% de confianza",
+		QualityScore:        "Puntuación de calidad: %.0f%%",
+		QualityMissing:      "Falta: %s",
+		QualityGood:         "La incidencia está bien documentada",
+		PotentialDuplicate:  "Posible duplicado",
+		Similarity:          "Similitud: %.0f%%",
+		Original:            "Original: %s",
+		AreaOwners:          "Responsables del área",
+		AreaMention:         "cc %s - esta incidencia menciona un área de la que sois responsables.",
+		TransferHeading:     "Sugerencia de transferencia",
+		TransferBelongs:     "Esta incidencia parece pertenecer a **%s**.",
+		TransferIn:          "Esta incidencia se transferirá en %d horas.",
+		ReactPrompt:         "Reacciona a este comentario:",
+		TransferApprove:     "para aprobar y continuar con la transferencia",
+		TransferCancel:      "para cancelar esta transferencia",
+		Deadline:            "Fecha límite",
+		TransferAuto:        "Si no hay ninguna reacción, la transferencia se realizará automáticamente.",
+		TransferNow:         "La transferencia se realizará de inmediato.",
+		PoweredBy:           "Con la tecnología de %s",
+		SimilarThanks:       "¡Gracias por abrir esta incidencia!",
+		SimilarFound:        "Encontré algunas incidencias posiblemente relacionadas que podrían ser útiles:",
+		SimilarPrompt:       "Si alguna de estas resuelve tu problema, avísanos y podremos cerrar esta como duplicada.",
+		TriageHeading:       "Resumen de clasificación",
+		LabelsApplied:       "Etiquetas aplicadas",
+		LabelsHeading:       "Etiquetas",
+		LabelsNone:          "No se aplicaron etiquetas (no hubo coincidencias con suficiente confianza)",
+		SimilarIssues:       "Incidencias similares",
+		SimilarNone:         "No se encontraron incidencias similares",
+		DuplicateClosed:     "Esta incidencia se ha cerrado automáticamente por ser un duplicado.",
+		DuplicateFlagged:    "Esta incidencia parece ser un duplicado.",
+		OriginalIssue:       "**Incidencia original:** %s",
+		DuplicateSimilarity: "**Similitud:** %.0f%%",
+		DuplicateReopen:     "Si crees que no es un duplicado, comenta y la volveremos a abrir.",
+		DuplicateReview:     "Revisa la incidencia enlazada. Si resuelve tu problema, considera cerrar esta incidencia y seguir la original.",
+		CloseIn:             "Esta incidencia se cerrará como duplicada en %d horas",
+		CloseApprove:        "para aprobar y continuar con el cierre",
+		CloseCancel:         "para cancelar y añadir en su lugar la etiqueta potential-duplicate",
+		CloseAuto:           "Si no hay ninguna reacción, la incidencia se cerrará automáticamente.",
+		CloseCancelled:      "El cierre automático se ha cancelado según tu reacción.",
+		CloseKeptLabeled:    "La incidencia seguirá abierta y se ha etiquetado como `potential-duplicate` para que la revise un responsable.",
+		SpamCloseIn:         "Esta incidencia parece spam y se cerrará como no planificada en %d horas",
+		SpamConfidence:      "**Confianza:** %.0f%%",
+		SpamCloseCancel:     "para cancelar y mantener la incidencia abierta",
+		IssueKept:           "La incidencia seguirá abierta.",
+		TransferDone:        "Esta incidencia se ha transferido automáticamente a **%s** porque coincide con nuestras reglas de enrutamiento.",
+		TransferMoving:      "Esta incidencia se está transfiriendo automáticamente a **%s** porque coincide con nuestras reglas de enrutamiento.",
+		MatchedRule:         "**Regla coincidente:** %s",
+		RoutingRules:        "reglas de enrutamiento",
+		TransferContinue:    "La conversación continuará allí. ¡Gracias por tu reporte!",
+		ContinuedAt:         "**Continúa en:** %s",
+		TransferWarning:     "Esta incidencia se transferirá a %s en %d horas",
+		TransferRevert:      "**¿Un error?** Reacciona con %s a este comentario para revertir la transferencia.",
+		TransferCancelled:   "La transferencia a **%s** se ha cancelado según tu reacción.",
+		TransferKept:        "La incidencia seguirá en este repositorio.",
+		DraftPending:        "**Pendiente de aprobación.** Un responsable puede reaccionar con %s para publicar este resumen y aplicar sus acciones.",
+		DraftSummary:        "Borrador del resumen (pendiente de aprobación)",
+		DeadlineExtended:    "Un responsable amplió el plazo. %s tendrá lugar después de %s.",
+		ActionCancelled:     "Un responsable canceló lo siguiente: %s.",
+		ScheduledAction:     "La acción programada",
+		ScheduledTransfer:   "La transferencia a **%s**",
+		ScheduledDuplicate:  "El cierre de esta incidencia como duplicada",
+		ScheduledNotPlanned: "El cierre de esta incidencia como no planificada",
+		ScheduledDraft:      "El comentario en borrador",
+		DuplicateLinked:     "Se abrió un posible duplicado: %s (%.0f%% de similitud)",
+		RedactedMatch:       "una incidencia interna relacionada",
+		StateDiscussion:     "Discusión",
+		DiscussionClosed:    "Discusión (cerrada)",
+	},
+	"fr": {
+		SummaryHeading:      "Résumé de l'issue",
+		SummaryIntro:        "Merci d'avoir ouvert cette issue ! Voici ce que j'ai trouvé :",
+		RelatedIssues:       "Issues similaires",
+		RelatedPrompt:       "Si l'une d'elles résout votre problème, faites-le-nous savoir !",
+		ColumnIssue:         "Issue",
+		ColumnRepository:    "Dépôt",
+		ColumnSimilarity:    "Similarité",
+		ColumnStatus:        "Statut",
+		StateOpen:           "Ouverte",
+		StateClosed:         "Fermée",
+		LikelySpam:          "Spam probable",
+		Confidence:          "Confiance : %.0f%%",
+		SuggestedLabels:     "Labels suggérés",
+		LabelConfidence:     "confiance %.0f%%",
+		QualityScore:        "Score de qualité : %.0f%%",
+		QualityMissing:      "Manquant : %s",
+		QualityGood:         "L'issue est bien documentée",
+		PotentialDuplicate:  "Doublon potentiel",
+		Similarity:          "Similarité : %.0f%%",
+		Original:            "Original : %s",
+		AreaOwners:          "Responsables du domaine",
+		AreaMention:         "cc %s - cette issue concerne un domaine dont vous êtes responsables.",
+		TransferHeading:     "Suggestion de transfert",
+		TransferBelongs:     "Cette issue semble appartenir à **%s**.",
+		TransferIn:          "Cette issue sera transférée dans %d heures.",
+		ReactPrompt:         "Réagissez à ce commentaire :",
+		TransferApprove:     "pour approuver et effectuer le transfert",
+		TransferCancel:      "pour annuler ce transfert",
+		Deadline:            "Échéance",
+		TransferAuto:        "Sans réaction, le transfert sera effectué automatiquement.",
+		TransferNow:         "Le transfert sera effectué immédiatement.",
+		PoweredBy:           "Propulsé par %s",
+		SimilarThanks:       "Merci d'avoir ouvert cette issue !",
+		SimilarFound:        "J'ai trouvé des issues peut-être liées qui pourraient vous aider :",
+		SimilarPrompt:       "Si l'une d'elles résout votre problème, faites-le-nous savoir et nous pourrons fermer celle-ci comme doublon.",
+		TriageHeading:       "Résumé du tri",
+		LabelsApplied:       "Labels appliqués",
+		LabelsHeading:       "Labels",
+		LabelsNone:          "Aucun label appliqué (aucune correspondance suffisamment fiable)",
+		SimilarIssues:       "Issues similaires",
+		SimilarNone:         "Aucune issue similaire trouvée",
+		DuplicateClosed:     "Cette issue a été fermée automatiquement comme doublon.",
+		DuplicateFlagged:    "Cette issue semble être un doublon.",
+		OriginalIssue:       "**Issue d'origine :** %s",
+		DuplicateSimilarity: "**Similarité :** %.0f%%",
+		DuplicateReopen:     "Si vous pensez qu'il ne s'agit pas d'un doublon, laissez un commentaire et nous la rouvrirons.",
+		DuplicateReview:     "Consultez l'issue liée. Si elle répond à votre problème, pensez à fermer cette issue et à suivre l'originale.",
+		CloseIn:             "Cette issue sera fermée comme doublon dans %d heures",
+		CloseApprove:        "pour approuver et procéder à la fermeture",
+		CloseCancel:         "pour annuler et ajouter plutôt le label potential-duplicate",
+		CloseAuto:           "Sans réaction, l'issue sera fermée automatiquement.",
+		CloseCancelled:      "La fermeture automatique a été annulée suite à votre réaction.",
+		CloseKeptLabeled:    "L'issue reste ouverte et a reçu le label `potential-duplicate` pour examen par un mainteneur.",
+		SpamCloseIn:         "Cette issue ressemble à du spam et sera fermée comme non planifiée dans %d heures",
+		SpamConfidence:      "**Confiance :** %.0f%%",
+		SpamCloseCancel:     "pour annuler et garder l'issue ouverte",
+		IssueKept:           "L'issue reste ouverte.",
+		TransferDone:        "Cette issue a été transférée automatiquement vers **%s** car elle correspond à nos règles de routage.",
+		TransferMoving:      "Cette issue est en cours de transfert automatique vers **%s** car elle correspond à nos règles de routage.",
+		MatchedRule:         "**Règle correspondante :** %s",
+		RoutingRules:        "règles de routage",
+		TransferContinue:    "La discussion se poursuivra là-bas. Merci pour votre signalement !",
+		ContinuedAt:         "**Suite ici :** %s",
+		TransferWarning:     "Cette issue sera transférée vers %s dans %d heures",
+		TransferRevert:      "**Une erreur ?** Réagissez avec %s à ce commentaire pour annuler le transfert.",
+		TransferCancelled:   "Le transfert vers **%s** a été annulé suite à votre réaction.",
+		TransferKept:        "L'issue reste dans ce dépôt.",
+		DraftPending:        "**En attente d'approbation.** Un mainteneur peut réagir avec %s pour publier ce résumé et appliquer ses actions.",
+		DraftSummary:        "Brouillon du résumé (en attente d'approbation)",
+		DeadlineExtended:    "Délai prolongé par un mainteneur. %s aura lieu après %s.",
+		ActionCancelled:     "Annulé par un mainteneur : %s.",
+		ScheduledAction:     "L'action planifiée",
+		ScheduledTransfer:   "Le transfert vers **%s**",
+		ScheduledDuplicate:  "La fermeture de cette issue comme doublon",
+		ScheduledNotPlanned: "La fermeture de cette issue comme non planifiée",
+		ScheduledDraft:      "Le commentaire en brouillon",
+		DuplicateLinked:     "Un doublon possible a été ouvert : %s (%.0f%% de similarité)",
+		RedactedMatch:       "une issue interne liée",
+		StateDiscussion:     "Discussion",
+		DiscussionClosed:    "Discussion (fermée)",
+	},
+	"de": {
+		SummaryHeading:      "Issue-Zusammenfassung",
+		SummaryIntro:        "Danke für das Erstellen dieses Issues! Folgendes habe ich gefunden:",
+		RelatedIssues:       "Ähnliche Issues",
+		RelatedPrompt:       "Falls eines davon dein Problem löst, gib uns bitte Bescheid!",
+		ColumnIssue:         "Issue",
+		ColumnRepository:    "Repository",
+		ColumnSimilarity:    "Ähnlichkeit",
+		ColumnStatus:        "Status",
+		StateOpen:           "Offen",
+		StateClosed:         "Geschlossen",
+		LikelySpam:          "Wahrscheinlich Spam",
+		Confidence:          "Konfidenz: %.0f%%",
+		SuggestedLabels:     "Vorgeschlagene Labels",
+		LabelConfidence:     "%.0f%% Konfidenz",
+		QualityScore:        "Qualitätsbewertung: %.0f%%",
+		QualityMissing:      "Fehlt: %s",
+		QualityGood:         "Das Issue ist gut dokumentiert",
+		PotentialDuplicate:  "Mögliches Duplikat",
+		Similarity:          "Ähnlichkeit: %.0f%%",
+		Original:            "Original: %s",
+		AreaOwners:          "Verantwortliche",
+		AreaMention:         "cc %s - dieses Issue betrifft einen Bereich, für den ihr verantwortlich seid.",
+		TransferHeading:     "Übertragungsvorschlag",
+		TransferBelongs:     "Dieses Issue gehört anscheinend nach **%s**.",
+		TransferIn:          "Dieses Issue wird in %d Stunden übertragen.",
+		ReactPrompt:         "Reagiere auf diesen Kommentar:",
+		TransferApprove:     "um die Übertragung zu bestätigen",
+		TransferCancel:      "um die Übertragung abzubrechen",
+		Deadline:            "Frist",
+		TransferAuto:        "Ohne Reaktion wird die Übertragung automatisch durchgeführt.",
+		TransferNow:         "Die Übertragung wird sofort durchgeführt.",
+		PoweredBy:           "Bereitgestellt von %s",
+		SimilarThanks:       "Danke für das Erstellen dieses Issues!",
+		SimilarFound:        "Ich habe einige möglicherweise verwandte Issues gefunden, die hilfreich sein könnten:",
+		SimilarPrompt:       "Falls eines davon dein Problem löst, gib uns Bescheid, dann schließen wir dieses als Duplikat.",
+		TriageHeading:       "Triage-Zusammenfassung",
+		LabelsApplied:       "Vergebene Labels",
+		LabelsHeading:       "Labels",
+		LabelsNone:          "Keine Labels vergeben (keine ausreichend sicheren Treffer)",
+		SimilarIssues:       "Ähnliche Issues",
+		SimilarNone:         "Keine ähnlichen Issues gefunden",
+		DuplicateClosed:     "Dieses Issue wurde automatisch als Duplikat geschlossen.",
+		DuplicateFlagged:    "Dieses Issue scheint ein Duplikat zu sein.",
+		OriginalIssue:       "**Ursprüngliches Issue:** %s",
+		DuplicateSimilarity: "**Ähnlichkeit:** %.0f%%",
+		DuplicateReopen:     "Falls es sich nicht um ein Duplikat handelt, schreib einen Kommentar und wir öffnen es wieder.",
+		DuplicateReview:     "Bitte sieh dir das verlinkte Issue an. Falls es dein Anliegen abdeckt, schließe dieses Issue und verfolge das ursprüngliche.",
+		CloseIn:             "Dieses Issue wird in %d Stunden als Duplikat geschlossen",
+		CloseApprove:        "um das Schließen zu bestätigen",
+		CloseCancel:         "um abzubrechen und stattdessen das Label potential-duplicate zu setzen",
+		CloseAuto:           "Ohne Reaktion wird das Issue automatisch geschlossen.",
+		CloseCancelled:      "Das automatische Schließen wurde aufgrund deiner Reaktion abgebrochen.",
+		CloseKeptLabeled:    "Das Issue bleibt offen und wurde zur Prüfung durch die Maintainer mit `potential-duplicate` gekennzeichnet.",
+		SpamCloseIn:         "Dieses Issue sieht nach Spam aus und wird in %d Stunden als nicht geplant geschlossen",
+		SpamConfidence:      "**Konfidenz:** %.0f%%",
+		SpamCloseCancel:     "um abzubrechen und das Issue offen zu lassen",
+		IssueKept:           "Das Issue bleibt offen.",
+		TransferDone:        "Dieses Issue wurde automatisch nach **%s** übertragen, weil es unseren Routing-Regeln entspricht.",
+		TransferMoving:      "Dieses Issue wird automatisch nach **%s** übertragen, weil es unseren Routing-Regeln entspricht.",
+		MatchedRule:         "**Zutreffende Regel:** %s",
+		RoutingRules:        "Routing-Regeln",
+		TransferContinue:    "Die Diskussion geht dort weiter. Danke für deine Meldung!",
+		ContinuedAt:         "**Weiter unter:** %s",
+		TransferWarning:     "Dieses Issue wird nach %s übertragen (in %d Stunden)",
+		TransferRevert:      "**Ein Fehler?** Reagiere mit %s auf diesen Kommentar, um die Übertragung rückgängig zu machen.",
+		TransferCancelled:   "Die Übertragung nach **%s** wurde aufgrund deiner Reaktion abgebrochen.",
+		TransferKept:        "Das Issue bleibt in diesem Repository.",
+		DraftPending:        "**Wartet auf Freigabe.** Eine verantwortliche Person kann mit %s reagieren, um diese Zusammenfassung zu veröffentlichen und ihre Aktionen auszuführen.",
+		DraftSummary:        "Entwurf der Zusammenfassung (wartet auf Freigabe)",
+		DeadlineExtended:    "Frist von einer verantwortlichen Person verlängert. %s erfolgt jetzt nach %s.",
+		ActionCancelled:     "Von einer verantwortlichen Person abgebrochen: %s.",
+		ScheduledAction:     "Die geplante Aktion",
+		ScheduledTransfer:   "Die Übertragung nach **%s**",
+		ScheduledDuplicate:  "Das Schließen dieses Issues als Duplikat",
+		ScheduledNotPlanned: "Das Schließen dieses Issues als nicht geplant",
+		ScheduledDraft:      "Der Kommentarentwurf",
+		DuplicateLinked:     "Ein mögliches Duplikat wurde eröffnet: %s (%.0f%% ähnlich)",
+		RedactedMatch:       "ein verwandtes internes Issue",
+		StateDiscussion:     "Diskussion",
+		DiscussionClosed:    "Diskussion (geschlossen)",
+	},
+	"pt": {
+		SummaryHeading:      "Resumo da issue",
+		SummaryIntro:        "Obrigado por abrir esta issue! Veja o que encontrei:",
+		RelatedIssues:       "Issues relacionadas",
+		RelatedPrompt:       "Se alguma delas resolver o seu problema, avise-nos!",
+		ColumnIssue:         "Issue",
+		ColumnRepository:    "Repositório",
+		ColumnSimilarity:    "Similaridade",
+		ColumnStatus:        "Status",
+		StateOpen:           "Aberta",
+		StateClosed:         "Fechada",
+		LikelySpam:          "Provável spam",
+		Confidence:          "Confiança: %.0f%%",
+		SuggestedLabels:     "Labels sugeridas",
+		LabelConfidence:     "%.0f%% de confiança",
+		QualityScore:        "Pontuação de qualidade: %.0f%%",
+		QualityMissing:      "Faltando: %s",
+		QualityGood:         "A issue está bem documentada",
+		PotentialDuplicate:  "Possível duplicata",
+		Similarity:          "Similaridade: %.0f%%",
+		Original:            "Original: %s",
+		AreaOwners:          "Responsáveis pela área",
+		AreaMention:         "cc %s - esta issue menciona uma área sob sua responsabilidade.",
+		TransferHeading:     "Sugestão de transferência",
+		TransferBelongs:     "Esta issue parece pertencer a **%s**.",
+		TransferIn:          "Esta issue será transferida em %d horas.",
+		ReactPrompt:         "Reaja a este comentário:",
+		TransferApprove:     "para aprovar e prosseguir com a transferência",
+		TransferCancel:      "para cancelar esta transferência",
+		Deadline:            "Prazo",
+		TransferAuto:        "Se não houver reação, a transferência será feita automaticamente.",
+		TransferNow:         "A transferência será executada imediatamente.",
+		PoweredBy:           "Desenvolvido com %s",
+		SimilarThanks:       "Obrigado por abrir esta issue!",
+		SimilarFound:        "Encontrei algumas issues possivelmente relacionadas que podem ajudar:",
+		SimilarPrompt:       "Se alguma delas resolver o seu problema, avise-nos e podemos fechar esta como duplicata.",
+		TriageHeading:       "Resumo da triagem",
+		LabelsApplied:       "Labels aplicadas",
+		LabelsHeading:       "Labels",
+		LabelsNone:          "Nenhuma label aplicada (nenhuma correspondência com confiança suficiente)",
+		SimilarIssues:       "Issues semelhantes",
+		SimilarNone:         "Nenhuma issue semelhante encontrada",
+		DuplicateClosed:     "Esta issue foi fechada automaticamente como duplicata.",
+		DuplicateFlagged:    "Esta issue parece ser uma duplicata.",
+		OriginalIssue:       "**Issue original:** %s",
+		DuplicateSimilarity: "**Similaridade:** %.0f%%",
+		DuplicateReopen:     "Se você acredita que não é uma duplicata, comente e nós a reabriremos.",
+		DuplicateReview:     "Verifique a issue vinculada. Se ela resolver o seu problema, considere fechar esta issue e acompanhar a original.",
+		CloseIn:             "Esta issue será fechada como duplicata em %d horas",
+		CloseApprove:        "para aprovar e prosseguir com o fechamento",
+		CloseCancel:         "para cancelar e adicionar a label potential-duplicate no lugar",
+		CloseAuto:           "Se não houver reação, a issue será fechada automaticamente.",
+		CloseCancelled:      "O fechamento automático foi cancelado com base na sua reação.",
+		CloseKeptLabeled:    "A issue continuará aberta e recebeu a label `potential-duplicate` para revisão dos mantenedores.",
+		SpamCloseIn:         "Esta issue parece spam e será fechada como não planejada em %d horas",
+		SpamConfidence:      "**Confiança:** %.0f%%",
+		SpamCloseCancel:     "para cancelar e manter a issue aberta",
+		IssueKept:           "A issue continuará aberta.",
+		TransferDone:        "Esta issue foi transferida automaticamente para **%s** porque corresponde às nossas regras de roteamento.",
+		TransferMoving:      "Esta issue está sendo transferida automaticamente para **%s** porque corresponde às nossas regras de roteamento.",
+		MatchedRule:         "**Regra correspondente:** %s",
+		RoutingRules:        "regras de roteamento",
+		TransferContinue:    "A discussão continuará lá. Obrigado pelo seu relato!",
+		ContinuedAt:         "**Continua em:** %s",
+		TransferWarning:     "Esta issue será transferida para %s em %d horas",
+		TransferRevert:      "**Foi um engano?** Reaja com %s a este comentário para reverter a transferência.",
+		TransferCancelled:   "A transferência para **%s** foi cancelada com base na sua reação.",
+		TransferKept:        "A issue continuará neste repositório.",
+		DraftPending:        "**Aguardando aprovação.** Um mantenedor pode reagir com %s para publicar este resumo e aplicar suas ações.",
+		DraftSummary:        "Rascunho do resumo (aguardando aprovação)",
+		DeadlineExtended:    "Prazo estendido por um mantenedor. %s acontecerá depois de %s.",
+		ActionCancelled:     "Cancelado por um mantenedor: %s.",
+		ScheduledAction:     "A ação agendada",
+		ScheduledTransfer:   "A transferência para **%s**",
+		ScheduledDuplicate:  "O fechamento desta issue como duplicata",
+		ScheduledNotPlanned: "O fechamento desta issue como não planejada",
+		ScheduledDraft:      "O comentário em rascunho",
+		DuplicateLinked:     "Uma possível duplicata foi aberta: %s (%.0f%% de similaridade)",
+		RedactedMatch:       "uma issue interna relacionada",
+		StateDiscussion:     "Discussão",
+		DiscussionClosed:    "Discussão (fechada)",
+	},
+}
+
+// Supported reports whether lang (e.g. "de" or "pt-BR") has a catalog
+func Supported(lang string) bool {
+	_, ok := catalogs[base(lang)]
+	return ok
+}
+
+// Languages lists the languages with a catalog
+func Languages() []string {
+	return slices.Sorted(maps.Keys(catalogs))
+}
+
+// Message returns the string for key in lang, falling back to English for
+// unknown languages and untranslated keys
+func Message(lang string, key Key) string {
+	if msg, ok := catalogs[base(lang)][key]; ok {
+		return msg
+	}
+	return catalogs[Default][key]
+}
+
+// base reduces a locale such as "pt-BR" or "pt_BR" to its language
+func base(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" {
+		return Default
+	}
+	return lang
+}
@@ -0,0 +1,33 @@
+package locale
+
+import "testing"
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		lang string
+		key  Key
+		want string
+	}{
+		{"", SummaryHeading, "Issue Intelligence Summary"},
+		{"de", StateOpen, "Offen"},
+		{"pt-BR", StateClosed, "Fechada"},
+		{"ES", ColumnStatus, "Estado"},
+		{"xx", StateOpen, "Open"},
+	}
+
+	for _, tt := range tests {
+		if got := Message(tt.lang, tt.key); got != tt.want {
+			t.Errorf("Message(%q, %q) = %q, want %q", tt.lang, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range catalog {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("%s: key %q has no English string", lang, key)
+			}
+		}
+	}
+}
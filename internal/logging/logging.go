@@ -0,0 +1,196 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Level is a log severity; messages below the configured level are dropped
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Format selects how log lines are rendered
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+var current atomic.Int32
+
+// jsonLogger renders every message as a JSON object when set; nil keeps the
+// plain log.Printf-style text output
+var jsonLogger atomic.Pointer[slog.Logger]
+
+func init() {
+	current.Store(int32(LevelInfo))
+}
+
+// ParseLevel converts a --log-level value (debug, info, warn, error) to a Level
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("invalid log level %q (want debug, info, warn, or error)", s)
+	}
+}
+
+// ParseFormat converts a --log-format value (text, json) to a Format
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatText, "":
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("invalid log format %q (want text or json)", s)
+	}
+}
+
+// SetFormat switches between text and JSON output on stderr
+func SetFormat(f Format) {
+	setFormat(f, os.Stderr)
+}
+
+func setFormat(f Format, w io.Writer) {
+	if f != FormatJSON {
+		jsonLogger.Store(nil)
+		return
+	}
+	// Levels are filtered by Enabled, so the handler accepts everything
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+	jsonLogger.Store(slog.New(handler))
+}
+
+// SetLevel sets the minimum level that is logged
+func SetLevel(l Level) {
+	current.Store(int32(l))
+}
+
+// Enabled reports whether messages at l are logged
+func Enabled(l Level) bool {
+	return l >= Level(current.Load())
+}
+
+// Debugf logs diagnostic detail such as scores, dimensions, and decisions
+func Debugf(format string, args ...any) {
+	logf(LevelDebug, "Debug: ", format, args...)
+}
+
+// Infof logs normal progress
+func Infof(format string, args ...any) {
+	logf(LevelInfo, "", format, args...)
+}
+
+// Warnf logs a recoverable problem
+func Warnf(format string, args ...any) {
+	logf(LevelWarn, "Warning: ", format, args...)
+}
+
+// Errorf logs a failure
+func Errorf(format string, args ...any) {
+	logf(LevelError, "Error: ", format, args...)
+}
+
+// Debug logs msg with key/value fields such as "issue", 42, "score", 0.91
+func Debug(msg string, args ...any) {
+	logAttrs(LevelDebug, "Debug: ", msg, args...)
+}
+
+// Info logs msg with key/value fields
+func Info(msg string, args ...any) {
+	logAttrs(LevelInfo, "", msg, args...)
+}
+
+// Warn logs msg with key/value fields
+func Warn(msg string, args ...any) {
+	logAttrs(LevelWarn, "Warning: ", msg, args...)
+}
+
+// Error logs msg with key/value fields
+func Error(msg string, args ...any) {
+	logAttrs(LevelError, "Error: ", msg, args...)
+}
+
+func logf(l Level, prefix, format string, args ...any) {
+	if !Enabled(l) {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if logger := jsonLogger.Load(); logger != nil {
+		logger.Log(context.Background(), l.slogLevel(), msg)
+		return
+	}
+	log.Output(3, prefix+msg)
+}
+
+func logAttrs(l Level, prefix, msg string, args ...any) {
+	if !Enabled(l) {
+		return
+	}
+	if logger := jsonLogger.Load(); logger != nil {
+		logger.Log(context.Background(), l.slogLevel(), msg, args...)
+		return
+	}
+
+	// Let slog pair up the arguments so odd or attr-valued args render the
+	// same way in both formats
+	record := slog.NewRecord(time.Time{}, l.slogLevel(), msg, 0)
+	record.Add(args...)
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteString(msg)
+	record.Attrs(func(a slog.Attr) bool {
+		sb.WriteString(" ")
+		sb.WriteString(a.Key)
+		sb.WriteString("=")
+		sb.WriteString(textValue(a.Value))
+		return true
+	})
+	log.Output(3, sb.String())
+}
+
+// textValue quotes values containing spaces so fields stay splittable
+func textValue(v slog.Value) string {
+	s := v.Resolve().String()
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func (l Level) slogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
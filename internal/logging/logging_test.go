@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{input: "debug", want: LevelDebug},
+		{input: "INFO", want: LevelInfo},
+		{input: "", want: LevelInfo},
+		{input: "warning", want: LevelWarn},
+		{input: "error", want: LevelError},
+		{input: "trace", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	defer SetLevel(LevelInfo)
+
+	SetLevel(LevelWarn)
+	if Enabled(LevelInfo) {
+		t.Error("info should be dropped at warn level")
+	}
+	if !Enabled(LevelError) {
+		t.Error("error should be logged at warn level")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Errorf("ParseFormat(JSON) = %v, %v", f, err)
+	}
+	if f, err := ParseFormat(""); err != nil || f != FormatText {
+		t.Errorf("ParseFormat(\"\") = %v, %v", f, err)
+	}
+	if _, err := ParseFormat("logfmt"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	defer setFormat(FormatText, nil)
+
+	var buf bytes.Buffer
+	setFormat(FormatJSON, &buf)
+	Info("similarity search", "repo", "org/app", "issue", 42, "score", 0.9)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("output is not JSON: %v (%s)", err, buf.String())
+	}
+	if entry["msg"] != "similarity search" || entry["level"] != "INFO" {
+		t.Errorf("unexpected msg/level: %v", entry)
+	}
+	if entry["repo"] != "org/app" || entry["issue"] != float64(42) || entry["score"] != 0.9 {
+		t.Errorf("fields not carried through: %v", entry)
+	}
+
+	buf.Reset()
+	Warnf("batch %d failed", 3)
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil || entry["msg"] != "batch 3 failed" || entry["level"] != "WARN" {
+		t.Errorf("formatted message not logged as JSON: %s", buf.String())
+	}
+}
+
+func TestTextFields(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	Warn("step failed", "step", "similarity", "error", "connection refused")
+	want := "Warning: step failed step=similarity error=\"connection refused\"\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,197 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+const (
+	draftStartMarker = "<!-- simili-draft-start -->"
+	draftEndMarker   = "<!-- simili-draft-end -->"
+
+	// metadataLabels is the PendingAction.Metadata key listing labels to apply on approval
+	metadataLabels = "labels"
+)
+
+// FormatDraftComment wraps body in a collapsed block awaiting maintainer
+// approval. The action metadata is appended so the draft can be found later.
+func FormatDraftComment(body string, action *PendingAction, approveReaction string, st style.Style) (string, error) {
+	metadata, err := FormatPendingActionMetadata(action)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(st.Icon("⏳") + st.Textf(locale.DraftPending, st.Reaction("👍", approveReaction)) + "\n\n")
+	sb.WriteString("<details>\n<summary>" + st.Text(locale.DraftSummary) + "</summary>\n\n")
+	sb.WriteString(draftStartMarker + "\n")
+	sb.WriteString(body)
+	sb.WriteString("\n" + draftEndMarker + "\n\n</details>\n\n")
+	sb.WriteString(metadata)
+	return sb.String(), nil
+}
+
+// ExtractDraftBody returns the original comment body wrapped by FormatDraftComment
+func ExtractDraftBody(comment string) (string, bool) {
+	start := strings.Index(comment, draftStartMarker)
+	end := strings.LastIndex(comment, draftEndMarker)
+	if start == -1 || end == -1 || end < start {
+		return "", false
+	}
+	return strings.TrimSpace(comment[start+len(draftStartMarker) : end]), true
+}
+
+// NewCommentApproval creates a pending comment action that applies labels once approved
+func NewCommentApproval(issue *models.Issue, labels []string, delayHours int) *PendingAction {
+	now := time.Now()
+	return &PendingAction{
+		Type:        ActionTypeComment,
+		Org:         issue.Org,
+		Repo:        issue.Repo,
+		IssueNumber: issue.Number,
+		ScheduledAt: now,
+		ExpiresAt:   now.Add(time.Duration(delayHours) * time.Hour),
+		Metadata:    map[string]string{metadataLabels: strings.Join(labels, ",")},
+	}
+}
+
+// ScheduleComment marks an issue as having a draft awaiting approval
+func (m *Manager) ScheduleComment(ctx context.Context, issue *models.Issue) error {
+	return m.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{LabelPendingComment})
+}
+
+// ProcessPendingComment publishes a drafted comment once a maintainer
+// approves it, and applies its labels. A maintainer's cancel reaction or
+// expiry without approval drops the draft; nothing is posted automatically.
+func (m *Manager) ProcessPendingComment(ctx context.Context, action *PendingAction, dryRun bool) error {
+	delayed := m.cfg.Defaults.DelayedActions
+
+	cancelled, err := m.maintainerReacted(ctx, action, delayed.Cancellations())
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		logging.Infof("Draft comment on %s/%s#%d rejected by a maintainer", action.Org, action.Repo, action.IssueNumber)
+		if dryRun {
+			return nil
+		}
+		return m.Cancel(ctx, action)
+	}
+
+	approved, err := m.maintainerReacted(ctx, action, delayed.Approvals())
+	if err != nil {
+		return err
+	}
+	if !approved {
+		if action.IsExpired() {
+			logging.Infof("Draft comment on %s/%s#%d expired without approval", action.Org, action.Repo, action.IssueNumber)
+			if dryRun {
+				return nil
+			}
+			return m.Cancel(ctx, action)
+		}
+		return nil // Still waiting
+	}
+
+	if dryRun {
+		logging.Infof("[DRY RUN] Would publish draft comment on %s/%s#%d", action.Org, action.Repo, action.IssueNumber)
+		return nil
+	}
+
+	comment, err := m.gh.GetComment(ctx, action.Org, action.Repo, action.CommentID)
+	if err != nil {
+		return err
+	}
+	body, ok := ExtractDraftBody(comment.Body)
+	if !ok {
+		return fmt.Errorf("draft body not found in comment %d", action.CommentID)
+	}
+
+	// Publish as a new comment so the approval reaction on the draft doesn't
+	// also approve the transfer or close it schedules, which get a fresh
+	// objection window from now
+	body, err = rescheduleActions(body, time.Duration(delayed.DelayHours)*time.Hour, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := m.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, body); err != nil {
+		return err
+	}
+	// The draft still carries the old schedule; leaving it would shadow the new one
+	if err := m.gh.DeleteComment(ctx, action.Org, action.Repo, action.CommentID); err != nil {
+		return fmt.Errorf("failed to remove published draft: %w", err)
+	}
+
+	if labels := approvedLabels(action); len(labels) > 0 {
+		if err := m.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, labels); err != nil {
+			return err
+		}
+	}
+
+	// Published; clear the pending label
+	return m.Cancel(ctx, action)
+}
+
+// rescheduleActions restarts the delay of every transfer and close action in
+// a comment body from now, updating their metadata and displayed deadlines
+func rescheduleActions(body string, delay time.Duration, now time.Time) (string, error) {
+	for _, match := range metadataRegex.FindAllStringSubmatch(body, -1) {
+		var action PendingAction
+		if err := json.Unmarshal([]byte(match[1]), &action); err != nil {
+			continue
+		}
+		if action.Type != ActionTypeTransfer && action.Type != ActionTypeClose {
+			continue
+		}
+
+		oldDeadline := action.ExpiresAt.Local().Format(DeadlineLayout)
+		action.ScheduledAt = now
+		action.ExpiresAt = now.Add(delay)
+		metadata, err := FormatPendingActionMetadata(&action)
+		if err != nil {
+			return "", err
+		}
+		body = strings.Replace(body, match[0], metadata, 1)
+		body = strings.ReplaceAll(body, oldDeadline, action.ExpiresAt.Local().Format(DeadlineLayout))
+	}
+	return body, nil
+}
+
+// maintainerReacted reports whether a user with write access left any of
+// reactions on the action's comment
+func (m *Manager) maintainerReacted(ctx context.Context, action *PendingAction, reactions []string) (bool, error) {
+	users, err := m.gh.GetReactionUsers(ctx, action.Org, action.Repo, action.CommentID, reactions...)
+	if err != nil {
+		return false, fmt.Errorf("failed to check reactions: %w", err)
+	}
+
+	for _, user := range users {
+		ok, err := m.gh.HasWriteAccess(ctx, action.Org, action.Repo, user)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// approvedLabels returns the labels recorded on a pending comment action
+func approvedLabels(action *PendingAction) []string {
+	var labels []string
+	for _, l := range strings.Split(action.Metadata[metadataLabels], ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
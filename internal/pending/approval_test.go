@@ -0,0 +1,90 @@
+package pending
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestDraftComment_RoundTrip(t *testing.T) {
+	issue := &models.Issue{Org: "org", Repo: "repo", Number: 12}
+
+	transfer := &PendingAction{Type: ActionTypeTransfer, IssueNumber: 12, Target: "org/other"}
+	transferMeta, err := FormatPendingActionMetadata(transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := "## Summary\n\nThis belongs elsewhere.\n" + transferMeta
+
+	approval := NewCommentApproval(issue, []string{"bug", LabelPendingTransfer}, 24)
+	draft, err := FormatDraftComment(body, approval, "+1", style.Style{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(draft, "react 👍 (+1) to publish") {
+		t.Errorf("draft does not name the approve reaction:\n%s", draft)
+	}
+
+	got, ok := ExtractDraftBody(draft)
+	if !ok {
+		t.Fatal("ExtractDraftBody() found no draft")
+	}
+	if got != body {
+		t.Errorf("ExtractDraftBody() = %q, want %q", got, body)
+	}
+
+	// Both actions in the draft must remain individually parseable
+	var types []ActionType
+	for _, m := range metadataRegex.FindAllStringSubmatch(draft, -1) {
+		var a PendingAction
+		if err := json.Unmarshal([]byte(m[1]), &a); err != nil {
+			t.Fatalf("metadata %q did not parse: %v", m[1], err)
+		}
+		types = append(types, a.Type)
+	}
+	if len(types) != 2 || types[0] != ActionTypeTransfer || types[1] != ActionTypeComment {
+		t.Errorf("parsed action types = %v, want [transfer comment]", types)
+	}
+
+	labels := approvedLabels(approval)
+	if len(labels) != 2 || labels[0] != "bug" || labels[1] != LabelPendingTransfer {
+		t.Errorf("approvedLabels() = %v", labels)
+	}
+}
+
+func TestExtractDraftBody_NotDraft(t *testing.T) {
+	if _, ok := ExtractDraftBody("just a regular comment"); ok {
+		t.Error("ExtractDraftBody() reported a draft in a regular comment")
+	}
+}
+
+func TestRescheduleActions(t *testing.T) {
+	scheduled := time.Date(2026, 1, 1, 9, 0, 0, 0, time.Local)
+	transfer := &PendingAction{Type: ActionTypeTransfer, IssueNumber: 12, Target: "org/other", CommentID: 99, ScheduledAt: scheduled, ExpiresAt: scheduled.Add(24 * time.Hour)}
+	transferMeta, err := FormatPendingActionMetadata(transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := "**Deadline**: " + transfer.ExpiresAt.Format(DeadlineLayout) + "\n" + transferMeta
+
+	now := scheduled.Add(30 * time.Hour)
+	got, err := rescheduleActions(body, 24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action, err := ParsePendingActionMetadata(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !action.ScheduledAt.Equal(now) || !action.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("rescheduled action = %v..%v, want a fresh window from %v", action.ScheduledAt, action.ExpiresAt, now)
+	}
+	if !strings.Contains(got, "**Deadline**: "+now.Add(24*time.Hour).Format(DeadlineLayout)) {
+		t.Errorf("displayed deadline not updated: %q", got)
+	}
+}
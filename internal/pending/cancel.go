@@ -0,0 +1,28 @@
+package pending
+
+import (
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/style"
+)
+
+// FormatCancelledComment notifies that a maintainer cancelled the action
+// directly rather than by reacting on the warning comment
+func FormatCancelledComment(action *PendingAction, st style.Style) string {
+	return st.Icon("✅") + st.Textf(locale.ActionCancelled, action.subject(st)) + "\n\n" + st.Footer("Simili")
+}
+
+// subject names the action in the extend and cancel notices
+func (a *PendingAction) subject(st style.Style) string {
+	switch a.Type {
+	case ActionTypeTransfer:
+		return st.Textf(locale.ScheduledTransfer, a.Target)
+	case ActionTypeClose:
+		if a.ClosesAsDuplicate() {
+			return st.Text(locale.ScheduledDuplicate)
+		}
+		return st.Text(locale.ScheduledNotPlanned)
+	case ActionTypeComment:
+		return st.Text(locale.ScheduledDraft)
+	}
+	return st.Text(locale.ScheduledAction)
+}
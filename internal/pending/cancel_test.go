@@ -0,0 +1,31 @@
+package pending
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/style"
+)
+
+func TestFormatCancelledComment(t *testing.T) {
+	tests := []struct {
+		name   string
+		action *PendingAction
+		lang   string
+		want   string
+	}{
+		{"duplicate", &PendingAction{Type: ActionTypeClose, Target: "https://github.com/org/app/issues/1"}, "", "Closing this issue as a duplicate has been cancelled"},
+		{"spam", &PendingAction{Type: ActionTypeClose}, "", "Closing this issue as not planned has been cancelled"},
+		{"transfer", &PendingAction{Type: ActionTypeTransfer, Target: "org/docs"}, "", "The transfer to **org/docs** has been cancelled"},
+		{"localized", &PendingAction{Type: ActionTypeClose}, "de", "abgebrochen: Das Schließen dieses Issues als nicht geplant."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := style.Style{}.Localized(tt.lang)
+			if got := FormatCancelledComment(tt.action, st); !strings.Contains(got, tt.want) {
+				t.Errorf("FormatCancelledComment() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
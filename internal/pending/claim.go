@@ -0,0 +1,111 @@
+package pending
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+const claimPattern = `<!-- simili-processing: (\S+) -->`
+
+var claimRegex = regexp.MustCompile(claimPattern)
+
+// FormatClaimMarker formats the hidden marker a run posts to claim an issue
+func FormatClaimMarker(runID string) string {
+	return fmt.Sprintf("<!-- simili-processing: %s -->", runID)
+}
+
+// RunID identifies this process for claims, preferring the Actions run
+func RunID() string {
+	if id := os.Getenv("GITHUB_RUN_ID"); id != "" {
+		if attempt := os.Getenv("GITHUB_RUN_ATTEMPT"); attempt != "" {
+			return id + "-" + attempt
+		}
+		return id
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// Claim marks the issue as being processed by runID. It posts a claim
+// marker and then re-reads the comments: the earliest claim within window
+// wins, so two runs racing on the same issue agree on a single owner. When
+// another run holds the claim, our marker is removed and claimed is false.
+// A winning marker is kept until the window expires, so runs that start
+// after this one finishes are skipped too; expired markers are deleted by
+// the next run that claims the issue.
+func (m *Manager) Claim(ctx context.Context, issue *models.Issue, runID string, window time.Duration) (bool, error) {
+	comments, err := m.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		return false, err
+	}
+	if owner := claimOwner(comments, window, time.Now()); owner != "" && owner != runID {
+		return false, nil
+	}
+	for _, id := range expiredClaims(comments, window, time.Now()) {
+		if err := m.gh.DeleteComment(ctx, issue.Org, issue.Repo, id); err != nil {
+			logging.Warnf("failed to delete expired claim on #%d: %v", issue.Number, err)
+		}
+	}
+
+	if err := m.gh.PostComment(ctx, issue.Org, issue.Repo, issue.Number, FormatClaimMarker(runID)); err != nil {
+		return false, fmt.Errorf("failed to post claim: %w", err)
+	}
+
+	comments, err = m.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		return false, err
+	}
+
+	if owner := claimOwner(comments, window, time.Now()); owner != runID {
+		if id := claimCommentID(comments, runID); id != 0 {
+			if err := m.gh.DeleteComment(ctx, issue.Org, issue.Repo, id); err != nil {
+				logging.Warnf("failed to release claim on #%d: %v", issue.Number, err)
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// expiredClaims returns the IDs of claim markers older than window
+func expiredClaims(comments []github.Comment, window time.Duration, now time.Time) []int {
+	var ids []int
+	for _, c := range comments {
+		if now.Sub(c.CreatedAt) > window && claimRegex.MatchString(c.Body) {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids
+}
+
+// claimOwner returns the run ID of the earliest claim made within window,
+// or "" when the issue is unclaimed
+func claimOwner(comments []github.Comment, window time.Duration, now time.Time) string {
+	for _, c := range comments {
+		if now.Sub(c.CreatedAt) > window {
+			continue
+		}
+		if m := claimRegex.FindStringSubmatch(c.Body); len(m) == 2 {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// claimCommentID returns the ID of runID's most recent claim marker
+func claimCommentID(comments []github.Comment, runID string) int {
+	marker := FormatClaimMarker(runID)
+	for i := len(comments) - 1; i >= 0; i-- {
+		if comments[i].Body == marker {
+			return comments[i].ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package pending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+)
+
+func TestClaimOwner(t *testing.T) {
+	now := time.Now()
+	comments := []github.Comment{
+		{ID: 1, Body: FormatClaimMarker("old"), CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, Body: "a regular comment", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: 3, Body: FormatClaimMarker("first"), CreatedAt: now.Add(-time.Minute)},
+		{ID: 4, Body: FormatClaimMarker("second"), CreatedAt: now},
+	}
+
+	if got := claimOwner(comments, 10*time.Minute, now); got != "first" {
+		t.Errorf("claimOwner = %q, want %q", got, "first")
+	}
+	if got := claimOwner(comments, 2*time.Hour, now); got != "old" {
+		t.Errorf("claimOwner with wide window = %q, want %q", got, "old")
+	}
+	if got := claimOwner(comments[1:2], 10*time.Minute, now); got != "" {
+		t.Errorf("claimOwner without markers = %q, want empty", got)
+	}
+	if got := claimCommentID(comments, "second"); got != 4 {
+		t.Errorf("claimCommentID = %d, want 4", got)
+	}
+}
+
+func TestExpiredClaims(t *testing.T) {
+	now := time.Now()
+	comments := []github.Comment{
+		{ID: 1, Body: FormatClaimMarker("old"), CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, Body: "a regular comment", CreatedAt: now.Add(-time.Hour)},
+		{ID: 3, Body: FormatClaimMarker("recent"), CreatedAt: now.Add(-time.Minute)},
+	}
+
+	got := expiredClaims(comments, 10*time.Minute, now)
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("expiredClaims = %v, want [1]", got)
+	}
+}
@@ -0,0 +1,86 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/style"
+)
+
+// metadataExtendedAt records when a maintainer extended the action's deadline
+const metadataExtendedAt = "extended_at"
+
+// Extended reports whether the action's deadline was already extended
+func (a *PendingAction) Extended() bool {
+	return a.Metadata[metadataExtendedAt] != ""
+}
+
+// ExtendReaction returns the configured extend reaction, or "" once the
+// action has already been extended
+func (a *PendingAction) ExtendReaction(configured string) string {
+	if a.Extended() {
+		return ""
+	}
+	return configured
+}
+
+// Extend pushes the action's deadline out by delayHours from now, persists
+// the new expiry in the comment metadata, and posts a short notice. An
+// action is only extended once; callers check Extended first.
+func (m *Manager) Extend(ctx context.Context, action *PendingAction, delayHours int) error {
+	comment, err := m.gh.GetComment(ctx, action.Org, action.Repo, action.CommentID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	updated := *action
+	updated.ExpiresAt = now.Add(time.Duration(delayHours) * time.Hour)
+	updated.Metadata = make(map[string]string, len(action.Metadata)+1)
+	for k, v := range action.Metadata {
+		updated.Metadata[k] = v
+	}
+	updated.Metadata[metadataExtendedAt] = now.UTC().Format(time.RFC3339)
+
+	body, err := replaceActionMetadata(comment.Body, &updated)
+	if err != nil {
+		return err
+	}
+	if err := m.gh.UpdateComment(ctx, action.Org, action.Repo, action.CommentID, body); err != nil {
+		return err
+	}
+	*action = updated
+
+	notice := FormatExtendedComment(action, style.ForRepo(m.cfg, action.Org, action.Repo))
+	return m.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, notice)
+}
+
+// replaceActionMetadata rewrites the metadata block for action's type and
+// issue in a comment body, leaving any other actions in it untouched
+func replaceActionMetadata(body string, action *PendingAction) (string, error) {
+	for _, loc := range metadataRegex.FindAllStringSubmatchIndex(body, -1) {
+		var existing PendingAction
+		if err := json.Unmarshal([]byte(body[loc[2]:loc[3]]), &existing); err != nil {
+			continue
+		}
+		if existing.Type != action.Type || existing.IssueNumber != action.IssueNumber {
+			continue
+		}
+
+		metadata, err := FormatPendingActionMetadata(action)
+		if err != nil {
+			return "", err
+		}
+		return body[:loc[0]] + metadata + body[loc[1]:], nil
+	}
+	return "", fmt.Errorf("pending %s metadata not found in comment %d", action.Type, action.CommentID)
+}
+
+// FormatExtendedComment notifies that a maintainer extended the deadline
+func FormatExtendedComment(action *PendingAction, st style.Style) string {
+	return st.Icon("⏳") + st.Textf(locale.DeadlineExtended,
+		action.subject(st), action.ExpiresAt.UTC().Format("2006-01-02 15:04 UTC"))
+}
@@ -0,0 +1,87 @@
+package pending
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/style"
+)
+
+func TestReplaceActionMetadata(t *testing.T) {
+	transfer := &PendingAction{Type: ActionTypeTransfer, IssueNumber: 7, Target: "org/other"}
+	draft := &PendingAction{Type: ActionTypeComment, IssueNumber: 7}
+
+	transferMeta, _ := FormatPendingActionMetadata(transfer)
+	draftMeta, _ := FormatPendingActionMetadata(draft)
+	body := "Summary\n" + transferMeta + "\n" + draftMeta
+
+	extended := *transfer
+	extended.ExpiresAt = time.Date(2030, 1, 2, 3, 4, 0, 0, time.UTC)
+	extended.Metadata = map[string]string{metadataExtendedAt: "2030-01-01T03:04:00Z"}
+
+	got, err := replaceActionMetadata(body, &extended)
+	if err != nil {
+		t.Fatalf("replaceActionMetadata() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "Summary\n") || !strings.HasSuffix(got, draftMeta) {
+		t.Errorf("surrounding content changed:\n%s", got)
+	}
+
+	parsed, err := ParsePendingActionMetadata(got)
+	if err != nil {
+		t.Fatalf("ParsePendingActionMetadata() error = %v", err)
+	}
+	if !parsed.ExpiresAt.Equal(extended.ExpiresAt) || !parsed.Extended() {
+		t.Errorf("parsed = %+v, want extended expiry %v", parsed, extended.ExpiresAt)
+	}
+
+	if _, err := replaceActionMetadata("no metadata", &extended); err == nil {
+		t.Error("expected an error when the metadata is missing")
+	}
+}
+
+func TestPendingAction_ExtendReaction(t *testing.T) {
+	action := &PendingAction{Type: ActionTypeClose}
+	if got := action.ExtendReaction("eyes"); got != "eyes" {
+		t.Errorf("ExtendReaction() = %q, want %q before extending", got, "eyes")
+	}
+
+	action.Metadata = map[string]string{metadataExtendedAt: time.Now().UTC().Format(time.RFC3339)}
+	if got := action.ExtendReaction("eyes"); got != "" {
+		t.Errorf("ExtendReaction() = %q, want none once extended", got)
+	}
+}
+
+func TestFormatExtendedComment(t *testing.T) {
+	expires := time.Date(2030, 1, 2, 3, 4, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		action *PendingAction
+		want   string
+	}{
+		{"duplicate", &PendingAction{Type: ActionTypeClose, Target: "https://github.com/org/app/issues/1"}, "Closing this issue as a duplicate"},
+		{"spam", &PendingAction{Type: ActionTypeClose}, "Closing this issue as not planned"},
+		{"transfer", &PendingAction{Type: ActionTypeTransfer, Target: "org/docs"}, "The transfer to **org/docs**"},
+		{"draft", &PendingAction{Type: ActionTypeComment}, "The drafted comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.action.ExpiresAt = expires
+			got := FormatExtendedComment(tt.action, style.Style{})
+			if !strings.Contains(got, tt.want) || !strings.Contains(got, "2030-01-02 03:04 UTC") {
+				t.Errorf("FormatExtendedComment() = %q, want it to mention %q and the new deadline", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatExtendedComment_Localized(t *testing.T) {
+	action := &PendingAction{Type: ActionTypeTransfer, Target: "org/docs", ExpiresAt: time.Date(2030, 1, 2, 3, 4, 0, 0, time.UTC)}
+	got := FormatExtendedComment(action, style.New(style.Plain).Localized("es"))
+	want := "Un responsable amplió el plazo. La transferencia a **org/docs** tendrá lugar después de 2030-01-02 03:04 UTC."
+	if got != want {
+		t.Errorf("FormatExtendedComment() = %q, want %q", got, want)
+	}
+}
@@ -15,7 +15,12 @@ import (
 const (
 	LabelPendingTransfer = "pending-transfer"
 	LabelPendingClose    = "pending-close"
-	metadataPattern      = `<!-- simili-pending-action: ({.*}) -->`
+	LabelPendingComment  = "pending-comment"
+	LabelIgnored         = "simili-ignored" // Set by /simili ignore; the bot leaves the issue alone
+	metadataPattern      = `<!-- simili-pending-action: ({.*?}) -->`
+
+	// DeadlineLayout formats an action's deadline in bot comments
+	DeadlineLayout = "2006-01-02 15:04 MST"
 )
 
 var metadataRegex = regexp.MustCompile(`(?s)` + metadataPattern)
@@ -26,6 +31,7 @@ type ActionType string
 const (
 	ActionTypeTransfer ActionType = "transfer"
 	ActionTypeClose    ActionType = "close"
+	ActionTypeComment  ActionType = "comment" // Drafted comment awaiting maintainer approval
 )
 
 // PendingAction represents a scheduled action
@@ -97,6 +103,21 @@ func (m *Manager) FindPendingActions(ctx context.Context, org, repo string) ([]*
 		}
 	}
 
+	// Find issues with drafted comments awaiting approval
+	if m.cfg.Defaults.CommentApprovalRequired {
+		draftIssues, err := m.gh.ListIssuesByLabel(ctx, org, repo, LabelPendingComment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pending comment issues: %w", err)
+		}
+
+		for _, issue := range draftIssues {
+			action, err := m.extractPendingAction(ctx, issue, ActionTypeComment)
+			if err == nil && action != nil {
+				actions = append(actions, action)
+			}
+		}
+	}
+
 	return actions, nil
 }
 
@@ -107,22 +128,20 @@ func (m *Manager) extractPendingAction(ctx context.Context, issue *models.Issue,
 		return nil, err
 	}
 
+	// A comment can carry several actions (e.g. a draft wrapping a transfer)
 	for _, comment := range comments {
-		matches := metadataRegex.FindStringSubmatch(comment.Body)
-		if len(matches) < 2 {
-			continue
-		}
-
-		var action PendingAction
-		if err := json.Unmarshal([]byte(matches[1]), &action); err != nil {
-			continue
-		}
-
-		if action.Type == actionType && action.IssueNumber == issue.Number {
-			action.Org = issue.Org
-			action.Repo = issue.Repo
-			action.CommentID = comment.ID
-			return &action, nil
+		for _, matches := range metadataRegex.FindAllStringSubmatch(comment.Body, -1) {
+			var action PendingAction
+			if err := json.Unmarshal([]byte(matches[1]), &action); err != nil {
+				continue
+			}
+
+			if action.Type == actionType && action.IssueNumber == issue.Number {
+				action.Org = issue.Org
+				action.Repo = issue.Repo
+				action.CommentID = comment.ID
+				return &action, nil
+			}
 		}
 	}
 
@@ -134,6 +153,7 @@ func (m *Manager) GetPendingAction(ctx context.Context, issue *models.Issue) (*P
 	// Check if issue has pending labels
 	hasTransfer := false
 	hasClose := false
+	hasComment := false
 
 	for _, label := range issue.Labels {
 		if label == LabelPendingTransfer {
@@ -142,12 +162,23 @@ func (m *Manager) GetPendingAction(ctx context.Context, issue *models.Issue) (*P
 		if label == LabelPendingClose {
 			hasClose = true
 		}
+		if label == LabelPendingComment {
+			hasComment = true
+		}
 	}
 
-	if !hasTransfer && !hasClose {
+	if !hasTransfer && !hasClose && !hasComment {
 		return nil, nil // No pending action
 	}
 
+	// An unapproved draft gates everything else on the issue
+	if hasComment {
+		action, err := m.extractPendingAction(ctx, issue, ActionTypeComment)
+		if err == nil && action != nil {
+			return action, nil
+		}
+	}
+
 	if hasTransfer {
 		action, err := m.extractPendingAction(ctx, issue, ActionTypeTransfer)
 		if err == nil && action != nil {
@@ -189,6 +220,12 @@ func ParsePendingActionMetadata(commentBody string) (*PendingAction, error) {
 	return &action, nil
 }
 
+// ClosesAsDuplicate reports whether a close action links an original issue;
+// closes without a target (such as spam) close the issue as not planned
+func (a *PendingAction) ClosesAsDuplicate() bool {
+	return a.Type == ActionTypeClose && a.Target != ""
+}
+
 // IsExpired checks if action has expired
 func (a *PendingAction) IsExpired() bool {
 	return time.Now().After(a.ExpiresAt)
@@ -202,6 +239,8 @@ func (m *Manager) Cancel(ctx context.Context, action *PendingAction) error {
 		label = LabelPendingTransfer
 	case ActionTypeClose:
 		label = LabelPendingClose
+	case ActionTypeComment:
+		label = LabelPendingComment
 	default:
 		return fmt.Errorf("unknown action type: %s", action.Type)
 	}
@@ -7,8 +7,11 @@ package pipeline
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/deadletter"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
@@ -55,18 +58,39 @@ func NewBuilder(
 	}
 }
 
+// newActionExecutor creates the action executor, recording failed writes in
+// the configured dead-letter file
+func (b *Builder) newActionExecutor() *steps.ActionExecutor {
+	executor := steps.NewActionExecutor(b.gh, b.transferClient, b.vdb, b.dryRun, b.execute)
+	if path := b.cfg.Defaults.DeadLetterFile; path != "" {
+		executor.SetDeadLetter(deadletter.NewQueue(path))
+	}
+	return executor
+}
+
+// stepRegistry lists every step that pipeline.steps may name, in default
+// pipeline order, with how the builder creates it
+var stepRegistry = []struct {
+	name   string
+	create func(b *Builder) core.Step
+}{
+	{"gatekeeper", func(b *Builder) core.Step { return steps.NewRepoGatekeeper(b.gh) }},
+	{"vectordb_prep", func(b *Builder) core.Step { return steps.NewVectorDBPrep(b.vdb, b.dryRun) }},
+	{"similarity_search", func(b *Builder) core.Step { return steps.NewSimilaritySearch(b.similarity) }},
+	{"transfer_check", func(b *Builder) core.Step { return steps.NewTransferCheck(b.similarity) }},
+	{"triage", func(b *Builder) core.Step { return steps.NewTriageAnalysis(b.triageAgent) }},
+	{"response_builder", func(b *Builder) core.Step { return steps.NewResponseBuilder() }},
+	{"action_executor", func(b *Builder) core.Step { return b.newActionExecutor() }},
+	{"indexer", func(b *Builder) core.Step { return steps.NewIndexer(b.indexer, b.dryRun) }},
+}
+
 // BuildDefault creates the standard pipeline
 func (b *Builder) BuildDefault() []core.Step {
-	return []core.Step{
-		steps.NewRepoGatekeeper(b.gh),
-		steps.NewVectorDBPrep(b.vdb, b.dryRun),
-		steps.NewSimilaritySearch(b.similarity),
-		steps.NewTransferCheck(),
-		steps.NewTriageAnalysis(b.triageAgent),
-		steps.NewResponseBuilder(),
-		steps.NewActionExecutor(b.gh, b.transferClient, b.vdb, b.dryRun, b.execute),
-		steps.NewIndexer(b.indexer, b.dryRun),
+	pipe := make([]core.Step, len(stepRegistry))
+	for i, entry := range stepRegistry {
+		pipe[i] = entry.create(b)
 	}
+	return pipe
 }
 
 // BuildFromConfig creates a pipeline based on the order defined in config.
@@ -87,25 +111,46 @@ func (b *Builder) BuildFromConfig() ([]core.Step, error) {
 	return pipe, nil
 }
 
+// StepNames lists the step names accepted in pipeline.steps, in default order
+var StepNames = func() []string {
+	names := make([]string, len(stepRegistry))
+	for i, entry := range stepRegistry {
+		names[i] = entry.name
+	}
+	return names
+}()
+
+// FilterSteps keeps the steps named in only (all when empty) and drops those
+// named in skip. Every name must be a known step present in pipe, so a typo
+// fails loudly instead of silently running the whole pipeline.
+func FilterSteps(pipe []core.Step, only, skip []string) ([]core.Step, error) {
+	for _, name := range append(append([]string{}, only...), skip...) {
+		if !slices.Contains(StepNames, name) {
+			return nil, fmt.Errorf("unknown step: %s (known: %s)", name, strings.Join(StepNames, ", "))
+		}
+		if !slices.ContainsFunc(pipe, func(s core.Step) bool { return s.Name() == name }) {
+			return nil, fmt.Errorf("step %s is not in the configured pipeline", name)
+		}
+	}
+
+	var filtered []core.Step
+	for _, step := range pipe {
+		if len(only) > 0 && !slices.Contains(only, step.Name()) {
+			continue
+		}
+		if slices.Contains(skip, step.Name()) {
+			continue
+		}
+		filtered = append(filtered, step)
+	}
+	return filtered, nil
+}
+
 func (b *Builder) createStep(name string) (core.Step, error) {
-	switch name {
-	case "gatekeeper":
-		return steps.NewRepoGatekeeper(b.gh), nil
-	case "vectordb_prep":
-		return steps.NewVectorDBPrep(b.vdb, b.dryRun), nil
-	case "similarity_search":
-		return steps.NewSimilaritySearch(b.similarity), nil
-	case "transfer_check":
-		return steps.NewTransferCheck(), nil
-	case "triage":
-		return steps.NewTriageAnalysis(b.triageAgent), nil
-	case "response_builder":
-		return steps.NewResponseBuilder(), nil
-	case "action_executor":
-		return steps.NewActionExecutor(b.gh, b.transferClient, b.vdb, b.dryRun, b.execute), nil
-	case "indexer":
-		return steps.NewIndexer(b.indexer, b.dryRun), nil
-	default:
-		return nil, fmt.Errorf("unknown step: %s", name)
+	for _, entry := range stepRegistry {
+		if entry.name == name {
+			return entry.create(b), nil
+		}
 	}
+	return nil, fmt.Errorf("unknown step: %s", name)
 }
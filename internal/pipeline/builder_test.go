@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+)
+
+type namedStep string
+
+func (s namedStep) Name() string                { return string(s) }
+func (s namedStep) Run(ctx *core.Context) error { return nil }
+
+func stepNames(pipe []core.Step) []string {
+	names := make([]string, len(pipe))
+	for i, s := range pipe {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+func TestFilterSteps(t *testing.T) {
+	pipe := []core.Step{namedStep("gatekeeper"), namedStep("similarity_search"), namedStep("response_builder"), namedStep("action_executor")}
+
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "only keeps pipeline order", only: []string{"response_builder", "similarity_search"}, want: []string{"similarity_search", "response_builder"}},
+		{name: "skip drops steps", skip: []string{"action_executor"}, want: []string{"gatekeeper", "similarity_search", "response_builder"}},
+		{name: "only and skip combine", only: []string{"gatekeeper", "action_executor"}, skip: []string{"action_executor"}, want: []string{"gatekeeper"}},
+		{name: "unknown step", only: []string{"similarity"}, wantErr: true},
+		{name: "known step not configured", skip: []string{"indexer"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterSteps(pipe, tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FilterSteps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if names := stepNames(got); !slices.Equal(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_StepRegistry(t *testing.T) {
+	b := NewBuilder(&config.Config{}, nil, nil, nil, nil, nil, nil, true, false)
+
+	if got := stepNames(b.BuildDefault()); !slices.Equal(got, StepNames) {
+		t.Errorf("BuildDefault() = %v, want StepNames %v", got, StepNames)
+	}
+
+	for _, name := range StepNames {
+		step, err := b.createStep(name)
+		if err != nil {
+			t.Errorf("createStep(%q) error = %v", name, err)
+			continue
+		}
+		if step.Name() != name {
+			t.Errorf("createStep(%q) built step %q", name, step.Name())
+		}
+	}
+
+	b.cfg.Pipeline.Steps = []string{"similarity_search", "triag"}
+	if _, err := b.BuildFromConfig(); err == nil {
+		t.Error("BuildFromConfig() with an unknown step succeeded, want error")
+	}
+}
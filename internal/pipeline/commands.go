@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/transfer"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// commandPrefix starts a maintainer command in an issue comment
+const commandPrefix = "/simili"
+
+// Comment commands
+const (
+	CommandRecheck  = "recheck"
+	CommandIgnore   = "ignore"
+	CommandTransfer = "transfer"
+	CommandLock     = "lock"
+)
+
+// Command is a slash-command parsed from an issue comment
+type Command struct {
+	Name string
+	Args []string
+}
+
+// ParseCommand returns the first "/simili <command> [args]" line in a
+// comment body, or nil when there is none
+func ParseCommand(body string) *Command {
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != commandPrefix {
+			continue
+		}
+		return &Command{Name: strings.ToLower(fields[1]), Args: fields[2:]}
+	}
+	return nil
+}
+
+// ProcessCommand runs a comment command from author, who must have write
+// access to the repository
+func (up *UnifiedProcessor) ProcessCommand(ctx context.Context, issue *models.Issue, cmd *Command, author string) (*core.UnifiedResult, error) {
+	result := &core.UnifiedResult{IssueNumber: issue.Number}
+
+	repoConfig := up.cfg.GetRepoConfig(issue.Org, issue.Repo)
+	if repoConfig == nil || !repoConfig.Enabled {
+		result.Skipped = true
+		result.SkipReason = "repository not enabled"
+		return result, nil
+	}
+
+	allowed, err := up.gh.HasWriteAccess(ctx, issue.Org, issue.Repo, author)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check command permission: %w", err)
+	}
+	if !allowed {
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("%s lacks write access for /simili %s", author, cmd.Name)
+		return result, nil
+	}
+
+	logging.Info("running comment command", "repo", issue.FullRepo(), "issue", issue.Number, "command", cmd.Name, "author", author)
+
+	switch cmd.Name {
+	case CommandRecheck:
+		return up.refreshSummary(ctx, issue, result, true)
+
+	case CommandIgnore:
+		return up.ignore(ctx, issue, result)
+
+	case CommandTransfer:
+		if len(cmd.Args) != 1 {
+			result.Skipped = true
+			result.SkipReason = "usage: /simili transfer owner/repo"
+			return result, nil
+		}
+		target := cmd.Args[0]
+		if _, _, err := github.ParseRepo(target); err != nil {
+			result.Skipped = true
+			result.SkipReason = fmt.Sprintf("invalid transfer target: %v", err)
+			return result, nil
+		}
+		result.TransferTarget = target
+		if up.dryRun || !up.execute {
+			logging.Info("[DRY RUN] would transfer", "repo", issue.FullRepo(), "issue", issue.Number, "action", "transfer", "target", target)
+			return result, nil
+		}
+		executor := transfer.NewExecutor(up.transferClient, up.gh, up.vdb, up.cfg, up.dryRun)
+		if err := executor.Transfer(ctx, issue, target, nil); err != nil {
+			return nil, fmt.Errorf("failed to transfer: %w", err)
+		}
+		delayed := up.cfg.Defaults.DelayedActions
+		result.TransferScheduled = delayed.Enabled && !delayed.OptimisticTransfers
+		result.Transferred = !result.TransferScheduled
+		result.ActionsExecuted = 1
+		return result, nil
+
+	case CommandLock:
+		reason := strings.Join(cmd.Args, " ")
+		if !github.ValidLockReason(reason) {
+			result.Skipped = true
+			result.SkipReason = fmt.Sprintf("invalid lock reason %q", reason)
+			return result, nil
+		}
+		if up.dryRun || !up.execute {
+			logging.Info("[DRY RUN] would lock", "repo", issue.FullRepo(), "issue", issue.Number, "action", "lock")
+			return result, nil
+		}
+		if err := up.gh.LockIssue(ctx, issue.Org, issue.Repo, issue.Number, reason); err != nil {
+			return nil, err
+		}
+		result.ActionsExecuted = 1
+		return result, nil
+
+	default:
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("unknown command /simili %s", cmd.Name)
+		return result, nil
+	}
+}
+
+// ignore cancels any pending action and labels the issue so the bot leaves it alone
+func (up *UnifiedProcessor) ignore(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) (*core.UnifiedResult, error) {
+	if up.dryRun || !up.execute {
+		logging.Info("[DRY RUN] would ignore", "repo", issue.FullRepo(), "issue", issue.Number, "action", "ignore")
+		return result, nil
+	}
+
+	pendingMgr := pending.NewManager(up.gh, up.cfg)
+	action, err := pendingMgr.GetPendingAction(ctx, issue)
+	if err != nil {
+		result.Warnf("failed to check pending action: %v", err)
+	} else if action != nil {
+		if err := pendingMgr.Cancel(ctx, action); err != nil {
+			return nil, fmt.Errorf("failed to cancel pending %s: %w", action.Type, err)
+		}
+		result.ActionsExecuted++
+	}
+
+	if err := up.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{pending.LabelIgnored}); err != nil {
+		return nil, fmt.Errorf("failed to add %s label: %w", pending.LabelIgnored, err)
+	}
+	result.ActionsExecuted++
+
+	return result, nil
+}
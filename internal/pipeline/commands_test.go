@@ -0,0 +1,176 @@
+package pipeline
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *Command
+	}{
+		{"no command", "Thanks, this looks like a duplicate", nil},
+		{"prefix alone", "/simili", nil},
+		{"other bot", "/other recheck", nil},
+		{"mid-sentence", "please run /simili recheck", nil},
+		{"recheck", "/simili recheck", &Command{Name: CommandRecheck, Args: []string{}}},
+		{"case and spacing", "  /simili   Transfer  octo/docs ", &Command{Name: CommandTransfer, Args: []string{"octo/docs"}}},
+		{"first of several", "Moving this.\n/simili lock off-topic\n/simili ignore", &Command{Name: CommandLock, Args: []string{"off-topic"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCommand(tt.body)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseCommand() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.want.Name || !slices.Equal(got.Args, tt.want.Args) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// githubAPI answers collaborator lookups with permission, comment listings
+// with comments (an empty list by default) and every other request with an
+// empty JSON object, recording every request but the collaborator lookups
+type githubAPI struct {
+	permission string
+	comments   string
+
+	mu    sync.Mutex
+	other []string
+}
+
+func (pt *githubAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := "{}"
+	if strings.Contains(req.URL.Path, "/collaborators/") {
+		body = `{"permission": "` + pt.permission + `"}`
+	} else {
+		if req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/comments") {
+			body = "[]"
+			if pt.comments != "" {
+				body = pt.comments
+			}
+		}
+		pt.mu.Lock()
+		pt.other = append(pt.other, req.Method+" "+req.URL.Path)
+		pt.mu.Unlock()
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestProcessCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		cmd        *Command
+		wantSkip   string
+		wantTarget string
+	}{
+		{"read access denied", "read", &Command{Name: CommandIgnore}, "alice lacks write access for /simili ignore", ""},
+		{"triage access denied", "triage", &Command{Name: CommandTransfer, Args: []string{"octo/docs"}}, "alice lacks write access for /simili transfer", ""},
+		{"unknown command", "write", &Command{Name: "close"}, "unknown command /simili close", ""},
+		{"transfer without target", "admin", &Command{Name: CommandTransfer}, "usage: /simili transfer owner/repo", ""},
+		{"transfer to bad target", "write", &Command{Name: CommandTransfer, Args: []string{"docs"}}, "invalid transfer target: ", ""},
+		{"invalid lock reason", "write", &Command{Name: CommandLock, Args: []string{"boring"}}, `invalid lock reason "boring"`, ""},
+		{"transfer in dry run", "write", &Command{Name: CommandTransfer, Args: []string{"octo/docs"}}, "", "octo/docs"},
+		{"ignore in dry run", "admin", &Command{Name: CommandIgnore}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &githubAPI{permission: tt.permission}
+			gh, err := github.NewClientWithTransport("test", api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			up := &UnifiedProcessor{cfg: enabledConfig(), gh: gh, dryRun: true}
+			issue := &models.Issue{Org: "octo", Repo: "app", Number: 4}
+
+			result, err := up.ProcessCommand(context.Background(), issue, tt.cmd, "alice")
+			if err != nil {
+				t.Fatalf("ProcessCommand() error = %v", err)
+			}
+			if result.Skipped != (tt.wantSkip != "") || !strings.HasPrefix(result.SkipReason, tt.wantSkip) {
+				t.Errorf("skipped = %v, reason = %q, want %q", result.Skipped, result.SkipReason, tt.wantSkip)
+			}
+			if result.TransferTarget != tt.wantTarget {
+				t.Errorf("TransferTarget = %q, want %q", result.TransferTarget, tt.wantTarget)
+			}
+			if result.ActionsExecuted != 0 || len(api.other) != 0 {
+				t.Errorf("ran %d actions and sent %v, want nothing beyond the permission check", result.ActionsExecuted, api.other)
+			}
+		})
+	}
+}
+
+// enabledConfig enables octo/app, the repository of the test issues
+func enabledConfig() *config.Config {
+	return &config.Config{Repositories: []config.RepositoryConfig{{Org: "octo", Repo: "app", Enabled: true}}}
+}
+
+func TestProcessCommand_RepoDisabled(t *testing.T) {
+	api := &githubAPI{permission: "admin"}
+	gh, err := github.NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := enabledConfig()
+	cfg.Repositories[0].Enabled = false
+	up := &UnifiedProcessor{cfg: cfg, gh: gh, execute: true}
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 4}
+
+	result, err := up.ProcessCommand(context.Background(), issue, &Command{Name: CommandIgnore}, "alice")
+	if err != nil {
+		t.Fatalf("ProcessCommand() error = %v", err)
+	}
+	if !result.Skipped || result.SkipReason != "repository not enabled" {
+		t.Errorf("skipped = %v, reason = %q, want repository not enabled", result.Skipped, result.SkipReason)
+	}
+	if len(api.other) != 0 {
+		t.Errorf("sent %v, want nothing for a disabled repository", api.other)
+	}
+}
+
+func TestProcessCommand_TransferScheduled(t *testing.T) {
+	api := &githubAPI{permission: "write", comments: `[{"id": 9, "body": "<!-- simili-pending-action -->"}]`}
+	gh, err := github.NewClientWithTransport("test", api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := enabledConfig()
+	cfg.Defaults.DelayedActions = config.DelayedActionsConfig{Enabled: true, DelayHours: 24}
+	up := &UnifiedProcessor{cfg: cfg, gh: gh, transferClient: gh, execute: true}
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 4}
+
+	result, err := up.ProcessCommand(context.Background(), issue, &Command{Name: CommandTransfer, Args: []string{"octo/docs"}}, "alice")
+	if err != nil {
+		t.Fatalf("ProcessCommand() error = %v", err)
+	}
+	if result.Transferred || !result.TransferScheduled {
+		t.Errorf("Transferred = %v, TransferScheduled = %v, want only scheduled", result.Transferred, result.TransferScheduled)
+	}
+	if slices.Contains(api.other, "POST /repos/octo/app/transfer") {
+		t.Errorf("sent %v, want no transfer while it is pending", api.other)
+	}
+}
@@ -8,8 +8,11 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
@@ -22,17 +25,29 @@ var ErrSkipPipeline = errors.New("skip pipeline")
 
 // UnifiedResult contains the complete result of unified processing
 type UnifiedResult struct {
-	IssueNumber     int                     `json:"issue_number"`
-	Skipped         bool                    `json:"skipped,omitempty"`
-	SkipReason      string                  `json:"skip_reason,omitempty"`
-	SimilarFound    []vectordb.SearchResult `json:"similar_found,omitempty"`
-	TriageResult    *triage.Result          `json:"triage_result,omitempty"`
-	Transferred     bool                    `json:"transferred,omitempty"`
-	TransferTarget  string                  `json:"transfer_target,omitempty"`
-	CommentPosted   bool                    `json:"comment_posted,omitempty"`
-	Indexed         bool                    `json:"indexed,omitempty"`
-	ActionsExecuted int                     `json:"actions_executed,omitempty"`
-	PendingAction   *pending.PendingAction  `json:"pending_action,omitempty"`
+	IssueNumber       int                     `json:"issue_number"`
+	Skipped           bool                    `json:"skipped,omitempty"`
+	SkipReason        string                  `json:"skip_reason,omitempty"`
+	SimilarFound      []vectordb.SearchResult `json:"similar_found,omitempty"`
+	TriageResult      *triage.Result          `json:"triage_result,omitempty"`
+	Transferred       bool                    `json:"transferred,omitempty"`
+	TransferScheduled bool                    `json:"transfer_scheduled,omitempty"` // Scheduled as a delayed action, not moved yet
+	TransferTarget    string                  `json:"transfer_target,omitempty"`
+	AreaTeam          string                  `json:"area_team,omitempty"`
+	CommentPosted     bool                    `json:"comment_posted,omitempty"`
+	Indexed           bool                    `json:"indexed,omitempty"`
+	ActionsExecuted   int                     `json:"actions_executed,omitempty"`
+	PendingAction     *pending.PendingAction  `json:"pending_action,omitempty"`
+	Timings           map[string]int          `json:"timings,omitempty"` // Per-stage wall time in ms (with --profile)
+	Errors            []string                `json:"errors,omitempty"`  // Failures that were logged and skipped past
+}
+
+// Warnf logs a recoverable failure and records it in Errors, so a run that
+// carried on past it can still be reported as partially processed
+func (r *UnifiedResult) Warnf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	r.Errors = append(r.Errors, msg)
+	logging.Warnf("%s", msg)
 }
 
 // Context carries state through the pipeline steps.
@@ -53,6 +68,9 @@ type Context struct {
 	// TransferTarget holds the matched transfer target repo name (if any)
 	TransferTarget string
 
+	// AreaTeam holds the owning team resolved from area rules (if any)
+	AreaTeam string
+
 	// TriageResult holds the output of the LLM/Rule-based triage
 	TriageResult *triage.Result
 
@@ -61,6 +79,21 @@ type Context struct {
 
 	// SkipReason is set when ErrSkipPipeline is returned to explain why
 	SkipReason string
+
+	// TriageFailed is set when triage analysis or its actions failed
+	TriageFailed bool
+
+	// SuppressComments is set when the issue opted out of bot comments
+	// or the comment cooldown holds back only the comment
+	SuppressComments bool
+
+	// RecentActions records when the bot last labeled or suggested a
+	// transfer for this issue (loaded when action cooldowns are configured)
+	RecentActions github.ActionLog
+
+	// LastBotCommentID is the bot's most recent comment on the issue, where
+	// the action log is kept when no new comment is posted
+	LastBotCommentID int
 }
 
 // Step defines a single unit of work in the pipeline.
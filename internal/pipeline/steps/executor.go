@@ -6,10 +6,17 @@
 package steps
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/deadletter"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/style"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
@@ -21,6 +28,7 @@ type ActionExecutor struct {
 	vdb            *vectordb.Client
 	dryRun         bool
 	runActions     bool // "execute" flag in old unified.go
+	deadLetter     *deadletter.Queue
 }
 
 func NewActionExecutor(gh *github.Client, transferClient *github.Client, vdb *vectordb.Client, dryRun bool, runActions bool) *ActionExecutor {
@@ -33,72 +41,280 @@ func NewActionExecutor(gh *github.Client, transferClient *github.Client, vdb *ve
 	}
 }
 
+// SetDeadLetter sets the queue failed comments and labels are recorded in
+func (s *ActionExecutor) SetDeadLetter(q *deadletter.Queue) {
+	s.deadLetter = q
+}
+
 func (s *ActionExecutor) Name() string {
 	return "action_executor"
 }
 
 func (s *ActionExecutor) Run(ctx *core.Context) error {
 	if s.dryRun || !s.runActions {
-		log.Println("Dry run or execute=false, skipping side effects")
+		logging.Info("dry run or execute=false, skipping side effects", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "step", s.Name())
+		return nil
+	}
+
+	policy := config.NewDryRunPolicy(s.dryRun, ctx.Config.Defaults.DryRun)
+
+	if ctx.SuppressComments && ctx.CommentBody != "" {
+		logging.Info("comments suppressed, not posting summary", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number)
+		ctx.CommentBody = ""
+	}
+
+	// Labels applied within the label cooldown are held back
+	if hours := ctx.Config.Defaults.ActionCooldowns.LabelHours; ctx.TriageResult != nil && ctx.RecentActions.Within(github.ActionLabels, hours) {
+		logging.Info("label cooldown active, not changing labels", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number)
+		filtered := *ctx.TriageResult
+		filtered.Actions = filterLabelActions(filtered.Actions)
+		ctx.TriageResult = &filtered
+	}
+	// Let edits right after this run be debounced
+	if ctx.Config.Defaults.EditDebounceMinutes > 0 && ctx.CommentBody != "" {
+		ctx.CommentBody = github.WithProcessedMarker(ctx.CommentBody, github.NewProcessedMarker(ctx.Issue))
+	}
+
+	// Sensitive repos: draft for a maintainer instead of acting autonomously
+	if ctx.Config.Defaults.CommentApprovalRequired && ctx.CommentBody != "" && !policy.Comments() {
+		s.draftForApproval(ctx)
 		return nil
 	}
 
 	// 1. Post Comment
 	commentID := 0
-	if ctx.CommentBody != "" {
-		id, err := s.gh.PostCommentWithID(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, ctx.CommentBody)
+	if ctx.CommentBody != "" && policy.Comments() {
+		logging.Info("[DRY RUN] would post summary comment", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "action", "comment")
+	} else if ctx.CommentBody != "" {
+		previous := s.previousSummary(ctx)
+		posted, err := s.postSummary(ctx)
 		if err != nil {
-			log.Printf("Warning: failed to post unified comment: %v", err)
+			ctx.Result.Warnf("failed to post unified comment: %v", err)
+			s.recordFailure(ctx, deadletter.Entry{Action: deadletter.ActionComment, Body: ctx.CommentBody}, err)
 		} else {
 			ctx.Result.CommentPosted = true
-			commentID = id
+			commentID = posted.ID
+			if previous != nil {
+				if err := s.gh.MinimizeComment(ctx.Ctx, previous.NodeID, github.MinimizeOutdated); err != nil {
+					ctx.Result.Warnf("failed to minimize previous summary: %v", err)
+				}
+			}
 		}
 	}
 
 	// 2. Execute Transfer
-	if ctx.TransferTarget != "" {
-		s.executeTransfer(ctx, commentID)
+	transferred := false
+	if ctx.TransferTarget != "" && policy.Transfers() {
+		logging.Info("[DRY RUN] would transfer", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "action", "transfer", "target", ctx.TransferTarget)
+	} else if ctx.TransferTarget != "" && ctx.SuppressComments {
+		// Every transfer path but the silent one posts a comment of its own
+		logging.Info("comments suppressed, not transferring", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "target", ctx.TransferTarget)
+	} else if ctx.TransferTarget != "" {
+		transferred = s.executeTransfer(ctx, commentID)
 	}
 
 	// 3. Execute Triage Actions
+	labeled := false
 	if ctx.TriageResult != nil {
-		s.executeTriageRequest(ctx, commentID)
+		labeled = s.executeTriageRequest(ctx, commentID) && !policy.Labels()
+	}
+
+	// 4. Let watchers of the original know about the duplicate
+	if ctx.Config.Triage.Duplicate.LinkOriginal && ctx.TriageResult != nil && !policy.Comments() && !ctx.SuppressComments {
+		dupChecker := triage.NewDuplicateCheckerWithDelayedActions(&ctx.Config.Triage.Duplicate, s.gh, ctx.Config)
+		if err := dupChecker.LinkOriginal(ctx.Ctx, ctx.Issue, ctx.TriageResult.Duplicate); err != nil {
+			ctx.Result.Warnf("failed to link original issue: %v", err)
+		}
+	}
+
+	// 5. Record what actually happened on the new comment, or on the last
+	// bot comment when none was posted
+	if actionLog := s.actionLog(ctx, labeled, transferred); actionLog != nil {
+		target := commentID
+		if target == 0 {
+			target = ctx.LastBotCommentID
+		}
+		if target != 0 {
+			s.saveActionLog(ctx, target, actionLog)
+		}
 	}
 
 	return nil
 }
 
-func (s *ActionExecutor) executeTransfer(ctx *core.Context, commentID int) {
+// writeRetry returns the retry policy for GitHub writes
+func writeRetry(cfg *config.Config) github.RetryPolicy {
+	return github.RetryPolicy{
+		Attempts: cfg.Defaults.WriteRetry.Attempts,
+		Backoff:  time.Duration(cfg.Defaults.WriteRetry.BackoffSeconds) * time.Second,
+	}
+}
+
+// postSummary posts the summary comment, retrying transient failures. Before
+// each retry it looks for the comment first, since a request that timed out
+// may still have been applied, so a retry never posts it twice.
+func (s *ActionExecutor) postSummary(ctx *core.Context) (*github.Comment, error) {
+	issue := ctx.Issue
+	var posted *github.Comment
+	attempt := 0
+	err := writeRetry(ctx.Config).Do(ctx.Ctx, "posting summary comment", func() error {
+		attempt++
+		if attempt > 1 {
+			existing, err := s.gh.FindBotComment(ctx.Ctx, issue.Org, issue.Repo, issue.Number, SummaryHeading)
+			if err != nil {
+				return err
+			}
+			if existing != nil && existing.Body == ctx.CommentBody {
+				posted = existing
+				return nil
+			}
+		}
+		var err error
+		posted, err = s.gh.CreateComment(ctx.Ctx, issue.Org, issue.Repo, issue.Number, ctx.CommentBody)
+		return err
+	})
+	return posted, err
+}
+
+// previousSummary returns the summary a new one would supersede, or nil when
+// outdated comments are left alone or there is none
+func (s *ActionExecutor) previousSummary(ctx *core.Context) *github.Comment {
+	if !ctx.Config.Defaults.MinimizeOutdatedComments {
+		return nil
+	}
+	previous, err := s.gh.FindBotComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, SummaryHeading)
+	if err != nil {
+		ctx.Result.Warnf("failed to look up previous summary: %v", err)
+		return nil
+	}
+	return previous
+}
+
+// actionLog returns the bot's action log updated with the label changes and
+// transfer this run completed, or nil when action cooldowns are off or
+// nothing new happened
+func (s *ActionExecutor) actionLog(ctx *core.Context, labeled, transferred bool) github.ActionLog {
+	if !ctx.Config.Defaults.ActionCooldowns.Enabled() || (!labeled && !transferred) {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	log := github.ActionLog{}
+	for action, at := range ctx.RecentActions {
+		log[action] = at
+	}
+	if labeled {
+		log[github.ActionLabels] = now
+	}
+	if transferred {
+		log[github.ActionTransfer] = now
+	}
+	return log
+}
+
+// saveActionLog writes the action log into one of the bot's comments
+func (s *ActionExecutor) saveActionLog(ctx *core.Context, commentID int, log github.ActionLog) {
+	issue := ctx.Issue
+	comment, err := s.gh.GetComment(ctx.Ctx, issue.Org, issue.Repo, commentID)
+	if err != nil {
+		ctx.Result.Warnf("failed to load comment for action log: %v", err)
+		return
+	}
+	if err := s.gh.UpdateComment(ctx.Ctx, issue.Org, issue.Repo, comment.ID, github.WithActionLog(comment.Body, log)); err != nil {
+		ctx.Result.Warnf("failed to save action log: %v", err)
+	}
+}
+
+// draftForApproval posts the summary as a collapsed draft and defers its
+// labels (including any pending transfer/close label) until a maintainer approves
+func (s *ActionExecutor) draftForApproval(ctx *core.Context) {
+	var labels []string
+	if ctx.TriageResult != nil {
+		for _, a := range filterNonCommentActions(ctx.TriageResult.Actions) {
+			if a.Type == triage.ActionAddLabel {
+				labels = append(labels, a.Label)
+			}
+		}
+	}
+	if pa := ctx.Result.PendingAction; pa != nil {
+		switch pa.Type {
+		case pending.ActionTypeTransfer:
+			labels = append(labels, pending.LabelPendingTransfer)
+		case pending.ActionTypeClose:
+			labels = append(labels, pending.LabelPendingClose)
+		}
+	}
+
+	delayed := ctx.Config.Defaults.DelayedActions
+	approval := pending.NewCommentApproval(ctx.Issue, labels, delayed.DelayHours)
+	body, err := pending.FormatDraftComment(ctx.CommentBody, approval, delayed.ApproveReaction, style.ForRepo(ctx.Config, ctx.Issue.Org, ctx.Issue.Repo))
+	if err != nil {
+		ctx.Result.Warnf("failed to format draft comment: %v", err)
+		return
+	}
+
+	if err := s.gh.PostComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, body); err != nil {
+		ctx.Result.Warnf("failed to post draft comment: %v", err)
+		return
+	}
+	ctx.Result.CommentPosted = true
+
+	if err := pending.NewManager(s.gh, ctx.Config).ScheduleComment(ctx.Ctx, ctx.Issue); err != nil {
+		ctx.Result.Warnf("failed to mark draft pending: %v", err)
+	}
+}
+
+// executeTransfer transfers the issue or schedules the transfer, reporting
+// whether it succeeded
+func (s *ActionExecutor) executeTransfer(ctx *core.Context, commentID int) bool {
 	executor := transfer.NewExecutor(s.transferClient, s.gh, s.vdb, ctx.Config, s.dryRun)
 
 	// Optimistic?
 	if ctx.Config.Defaults.DelayedActions.Enabled && ctx.Config.Defaults.DelayedActions.OptimisticTransfers {
-		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil); err != nil { // nil rule? we lost the rule obj in Context, but maybe Transfer doesn't NEED it if target is set?
+		if err := s.transfer(ctx, executor); err != nil { // nil rule? we lost the rule obj in Context, but maybe Transfer doesn't NEED it if target is set?
 			// Checking transfer.go: Transfer(ctx, issue, target, rule). The rule is used for logging priority.
 			// Currently we didn't store the rule in Context, only the target.
 			// That's acceptable for now.
-			log.Printf("Warning: failed to execute optimistic transfer: %v", err)
-		} else {
-			ctx.Result.Transferred = true
-			ctx.Result.ActionsExecuted++
+			ctx.Result.Warnf("failed to execute optimistic transfer: %v", err)
+			return false
 		}
+		ctx.Result.Transferred = true
+		ctx.Result.ActionsExecuted++
 	} else if ctx.Result.CommentPosted {
 		// Delayed Silent
 		if err := executor.ScheduleTransferSilent(ctx.Ctx, ctx.Issue, ctx.TransferTarget, commentID); err != nil {
-			log.Printf("Warning: failed to schedule transfer: %v", err)
+			ctx.Result.Warnf("failed to schedule transfer: %v", err)
+			return false
 		}
+		ctx.Result.TransferScheduled = true
 	} else {
 		// Fallback
-		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil); err != nil {
-			log.Printf("Warning: failed to transfer: %v", err)
-		} else {
-			ctx.Result.Transferred = true
-			ctx.Result.ActionsExecuted++
+		if err := s.transfer(ctx, executor); err != nil {
+			ctx.Result.Warnf("failed to transfer: %v", err)
+			return false
 		}
+		ctx.Result.Transferred = true
+		ctx.Result.ActionsExecuted++
+	}
+	return true
+}
+
+// transfer moves the issue to the target. Immediate transfers are retried on
+// transient failures, since Transfer skips issues that were already moved;
+// scheduling one posts a warning comment, so it is tried only once.
+func (s *ActionExecutor) transfer(ctx *core.Context, executor *transfer.Executor) error {
+	run := func() error {
+		return executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil)
 	}
+	if delayed := ctx.Config.Defaults.DelayedActions; delayed.Enabled && !delayed.OptimisticTransfers {
+		return run()
+	}
+	return writeRetry(ctx.Config).Do(ctx.Ctx, "transferring issue", run)
 }
 
-func (s *ActionExecutor) executeTriageRequest(ctx *core.Context, commentID int) {
+// executeTriageRequest applies the triage actions, reporting whether any
+// label changes went through
+func (s *ActionExecutor) executeTriageRequest(ctx *core.Context, commentID int) bool {
 	// Filter comment actions since we already posted unified comment
 	actions := filterNonCommentActions(ctx.TriageResult.Actions)
 
@@ -111,23 +327,127 @@ func (s *ActionExecutor) executeTriageRequest(ctx *core.Context, commentID int)
 			ctx.TriageResult.Duplicate.ShouldClose && ctx.Result.CommentPosted {
 
 			if err := dupChecker.ScheduleCloseSilent(ctx.Ctx, ctx.Issue, ctx.TriageResult.Duplicate.Original.URL, commentID); err != nil {
-				log.Printf("Warning: failed to schedule close: %v", err)
+				ctx.Result.Warnf("failed to schedule close: %v", err)
 			}
 			actions = filterCloseActions(actions)
 		}
+		actions = withoutCloseWarning(ctx, actions)
 		executor = triage.NewExecutorWithDelayedActions(s.gh, ctx.Config, dupChecker, s.dryRun)
 	} else {
 		executor = triage.NewExecutor(s.gh, s.dryRun)
+		executor.SetDryRunPolicy(config.NewDryRunPolicy(s.dryRun, ctx.Config.Defaults.DryRun))
 	}
+	executor.SetRetryPolicy(writeRetry(ctx.Config))
+	labelFailed := false
+	executor.SetFailureHandler(func(action triage.Action, err error) {
+		ctx.TriageFailed = true
+		ctx.Result.Warnf("failed to apply %s: %v", action.Type, err)
+		switch action.Type {
+		case triage.ActionAddLabel:
+			labelFailed = true
+			s.recordFailure(ctx, deadletter.Entry{Action: deadletter.ActionAddLabel, Label: action.Label}, err)
+		case triage.ActionRemoveLabel:
+			labelFailed = true
+			s.recordFailure(ctx, deadletter.Entry{Action: deadletter.ActionRemoveLabel, Label: action.Label}, err)
+		}
+	})
 
 	filteredResult := *ctx.TriageResult // Copy
 	filteredResult.Actions = actions
 
 	if err := executor.Execute(ctx.Ctx, ctx.Issue, &filteredResult); err != nil {
-		log.Printf("Warning: failed to execute triage actions: %v", err)
-	} else {
-		ctx.Result.ActionsExecuted += len(actions)
+		ctx.Result.Warnf("failed to execute triage actions: %v", err)
+		ctx.TriageFailed = true
+		return false
 	}
+	ctx.Result.ActionsExecuted += len(actions)
+	return !labelFailed && len(filterLabelActions(actions)) < len(actions)
+}
+
+// withoutCloseWarning drops the close of a duplicate or spam when comments
+// are suppressed and the close could only be scheduled by posting a warning
+func withoutCloseWarning(ctx *core.Context, actions []triage.Action) []triage.Action {
+	if !ctx.SuppressComments || ctx.Result.CommentPosted || !ctx.Config.Defaults.DelayedActions.Enabled || !ctx.TriageResult.SchedulesClose() {
+		return actions
+	}
+	filtered := filterCloseActions(actions)
+	if len(filtered) < len(actions) {
+		logging.Info("comments suppressed, not scheduling close", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number)
+	}
+	return filtered
+}
+
+// recordFailure writes a failed side effect to the dead-letter queue for retry-failed
+func (s *ActionExecutor) recordFailure(ctx *core.Context, entry deadletter.Entry, cause error) {
+	if s.deadLetter == nil {
+		return
+	}
+	entry.Org = ctx.Issue.Org
+	entry.Repo = ctx.Issue.Repo
+	entry.IssueNumber = ctx.Issue.Number
+	entry.Error = cause.Error()
+	if err := s.deadLetter.Add(entry); err != nil {
+		logging.Warn("failed to record failed action", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "action", entry.Action, "error", err)
+	}
+}
+
+// Retry replays a dead-lettered action. When the action no longer applies it
+// returns why, without error, and nothing is written: the issue was ignored
+// or opted out with the no-bot label, or, for a summary, the issue was closed
+// or a summary was posted since.
+func (s *ActionExecutor) Retry(ctx context.Context, cfg *config.Config, entry deadletter.Entry) (string, error) {
+	if s.dryRun {
+		logging.Info("[DRY RUN] would retry", "repo", entry.Org+"/"+entry.Repo, "issue", entry.IssueNumber, "action", entry.Action)
+		return "", nil
+	}
+
+	if reason, err := s.retryObsolete(ctx, cfg, entry); err != nil || reason != "" {
+		return reason, err
+	}
+
+	switch entry.Action {
+	case deadletter.ActionComment:
+		return "", s.gh.PostComment(ctx, entry.Org, entry.Repo, entry.IssueNumber, entry.Body)
+	case deadletter.ActionAddLabel:
+		return "", s.gh.AddLabels(ctx, entry.Org, entry.Repo, entry.IssueNumber, []string{entry.Label})
+	case deadletter.ActionRemoveLabel:
+		return "", s.gh.RemoveLabel(ctx, entry.Org, entry.Repo, entry.IssueNumber, entry.Label)
+	default:
+		return "", fmt.Errorf("unknown action type: %s", entry.Action)
+	}
+}
+
+// retryObsolete reports why a dead-lettered action should be dropped rather
+// than replayed, applying the gatekeeper's checks to the issue as it is now
+func (s *ActionExecutor) retryObsolete(ctx context.Context, cfg *config.Config, entry deadletter.Entry) (string, error) {
+	issue, err := s.gh.GetIssue(ctx, entry.Org, entry.Repo, entry.IssueNumber)
+	if err != nil {
+		return "", err
+	}
+
+	if issue.HasLabel(pending.LabelIgnored) {
+		return fmt.Sprintf("%s label present", pending.LabelIgnored), nil
+	}
+	noBot := cfg.Defaults.NoBot
+	optedOut := noBot.Label != "" && issue.HasLabel(noBot.Label)
+	if optedOut && (noBot.SkipAll || entry.Action == deadletter.ActionComment) {
+		return fmt.Sprintf("%s label present", noBot.Label), nil
+	}
+	if entry.Action != deadletter.ActionComment {
+		return "", nil
+	}
+
+	if issue.State == "closed" {
+		return "issue closed", nil
+	}
+	existing, err := s.gh.FindBotComment(ctx, entry.Org, entry.Repo, entry.IssueNumber, SummaryHeading)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "summary already posted", nil
+	}
+	return "", nil
 }
 
 // Helpers copied from unified.go (or we should export them there? No, better copy or put in triage package)
@@ -142,6 +462,17 @@ func filterNonCommentActions(actions []triage.Action) []triage.Action {
 	return filtered
 }
 
+// filterLabelActions drops label additions and removals
+func filterLabelActions(actions []triage.Action) []triage.Action {
+	filtered := make([]triage.Action, 0, len(actions))
+	for _, a := range actions {
+		if a.Type != triage.ActionAddLabel && a.Type != triage.ActionRemoveLabel {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
 func filterCloseActions(actions []triage.Action) []triage.Action {
 	filtered := make([]triage.Action, 0, len(actions))
 	for _, a := range actions {
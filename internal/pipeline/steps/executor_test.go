@@ -0,0 +1,174 @@
+package steps
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/deadletter"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func suppressedContext(delayed bool) *core.Context {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Enabled = delayed
+	return &core.Context{
+		Ctx:              context.Background(),
+		Issue:            &models.Issue{Org: "org", Repo: "repo", Number: 1},
+		Config:           cfg,
+		Result:           &core.UnifiedResult{},
+		SuppressComments: true,
+	}
+}
+
+func TestWithoutCloseWarning(t *testing.T) {
+	actions := []triage.Action{
+		{Type: triage.ActionAddLabel, Label: "duplicate"},
+		{Type: triage.ActionClose},
+	}
+	dup := &triage.DuplicateResult{IsDuplicate: true, ShouldClose: true, Original: &models.Issue{Number: 2}}
+
+	tests := []struct {
+		name      string
+		delayed   bool
+		suppress  bool
+		posted    bool
+		duplicate *triage.DuplicateResult
+		want      int
+	}{
+		{"suppressed delayed duplicate", true, true, false, dup, 1},
+		{"comments allowed", true, false, false, dup, 2},
+		{"immediate close posts nothing", false, true, false, dup, 2},
+		{"silent close on the summary", true, true, true, dup, 2},
+		{"not a duplicate", true, true, false, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := suppressedContext(tt.delayed)
+			ctx.SuppressComments = tt.suppress
+			ctx.Result.CommentPosted = tt.posted
+			ctx.TriageResult = &triage.Result{Duplicate: tt.duplicate, Actions: actions}
+
+			got := withoutCloseWarning(ctx, actions)
+			if len(got) != tt.want {
+				t.Errorf("withoutCloseWarning() kept %d actions, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestActionExecutor_SuppressedSkipsTransfer(t *testing.T) {
+	for _, delayed := range []bool{false, true} {
+		ctx := suppressedContext(delayed)
+		ctx.TransferTarget = "org/other"
+
+		// No clients: any transfer attempt would panic
+		s := NewActionExecutor(nil, nil, nil, false, true)
+		s.SetDeadLetter(nil)
+		if err := s.Run(ctx); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if ctx.Result.Transferred || ctx.Result.CommentPosted {
+			t.Errorf("delayed=%v: transferred=%v commented=%v, want neither", delayed, ctx.Result.Transferred, ctx.Result.CommentPosted)
+		}
+	}
+}
+
+func TestActionExecutor_ActionLogRecordsCompletedActions(t *testing.T) {
+	ctx := suppressedContext(false)
+	ctx.Config.Defaults.ActionCooldowns.TransferHours = 24
+	s := &ActionExecutor{}
+
+	if log := s.actionLog(ctx, false, false); log != nil {
+		t.Errorf("actionLog() = %v, want nil when nothing happened", log)
+	}
+
+	log := s.actionLog(ctx, true, false)
+	if _, ok := log[github.ActionLabels]; !ok {
+		t.Error("actionLog() did not record the label change")
+	}
+	if _, ok := log[github.ActionTransfer]; ok {
+		t.Error("actionLog() recorded a transfer that did not happen")
+	}
+}
+
+// issueAPI serves one issue and its comments, recording every write
+type issueAPI struct {
+	issue    string
+	comments string
+	writes   []string
+}
+
+func (a *issueAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := "{}"
+	switch {
+	case req.Method != http.MethodGet:
+		a.writes = append(a.writes, req.Method+" "+req.URL.Path)
+	case strings.HasSuffix(req.URL.Path, "/comments"):
+		body = a.comments
+	default:
+		body = a.issue
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestActionExecutor_Retry(t *testing.T) {
+	const (
+		open    = `{"number": 1, "state": "open", "labels": []}`
+		summary = `[{"id": 9, "body": "## Simili summary\n<!-- ` + SummaryHeading + ` -->"}]`
+	)
+	comment := deadletter.Entry{Org: "org", Repo: "repo", IssueNumber: 1, Action: deadletter.ActionComment, Body: "summary"}
+	label := deadletter.Entry{Org: "org", Repo: "repo", IssueNumber: 1, Action: deadletter.ActionAddLabel, Label: "bug"}
+
+	tests := []struct {
+		name       string
+		entry      deadletter.Entry
+		issue      string
+		comments   string
+		wantReason string
+		wantWrites int
+	}{
+		{"comment replayed", comment, open, "[]", "", 1},
+		{"summary posted since", comment, open, summary, "summary already posted", 0},
+		{"issue closed", comment, `{"number": 1, "state": "closed", "labels": []}`, "[]", "issue closed", 0},
+		{"no-bot comment", comment, `{"number": 1, "state": "open", "labels": [{"name": "no-bot"}]}`, "[]", "no-bot label present", 0},
+		{"no-bot label still applied", label, `{"number": 1, "state": "open", "labels": [{"name": "no-bot"}]}`, "[]", "", 1},
+		{"ignored", label, `{"number": 1, "state": "open", "labels": [{"name": "` + pending.LabelIgnored + `"}]}`, "[]", pending.LabelIgnored + " label present", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &issueAPI{issue: tt.issue, comments: tt.comments}
+			gh, err := github.NewClientWithTransport("test", api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{}
+			cfg.Defaults.NoBot.Label = "no-bot"
+
+			reason, err := NewActionExecutor(gh, gh, nil, false, true).Retry(context.Background(), cfg, tt.entry)
+			if err != nil {
+				t.Fatalf("Retry() error = %v", err)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("Retry() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if len(api.writes) != tt.wantWrites {
+				t.Errorf("writes = %v, want %d", api.writes, tt.wantWrites)
+			}
+		})
+	}
+}
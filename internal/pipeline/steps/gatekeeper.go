@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 )
 
@@ -21,7 +23,7 @@ type RepoGatekeeper struct {
 
 // Client defines the subset of github.Client needed for this step
 type Client interface {
-	ShouldSkipComment(ctx context.Context, org, repo string, issueNum, cooldownHours int) (bool, error)
+	ListComments(ctx context.Context, org, repo string, number int) ([]github.Comment, error)
 }
 
 // NewRepoGatekeeper creates a new gatekeeper step
@@ -42,15 +44,60 @@ func (s *RepoGatekeeper) Run(ctx *core.Context) error {
 		return core.ErrSkipPipeline
 	}
 
-	// 2. Check cooldown
-	skip, err := s.gh.ShouldSkipComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, ctx.Config.Defaults.CommentCooldownHours)
-	if err != nil {
-		return fmt.Errorf("failed to check cooldown: %w", err)
+	// Maintainers silenced the bot with /simili ignore
+	if ctx.Issue.HasLabel(pending.LabelIgnored) {
+		ctx.Result.Skipped = true
+		ctx.SkipReason = fmt.Sprintf("%s label present", pending.LabelIgnored)
+		return core.ErrSkipPipeline
+	}
+
+	defaults := &ctx.Config.Defaults
+
+	// 2. Honor the per-issue opt-out label
+	optedOut := defaults.NoBot.Label != "" && ctx.Issue.HasLabel(defaults.NoBot.Label)
+	if optedOut && defaults.NoBot.SkipAll {
+		ctx.Result.Skipped = true
+		ctx.SkipReason = fmt.Sprintf("%s label present", defaults.NoBot.Label)
+		return core.ErrSkipPipeline
+	}
+
+	// One listing serves both the action log and the comment cooldown
+	var comments []github.Comment
+	if defaults.ActionCooldowns.Enabled() || !optedOut {
+		var err error
+		comments, err = s.gh.ListComments(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)
+		if err != nil {
+			return fmt.Errorf("failed to list comments: %w", err)
+		}
+	}
+
+	// Per-action cooldowns need to know when the bot last acted
+	if defaults.ActionCooldowns.Enabled() {
+		ctx.RecentActions, ctx.LastBotCommentID = github.BotActions(comments)
+	}
+
+	if optedOut {
+		// Still index and label, but never comment; the cooldown only guards comments
+		ctx.SuppressComments = true
+		return nil
+	}
+
+	// 3. Check cooldown
+	skip := github.CommentCooldownActive(comments, defaults.CommentCooldownHours, defaults.CommentOncePerIssue)
+
+	if skip && defaults.ActionCooldowns.Enabled() {
+		// Labels and transfers have their own cooldowns; only hold back the comment
+		logging.Info("comment cooldown active, continuing without a comment", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number)
+		ctx.SuppressComments = true
+		return nil
 	}
 
 	if skip {
 		ctx.Result.Skipped = true
 		ctx.SkipReason = "cooldown active"
+		if defaults.CommentOncePerIssue {
+			ctx.SkipReason = "already commented"
+		}
 		return core.ErrSkipPipeline
 	}
 
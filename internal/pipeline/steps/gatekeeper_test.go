@@ -0,0 +1,78 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+type fakeCommentLister struct {
+	comments []github.Comment
+	calls    int
+}
+
+func (f *fakeCommentLister) ListComments(ctx context.Context, org, repo string, number int) ([]github.Comment, error) {
+	f.calls++
+	return f.comments, nil
+}
+
+func TestRepoGatekeeper_ListsCommentsOnce(t *testing.T) {
+	log := github.WithActionLog("Simili summary", github.ActionLog{github.ActionLabels: time.Now().UTC()})
+	tests := []struct {
+		name      string
+		labels    []string
+		cooldown  bool
+		recent    bool
+		wantCalls int
+		wantSkip  bool
+		suppress  bool
+	}{
+		{"no bot comment", nil, true, false, 1, false, false},
+		{"comment cooldown holds back only the comment", nil, true, true, 1, false, true},
+		{"comment cooldown skips without action cooldowns", nil, false, true, 1, true, false},
+		{"opted out without action cooldowns", []string{"no-bot"}, false, true, 0, false, true},
+		{"opted out with action cooldowns", []string{"no-bot"}, true, true, 1, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "repo", Enabled: true}}}
+			cfg.Defaults.CommentCooldownHours = 1
+			cfg.Defaults.NoBot.Label = "no-bot"
+			if tt.cooldown {
+				cfg.Defaults.ActionCooldowns.LabelHours = 24
+			}
+
+			fake := &fakeCommentLister{}
+			if tt.recent {
+				fake.comments = []github.Comment{{ID: 7, Body: log, CreatedAt: time.Now()}}
+			}
+			ctx := &core.Context{
+				Ctx:    context.Background(),
+				Issue:  &models.Issue{Org: "org", Repo: "repo", Number: 1, Labels: tt.labels},
+				Config: cfg,
+				Result: &core.UnifiedResult{},
+			}
+
+			err := (&RepoGatekeeper{gh: fake}).Run(ctx)
+			if skipped := errors.Is(err, core.ErrSkipPipeline); skipped != tt.wantSkip {
+				t.Fatalf("Run() error = %v, want skip %v", err, tt.wantSkip)
+			}
+			if fake.calls != tt.wantCalls {
+				t.Errorf("ListComments called %d times, want %d", fake.calls, tt.wantCalls)
+			}
+			if ctx.SuppressComments != tt.suppress {
+				t.Errorf("SuppressComments = %v, want %v", ctx.SuppressComments, tt.suppress)
+			}
+			if tt.cooldown && tt.recent && ctx.LastBotCommentID != 7 {
+				t.Errorf("LastBotCommentID = %d, want 7", ctx.LastBotCommentID)
+			}
+		})
+	}
+}
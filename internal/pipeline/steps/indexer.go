@@ -7,8 +7,8 @@ package steps
 
 import (
 	"context"
-	"log"
 
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
@@ -39,11 +39,11 @@ func (s *Indexer) Name() string {
 func (s *Indexer) Run(ctx *core.Context) error {
 	// Skip logic from unified.go
 	if ctx.TransferTarget != "" {
-		log.Printf("Skipping indexing: issue will be transferred")
+		logging.Debug("skipping indexing, issue will be transferred", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "step", s.Name())
 		return nil
 	}
 	if ctx.TriageResult != nil && ctx.TriageResult.Duplicate != nil && ctx.TriageResult.Duplicate.ShouldClose {
-		log.Printf("Skipping indexing: issue will be closed as duplicate")
+		logging.Debug("skipping indexing, issue will be closed as duplicate", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "step", s.Name())
 		return nil
 	}
 
@@ -52,7 +52,7 @@ func (s *Indexer) Run(ctx *core.Context) error {
 	}
 
 	if err := s.client.IndexSingleIssue(ctx.Ctx, ctx.Issue); err != nil {
-		log.Printf("Warning: failed to index issue: %v", err)
+		ctx.Result.Warnf("failed to index issue: %v", err)
 	} else {
 		ctx.Result.Indexed = true
 	}
@@ -9,13 +9,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Kavirubc/gh-simili/internal/locale"
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 )
 
+// SummaryHeading identifies the unified summary comment among bot comments
+const SummaryHeading = "Issue Intelligence Summary"
+
+// summaryMarker keeps localized summaries findable by SummaryHeading
+const summaryMarker = "<!-- " + SummaryHeading + " -->"
+
 // ResponseBuilder constructs the unified comment body based on results.
 type ResponseBuilder struct{}
 
@@ -37,37 +46,54 @@ func (s *ResponseBuilder) Run(ctx *core.Context) error {
 
 func (s *ResponseBuilder) buildComment(ctx *core.Context) string {
 	result := ctx.Result
-	similarIssues := ctx.SimilarIssues
 	issue := ctx.Issue
+	similarIssues := displayedMatches(ctx)
+
+	if len(similarIssues) == 0 && result.TriageResult == nil && ctx.TransferTarget == "" && ctx.AreaTeam == "" {
+		return ""
+	}
 
-	if len(similarIssues) == 0 && result.TriageResult == nil && ctx.TransferTarget == "" {
+	// A summary with nothing in it only implies findings that aren't there
+	if !ctx.Config.Defaults.CommentWhenNothingFound && !hasFindings(ctx) {
 		return ""
 	}
 
+	st := style.ForRepo(ctx.Config, issue.Org, issue.Repo)
 	var sections []string
 
 	// Header
-	sections = append(sections, "## 🤖 Issue Intelligence Summary\n")
-	sections = append(sections, "Thanks for opening this issue! Here's what I found:\n")
+	heading := st.Heading(2, "🤖", st.Text(locale.SummaryHeading))
+	if !strings.Contains(heading, SummaryHeading) {
+		heading += "\n" + summaryMarker
+	}
+	sections = append(sections, heading+"\n")
+	sections = append(sections, st.Text(locale.SummaryIntro)+"\n")
 
-	// Similar issues section
+	// Similar issues section; more may have been fetched for analysis than are shown
 	if len(similarIssues) > 0 {
-		crossRepo := processor.HasCrossRepoResults(similarIssues, issue.Org, issue.Repo)
-		sections = append(sections, s.formatSimilarIssuesSection(similarIssues, crossRepo))
+		shown := vectordb.TrimResults(similarIssues, ctx.Config.Defaults.MaxSimilarToShow)
+		shown = vectordb.SortForDisplay(shown, ctx.Config.Defaults.SimilarSort)
+		crossRepo := processor.HasCrossRepoResults(shown, issue.Org, issue.Repo)
+		sections = append(sections, s.formatSimilarIssuesSection(st, shown, crossRepo))
 	}
 
 	// Triage results
 	if result.TriageResult != nil {
-		s.appendTriageSections(&sections, result.TriageResult)
+		s.appendTriageSections(ctx, st, &sections, result.TriageResult)
+	}
+
+	// Area owners section
+	if ctx.AreaTeam != "" {
+		sections = append(sections, s.formatAreaSection(st, ctx.AreaTeam))
 	}
 
 	// Transfer section
 	if ctx.TransferTarget != "" && !(ctx.Config.Defaults.DelayedActions.Enabled && ctx.Config.Defaults.DelayedActions.OptimisticTransfers) {
-		sections = append(sections, s.formatTransferSection(ctx, ctx.TransferTarget, ctx.Result.PendingAction))
+		sections = append(sections, s.formatTransferSection(ctx, st, ctx.TransferTarget, ctx.Result.PendingAction))
 	}
 
 	// Footer
-	footer := "\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>"
+	footer := "\n" + st.Footer("Simili")
 	if ctx.Result.PendingAction != nil {
 		metadata, err := pending.FormatPendingActionMetadata(ctx.Result.PendingAction)
 		if err == nil {
@@ -79,95 +105,132 @@ func (s *ResponseBuilder) buildComment(ctx *core.Context) string {
 	return strings.Join(sections, "\n\n")
 }
 
-func (s *ResponseBuilder) appendTriageSections(sections *[]string, triageResult *triage.Result) {
+// displayedMatches returns the similar issues scoring at least the display
+// threshold that the author didn't already link; the rest were only fetched
+// for duplicate detection
+func displayedMatches(ctx *core.Context) []vectordb.SearchResult {
+	shown := vectordb.AtLeast(ctx.SimilarIssues, ctx.Config.GetDisplayThreshold(ctx.Issue.Org, ctx.Issue.Repo))
+	return processor.FilterReferenced(shown, ctx.Issue)
+}
+
+// hasFindings reports whether the summary would carry anything actionable:
+// matches, labels, spam, a transfer, area owners, a duplicate, or quality concerns
+func hasFindings(ctx *core.Context) bool {
+	if len(displayedMatches(ctx)) > 0 || ctx.TransferTarget != "" || ctx.AreaTeam != "" {
+		return true
+	}
+
+	tr := ctx.Result.TriageResult
+	if tr == nil {
+		return false
+	}
+	return len(tr.Labels) > 0 ||
+		(tr.Spam != nil && tr.Spam.IsSpam) ||
+		(tr.Duplicate != nil && tr.Duplicate.IsDuplicate) ||
+		(tr.Quality != nil && len(tr.Quality.Missing) > 0)
+}
+
+func (s *ResponseBuilder) appendTriageSections(ctx *core.Context, st style.Style, sections *[]string, triageResult *triage.Result) {
+	// Spam section
+	if triageResult.Spam != nil && triageResult.Spam.IsSpam {
+		spamLine := st.Heading(3, "🚫", st.Text(locale.LikelySpam)) + "\n" + st.Textf(locale.Confidence, triageResult.Spam.Confidence*100)
+		if triageResult.Spam.Reason != "" {
+			spamLine += "\n" + triageResult.Spam.Reason
+		}
+		*sections = append(*sections, spamLine)
+	}
+
 	// Labels section
 	if len(triageResult.Labels) > 0 {
 		var labelLines []string
-		labelLines = append(labelLines, "### 🏷️ Suggested Labels")
+		labelLines = append(labelLines, st.Heading(3, "🏷️", st.Text(locale.SuggestedLabels)))
 		for _, l := range triageResult.Labels {
-			labelLines = append(labelLines, fmt.Sprintf("- `%s` (%.0f%% confidence) - %s", l.Label, l.Confidence*100, l.Reason))
+			labelLines = append(labelLines, fmt.Sprintf("- `%s` (%s) - %s", l.Label, st.Textf(locale.LabelConfidence, l.Confidence*100), l.Reason))
 		}
 		*sections = append(*sections, strings.Join(labelLines, "\n"))
 	}
 
 	// Quality section
 	if triageResult.Quality != nil {
-		qualityLine := fmt.Sprintf("### 📊 Quality Score: %.0f%%", triageResult.Quality.Score*100)
+		qualityLine := st.Heading(3, "📊", st.Textf(locale.QualityScore, triageResult.Quality.Score*100))
 		if len(triageResult.Quality.Missing) > 0 {
-			qualityLine += fmt.Sprintf("\n⚠️ Missing: %s", strings.Join(triageResult.Quality.Missing, ", "))
+			qualityLine += "\n" + st.Icon("⚠️") + st.Textf(locale.QualityMissing, strings.Join(triageResult.Quality.Missing, ", "))
 		} else {
-			qualityLine += "\n✅ Issue is well-documented"
+			qualityLine += "\n" + st.Icon("✅") + st.Text(locale.QualityGood)
 		}
 		*sections = append(*sections, qualityLine)
 	}
 
 	// Duplicate section
 	if triageResult.Duplicate != nil && triageResult.Duplicate.IsDuplicate {
-		dupLine := fmt.Sprintf("### ⚠️ Potential Duplicate\nSimilarity: %.0f%%", triageResult.Duplicate.Similarity*100)
+		dupLine := st.Heading(3, "⚠️", st.Text(locale.PotentialDuplicate)) + "\n" + st.Textf(locale.Similarity, triageResult.Duplicate.Similarity*100)
 		if triageResult.Duplicate.Original != nil {
-			dupLine += fmt.Sprintf("\nOriginal: [#%d - %s](%s)",
+			dupLine += "\n" + st.Textf(locale.Original, fmt.Sprintf("[#%d - %s](%s)",
 				triageResult.Duplicate.Original.Number,
 				truncateString(triageResult.Duplicate.Original.Title, 50),
-				triageResult.Duplicate.Original.URL)
+				triageResult.Duplicate.Original.URL))
+			if ctx.Config.Triage.Duplicate.MentionOriginalAuthor {
+				if mention := triage.OriginalAuthorMention(triageResult.Duplicate.Original); mention != "" {
+					dupLine += "\n" + mention
+				}
+			}
 		}
 		*sections = append(*sections, dupLine)
 	}
 }
 
-func (s *ResponseBuilder) formatSimilarIssuesSection(results []vectordb.SearchResult, crossRepo bool) string {
+func (s *ResponseBuilder) formatSimilarIssuesSection(st style.Style, results []vectordb.SearchResult, crossRepo bool) string {
 	if len(results) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("### 🔍 Related Issues\n\n")
+	sb.WriteString(st.Heading(3, "🔍", st.Text(locale.RelatedIssues)) + "\n\n")
 
 	if crossRepo {
-		sb.WriteString("| Issue | Repository | Similarity | Status |\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", st.Text(locale.ColumnIssue), st.Text(locale.ColumnRepository), st.Text(locale.ColumnSimilarity), st.Text(locale.ColumnStatus)))
 		sb.WriteString("|-------|------------|------------|--------|\n")
 	} else {
-		sb.WriteString("| Issue | Similarity | Status |\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", st.Text(locale.ColumnIssue), st.Text(locale.ColumnSimilarity), st.Text(locale.ColumnStatus)))
 		sb.WriteString("|-------|------------|--------|\n")
 	}
 
 	for _, r := range results {
-		status := "🟢 Open"
-		if r.Issue.State == "closed" {
-			status = "🔴 Closed"
-		}
-
-		title := truncateString(r.Issue.Title, 50)
-		link := fmt.Sprintf("[#%d - %s](%s)", r.Issue.Number, title, r.Issue.URL)
+		status := processor.StatusCell(st, &r.Issue)
+		link, repo := processor.MatchCells(st, r)
 		similarity := fmt.Sprintf("%.0f%%", r.Score*100)
 
 		if crossRepo {
-			repo := fmt.Sprintf("%s/%s", r.Issue.Org, r.Issue.Repo)
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", link, repo, similarity, status))
 		} else {
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", link, similarity, status))
 		}
 	}
 
-	sb.WriteString("\nIf any of these address your problem, please let us know!")
+	sb.WriteString("\n" + st.Text(locale.RelatedPrompt))
 	return sb.String()
 }
 
-func (s *ResponseBuilder) formatTransferSection(ctx *core.Context, target string, action *pending.PendingAction) string {
+func (s *ResponseBuilder) formatAreaSection(st style.Style, team string) string {
+	return st.Heading(3, "👥", st.Text(locale.AreaOwners)) + "\n\n" + st.Textf(locale.AreaMention, transfer.TeamMention(team))
+}
+
+func (s *ResponseBuilder) formatTransferSection(ctx *core.Context, st style.Style, target string, action *pending.PendingAction) string {
 	var sb strings.Builder
-	sb.WriteString("### 🔄 Transfer Suggestion\n\n")
-	sb.WriteString(fmt.Sprintf("This issue appears to belong in **%s**.\n\n", target))
+	sb.WriteString(st.Heading(3, "🔄", st.Text(locale.TransferHeading)) + "\n\n")
+	sb.WriteString(st.Textf(locale.TransferBelongs, target) + "\n\n")
 
 	if ctx.Config.Defaults.DelayedActions.Enabled && action != nil {
-		deadline := action.ExpiresAt.Format("2006-01-02 15:04 MST")
+		deadline := action.ExpiresAt.Format(pending.DeadlineLayout)
 		delayHours := ctx.Config.Defaults.DelayedActions.DelayHours
-		sb.WriteString(fmt.Sprintf("**This issue will be transferred in %d hours.**\n\n", delayHours))
-		sb.WriteString("**React to this comment:**\n")
-		sb.WriteString(fmt.Sprintf("- 👍 (%s) to approve and proceed with transfer\n", ctx.Config.Defaults.DelayedActions.ApproveReaction))
-		sb.WriteString(fmt.Sprintf("- 👎 (%s) to cancel this transfer\n\n", ctx.Config.Defaults.DelayedActions.CancelReaction))
-		sb.WriteString(fmt.Sprintf("**Deadline**: %s\n\n", deadline))
-		sb.WriteString("If no reaction is provided, the transfer will proceed automatically.")
+		sb.WriteString(fmt.Sprintf("**%s**\n\n", st.Textf(locale.TransferIn, delayHours)))
+		sb.WriteString(fmt.Sprintf("**%s**\n", st.Text(locale.ReactPrompt)))
+		sb.WriteString(fmt.Sprintf("- %s %s\n", st.Reaction("👍", ctx.Config.Defaults.DelayedActions.ApproveReaction), st.Text(locale.TransferApprove)))
+		sb.WriteString(fmt.Sprintf("- %s %s\n\n", st.Reaction("👎", ctx.Config.Defaults.DelayedActions.CancelReaction), st.Text(locale.TransferCancel)))
+		sb.WriteString(fmt.Sprintf("**%s**: %s\n\n", st.Text(locale.Deadline), deadline))
+		sb.WriteString(st.Text(locale.TransferAuto))
 	} else {
-		sb.WriteString("Transfer will be executed immediately.")
+		sb.WriteString(st.Text(locale.TransferNow))
 	}
 
 	return sb.String()
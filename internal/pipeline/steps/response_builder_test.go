@@ -0,0 +1,107 @@
+package steps
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func summaryContext(similar []vectordb.SearchResult, tr *triage.Result) *core.Context {
+	cfg := &config.Config{}
+	cfg.Defaults.SimilarityThreshold = 0.8
+	return &core.Context{
+		Ctx:           context.Background(),
+		Issue:         &models.Issue{Org: "org", Repo: "repo", Number: 1},
+		Config:        cfg,
+		Result:        &core.UnifiedResult{TriageResult: tr},
+		SimilarIssues: similar,
+	}
+}
+
+func TestHasFindings(t *testing.T) {
+	match := []vectordb.SearchResult{{Issue: models.Issue{Org: "org", Repo: "repo", Number: 2}, Score: 0.9}}
+	weak := []vectordb.SearchResult{{Issue: models.Issue{Org: "org", Repo: "repo", Number: 2}, Score: 0.5}}
+
+	tests := []struct {
+		name     string
+		similar  []vectordb.SearchResult
+		triage   *triage.Result
+		transfer string
+		area     string
+		want     bool
+	}{
+		{"nothing", nil, nil, "", "", false},
+		{"empty triage", nil, &triage.Result{Quality: &triage.QualityResult{Score: 0.9}}, "", "", false},
+		{"match below display threshold", weak, &triage.Result{}, "", "", false},
+		{"not a duplicate", nil, &triage.Result{Duplicate: &triage.DuplicateResult{}}, "", "", false},
+		{"displayed match", match, nil, "", "", true},
+		{"transfer", nil, nil, "org/docs", "", true},
+		{"area owners", nil, nil, "", "@org/ui", true},
+		{"labels", nil, &triage.Result{Labels: []triage.LabelResult{{Label: "bug"}}}, "", "", true},
+		{"spam", nil, &triage.Result{Spam: &triage.SpamResult{IsSpam: true}}, "", "", true},
+		{"duplicate", nil, &triage.Result{Duplicate: &triage.DuplicateResult{IsDuplicate: true}}, "", "", true},
+		{"missing details", nil, &triage.Result{Quality: &triage.QualityResult{Missing: []string{"steps to reproduce"}}}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		ctx := summaryContext(tt.similar, tt.triage)
+		ctx.TransferTarget = tt.transfer
+		ctx.AreaTeam = tt.area
+		if got := hasFindings(ctx); got != tt.want {
+			t.Errorf("hasFindings(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayedMatches_SkipsReferenced(t *testing.T) {
+	similar := []vectordb.SearchResult{
+		{Issue: models.Issue{Org: "org", Repo: "repo", Number: 2}, Score: 0.95},
+		{Issue: models.Issue{Org: "org", Repo: "repo", Number: 3}, Score: 0.9},
+	}
+	ctx := summaryContext(similar, nil)
+	ctx.Issue.Body = "Looks like a dup of #2"
+
+	shown := displayedMatches(ctx)
+	if len(shown) != 1 || shown[0].Issue.Number != 3 {
+		t.Errorf("displayedMatches() = %+v, want only #3", shown)
+	}
+	// Duplicate detection still gets the linked issue
+	if len(ctx.SimilarIssues) != 2 {
+		t.Errorf("SimilarIssues = %+v, want both matches kept", ctx.SimilarIssues)
+	}
+}
+
+func TestResponseBuilder_NothingFound(t *testing.T) {
+	empty := &triage.Result{Quality: &triage.QualityResult{Score: 0.9}}
+
+	ctx := summaryContext(nil, empty)
+	if err := NewResponseBuilder().Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.CommentBody != "" {
+		t.Errorf("CommentBody = %q, want no summary when nothing was found", ctx.CommentBody)
+	}
+
+	ctx = summaryContext(nil, empty)
+	ctx.Config.Defaults.CommentWhenNothingFound = true
+	if err := NewResponseBuilder().Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(ctx.CommentBody, SummaryHeading) {
+		t.Errorf("CommentBody = %q, want the summary with comment_when_nothing_found", ctx.CommentBody)
+	}
+
+	ctx = summaryContext(nil, &triage.Result{Labels: []triage.LabelResult{{Label: "bug"}}})
+	if err := NewResponseBuilder().Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(ctx.CommentBody, "bug") {
+		t.Errorf("CommentBody = %q, want the summary listing the label", ctx.CommentBody)
+	}
+}
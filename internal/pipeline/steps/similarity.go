@@ -7,8 +7,8 @@ package steps
 
 import (
 	"context"
-	"log"
 
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
@@ -36,7 +36,7 @@ func (s *SimilaritySearch) Name() string {
 func (s *SimilaritySearch) Run(ctx *core.Context) error {
 	// Optimization: If the issue is already marked for transfer, we don't need to search here.
 	if ctx.TransferTarget != "" {
-		log.Printf("Skipping similarity search: issue marked for transfer to %s", ctx.TransferTarget)
+		logging.Debug("skipping similarity search, issue marked for transfer", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "step", s.Name(), "target", ctx.TransferTarget)
 		return nil
 	}
 
@@ -44,7 +44,7 @@ func (s *SimilaritySearch) Run(ctx *core.Context) error {
 	if err != nil {
 		// We log warning but don't fail the pipeline for search failure (resilience)
 		// Or should we fail? The old code logged warning.
-		log.Printf("Warning: similarity search failed: %v", err)
+		ctx.Result.Warnf("similarity search failed: %v", err)
 		return nil
 	}
 
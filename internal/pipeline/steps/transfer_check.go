@@ -6,20 +6,27 @@
 package steps
 
 import (
-	"log"
+	"strings"
 	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/processor"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
 )
 
 // TransferCheck evaluates if an issue matches any transfer rules.
-type TransferCheck struct{}
+type TransferCheck struct {
+	similarity *processor.SimilarityFinder
+}
 
-// NewTransferCheck creates a new transfer check step
-func NewTransferCheck() *TransferCheck {
-	return &TransferCheck{}
+// NewTransferCheck creates a new transfer check step. The similarity finder
+// backs rules with min_similarity_to_target; without one those rules never fire.
+func NewTransferCheck(similarity *processor.SimilarityFinder) *TransferCheck {
+	return &TransferCheck{similarity: similarity}
 }
 
 func (s *TransferCheck) Name() string {
@@ -29,19 +36,41 @@ func (s *TransferCheck) Name() string {
 func (s *TransferCheck) Run(ctx *core.Context) error {
 	repoConfig := ctx.Config.GetRepoConfig(ctx.Issue.Org, ctx.Issue.Repo)
 	// If no config or no rules, nothing to do
-	if repoConfig == nil || len(repoConfig.TransferRules) == 0 {
+	if repoConfig == nil || (len(repoConfig.TransferRules) == 0 && len(repoConfig.AreaRules) == 0) {
 		return nil
 	}
 
-	matcher := transfer.NewRuleMatcher(repoConfig.TransferRules)
-	target, _ := matcher.Match(ctx.Issue)
+	var target string
+	if len(repoConfig.TransferRules) > 0 {
+		matcher := transfer.NewRuleMatcher(repoConfig.TransferRules)
+		target, _ = matcher.MatchWith(ctx.Issue, func(rule *config.TransferRule) bool {
+			return s.similarEnoughToTarget(ctx, rule)
+		})
+	}
+
+	// Area rules resolve the owning team, and route the issue when no transfer rule did
+	if len(repoConfig.AreaRules) > 0 {
+		if area := transfer.NewAreaMatcher(repoConfig.AreaRules).Match(ctx.Issue); area != nil {
+			logging.Debug("area rule matched", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "team", area.Team)
+			ctx.AreaTeam = area.Team
+			ctx.Result.AreaTeam = area.Team
+			if target == "" {
+				target = area.Target
+			}
+		}
+	}
 
 	if target == "" {
 		return nil
 	}
 
+	if hours := ctx.Config.Defaults.ActionCooldowns.TransferHours; ctx.RecentActions.Within(github.ActionTransfer, hours) {
+		logging.Info("transfer cooldown active, not suggesting transfer", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "target", target)
+		return nil
+	}
+
 	// Match found
-	log.Printf("Transfer rule matched: %s -> %s", ctx.Issue.Repo, target)
+	logging.Debug("transfer rule matched", "repo", ctx.Issue.FullRepo(), "issue", ctx.Issue.Number, "target", target)
 	ctx.TransferTarget = target
 
 	// Handle Delayed Actions Logic
@@ -71,3 +100,28 @@ func (s *TransferCheck) Run(ctx *core.Context) error {
 
 	return nil
 }
+
+// similarEnoughToTarget reports whether the issue is at least the rule's
+// min_similarity_to_target similar to an issue already in the target repo
+func (s *TransferCheck) similarEnoughToTarget(ctx *core.Context, rule *config.TransferRule) bool {
+	if rule.MinSimilarityToTarget <= 0 {
+		return true
+	}
+	if s.similarity == nil {
+		return false
+	}
+
+	parts := strings.SplitN(rule.Target, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	score, err := s.similarity.TopScoreInRepo(ctx.Ctx, ctx.Issue, parts[0], parts[1])
+	if err != nil {
+		ctx.Result.Warnf("similarity check against %s failed: %v", rule.Target, err)
+		return false
+	}
+
+	logging.Debug("transfer rule similarity", "issue", ctx.Issue.Number, "target", rule.Target, "score", score, "min_score", rule.MinSimilarityToTarget)
+	return score >= rule.MinSimilarityToTarget
+}
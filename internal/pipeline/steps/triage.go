@@ -7,7 +7,6 @@ package steps
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/pending"
@@ -55,7 +54,8 @@ func (s *TriageAnalysis) Run(ctx *core.Context) error {
 	}
 
 	if err != nil {
-		log.Printf("Warning: triage failed: %v", err)
+		ctx.Result.Warnf("triage failed: %v", err)
+		ctx.TriageFailed = true
 		return nil
 	}
 
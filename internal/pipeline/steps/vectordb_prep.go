@@ -41,7 +41,7 @@ func (s *VectorDBPrep) Run(ctx *core.Context) error {
 		return nil
 	}
 
-	collection := vectordb.CollectionName(ctx.Issue.Org)
+	collection := vectordb.CollectionName(&ctx.Config.Qdrant, ctx.Issue.Org, ctx.Issue.Repo)
 	if err := s.vdb.EnsureCollection(ctx.Ctx, collection); err != nil {
 		return fmt.Errorf("failed to ensure collection: %w", err)
 	}
@@ -9,15 +9,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/profile"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
@@ -32,16 +39,26 @@ type UnifiedProcessor struct {
 	embedder       *embedding.FallbackProvider
 	vdb            *vectordb.Client
 	similarity     *processor.SimilarityFinder
-	indexer        *processor.Indexer
+	indexer        issueIndex
 	triageAgent    *triage.Agent
 	llmProvider    llm.Provider
 	dryRun         bool
 	execute        bool
+	profile        bool
+	runID          string // identifies this run in claim markers
 
 	// pipeline is the sequence of steps to execute for new issues
 	pipeline []core.Step
 }
 
+// issueIndex is the part of processor.Indexer that event handling uses to
+// keep single issues in the index up to date
+type issueIndex interface {
+	IndexSingleIssue(ctx context.Context, issue *models.Issue) error
+	DeleteIssue(ctx context.Context, org, repo string, number int) error
+	Close() error
+}
+
 // NewUnifiedProcessor creates a new unified processor
 func NewUnifiedProcessor(cfg *config.Config, dryRun bool, execute bool) (*UnifiedProcessor, error) {
 	return NewUnifiedProcessorWithTransferToken(cfg, dryRun, execute, "")
@@ -75,6 +92,7 @@ func NewUnifiedProcessorWithTransferToken(cfg *config.Config, dryRun bool, execu
 		embedder.Close()
 		return nil, fmt.Errorf("failed to create vector DB client: %w", err)
 	}
+	vdb.SetMultiVector(&cfg.Embedding.MultiVector)
 
 	indexer, err := processor.NewIndexer(cfg, dryRun)
 	if err != nil {
@@ -84,6 +102,7 @@ func NewUnifiedProcessorWithTransferToken(cfg *config.Config, dryRun bool, execu
 	}
 
 	similarity := processor.NewSimilarityFinder(cfg, embedder, vdb)
+	similarity.SetVisibilityChecker(gh)
 
 	// Create LLM provider for triage (optional - only if triage is enabled)
 	var llmProvider llm.Provider
@@ -91,7 +110,7 @@ func NewUnifiedProcessorWithTransferToken(cfg *config.Config, dryRun bool, execu
 	if cfg.Triage.Enabled {
 		llmProvider, err = createLLMProvider(&cfg.Triage.LLM)
 		if err != nil {
-			log.Printf("Warning: failed to create LLM provider for triage: %v", err)
+			logging.Warnf("failed to create LLM provider for triage: %v", err)
 		} else {
 			triageAgent = triage.NewAgentWithGitHub(cfg, llmProvider, similarity, gh)
 		}
@@ -109,6 +128,7 @@ func NewUnifiedProcessorWithTransferToken(cfg *config.Config, dryRun bool, execu
 		llmProvider:    llmProvider,
 		dryRun:         dryRun,
 		execute:        execute,
+		runID:          pending.RunID(),
 	}
 
 	// Initialize the pipeline
@@ -116,7 +136,7 @@ func NewUnifiedProcessorWithTransferToken(cfg *config.Config, dryRun bool, execu
 	pipe, err := builder.BuildFromConfig()
 	if err != nil {
 		// Log warning and fallback to default if config invalid
-		log.Printf("Warning: invalid pipeline configuration: %v. Using default pipeline.", err)
+		logging.Warn("invalid pipeline configuration, using default pipeline", "error", err)
 		pipe = builder.BuildDefault()
 	}
 	up.pipeline = pipe
@@ -129,14 +149,28 @@ func createLLMProvider(cfg *config.LLMConfig) (llm.Provider, error) {
 	if cfg.APIKey == "" {
 		return nil, fmt.Errorf("LLM API key not configured")
 	}
+	opts := llm.Options{
+		Temperature: float32(cfg.GetTemperature()),
+		Seed:        cfg.Seed,
+		Timeout:     time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+	var provider llm.Provider
+	var err error
 	switch cfg.Provider {
 	case "gemini":
-		return llm.NewGeminiProvider(cfg.APIKey, cfg.Model)
+		provider, err = llm.NewGeminiProvider(cfg.APIKey, cfg.Model, opts)
 	case "openai":
-		return llm.NewOpenAIProvider(cfg.APIKey, cfg.Model)
+		provider, err = llm.NewOpenAIProvider(cfg.APIKey, cfg.Model, opts)
 	default:
 		return nil, fmt.Errorf("unknown LLM provider: %s", cfg.Provider)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast to rule-based triage during an LLM outage
+	cooldown := time.Duration(cfg.BreakerCooldownSeconds) * time.Second
+	return llm.NewCircuitBreaker(provider, cfg.BreakerThreshold, cooldown), nil
 }
 
 // Close releases all resources
@@ -179,6 +213,13 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 		if issue == nil {
 			return nil, fmt.Errorf("failed to parse issue from comment event")
 		}
+		if cmd := ParseCommand(event.Comment.Body); cmd != nil && event.Action == "created" {
+			author := ""
+			if event.Comment.User != nil {
+				author = event.Comment.User.Login
+			}
+			return up.ProcessCommand(ctx, issue, cmd, author)
+		}
 		return up.ProcessCommentEvent(ctx, issue)
 	}
 
@@ -198,7 +239,9 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 	switch {
 	case event.IsOpenedEvent():
 		return up.ProcessIssue(ctx, issue)
-	case event.IsEditedEvent(), event.IsClosedEvent(), event.IsReopenedEvent():
+	case event.IsEditedEvent():
+		return up.ReevaluateEdited(ctx, issue)
+	case event.IsClosedEvent(), event.IsReopenedEvent():
 		// For state changes, we just need to update the index
 		// We use a simplified context just for indexing
 		if err := up.indexer.IndexSingleIssue(ctx, issue); err != nil {
@@ -216,6 +259,14 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 			IssueNumber: issue.Number,
 			Indexed:     true, // Flagging as "Indexed" (updated) effectively
 		}, nil
+	case event.IsTransferredEvent():
+		return up.handleTransferred(ctx, issue, event.TransferredIssue())
+	case event.IsLabeledEvent():
+		label := ""
+		if event.Label != nil {
+			label = event.Label.Name
+		}
+		return up.ReevaluateLabeled(ctx, issue, label)
 	default:
 		return &core.UnifiedResult{
 			IssueNumber: issue.Number,
@@ -225,8 +276,51 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 	}
 }
 
+// SetProfile enables per-stage timing, reported in UnifiedResult.Timings
+func (up *UnifiedProcessor) SetProfile(enabled bool) {
+	up.profile = enabled
+}
+
+// SelectSteps narrows the pipeline to the steps in only and without those in
+// skip, for isolating a single stage while debugging
+func (up *UnifiedProcessor) SelectSteps(only, skip []string) error {
+	if len(only) == 0 && len(skip) == 0 {
+		return nil
+	}
+	pipe, err := FilterSteps(up.pipeline, only, skip)
+	if err != nil {
+		return err
+	}
+	up.pipeline = pipe
+	return nil
+}
+
+// reprocessSkipped are the steps left out when re-triaging issues that were
+// already processed: they have a summary and a transfer decision, and their
+// vectors are current
+var reprocessSkipped = []string{"transfer_check", "response_builder", "indexer"}
+
+// PrepareReprocess narrows the processor to re-triaging existing issues. It
+// drops the summary, transfer and indexer steps, and only considers older
+// issues as originals, so an original is never closed as a duplicate of a
+// newer report.
+func (up *UnifiedProcessor) PrepareReprocess() {
+	up.pipeline = slices.DeleteFunc(up.pipeline, func(s core.Step) bool {
+		return slices.Contains(reprocessSkipped, s.Name())
+	})
+	up.similarity.AddFilters(processor.CreatedBefore())
+}
+
 // ProcessIssue processes a single issue through the configured pipeline
 func (up *UnifiedProcessor) ProcessIssue(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, error) {
+	var rec *profile.Recorder
+	if up.profile {
+		rec = profile.NewRecorder()
+		ctx = profile.WithRecorder(ctx, rec)
+		start := time.Now()
+		defer func() { rec.Add("total", time.Since(start)) }()
+	}
+
 	// Initialize Pipeline Context
 	pCtx := &core.Context{
 		Ctx:    ctx,
@@ -235,20 +329,386 @@ func (up *UnifiedProcessor) ProcessIssue(ctx context.Context, issue *models.Issu
 		Result: &core.UnifiedResult{IssueNumber: issue.Number},
 	}
 
+	// Claim and label only once the gatekeeper has let the issue through, so
+	// disabled repos and ignored issues never see a bot comment or label
+	admitted, holding := false, false
+
 	// Execute Steps
 	for _, step := range up.pipeline {
-		if err := step.Run(pCtx); err != nil {
+		if !admitted && step.Name() != "gatekeeper" {
+			admitted = true
+			if !up.claim(ctx, issue, pCtx.Result) {
+				pCtx.Result.Skipped = true
+				pCtx.Result.SkipReason = "claimed by another run"
+				return pCtx.Result, nil
+			}
+			holding = up.applyHoldingLabel(ctx, issue, pCtx.Result)
+		}
+
+		logging.Debug("running step", "repo", issue.FullRepo(), "issue", issue.Number, "step", step.Name())
+		stop := profile.Track(ctx, "step."+step.Name())
+		err := step.Run(pCtx)
+		stop()
+		if err != nil {
 			if errors.Is(err, core.ErrSkipPipeline) {
 				// Pipeline stopped gratefully (e.g. cooldown, disabled repo)
+				logging.Debug("step stopped the pipeline", "repo", issue.FullRepo(), "issue", issue.Number, "step", step.Name(), "reason", pCtx.SkipReason)
+				pCtx.Result.SkipReason = pCtx.SkipReason
 				break
 			}
 			return nil, fmt.Errorf("step %s failed: %w", step.Name(), err)
 		}
 	}
 
+	// A failed triage keeps the holding label so maintainers can spot it
+	if holding && !pCtx.TriageFailed {
+		up.removeHoldingLabel(ctx, issue, pCtx.Result)
+	}
+
+	if rec != nil {
+		pCtx.Result.Timings = rec.Milliseconds()
+	}
+
 	return pCtx.Result, nil
 }
 
+// claim marks the issue as owned by this run when claim_window_minutes is
+// set, reporting false when another run claimed it within the window.
+// Claim errors are logged and processing continues unclaimed. Issues with
+// the no-bot label are never claimed since the claim is itself a comment.
+func (up *UnifiedProcessor) claim(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) bool {
+	window := up.cfg.Defaults.ClaimWindowMinutes
+	if window <= 0 || up.dryRun || !up.execute {
+		return true
+	}
+	if label := up.cfg.Defaults.NoBot.Label; label != "" && issue.HasLabel(label) {
+		return true
+	}
+
+	mgr := pending.NewManager(up.gh, up.cfg)
+	claimed, err := mgr.Claim(ctx, issue, up.runID, time.Duration(window)*time.Minute)
+	if err != nil {
+		result.Warnf("failed to claim #%d: %v", issue.Number, err)
+		return true
+	}
+	if !claimed {
+		logging.Info("skipping issue claimed by another run", "repo", issue.FullRepo(), "issue", issue.Number)
+	}
+	return claimed
+}
+
+// applyHoldingLabel adds the needs-triage label to an issue in an enabled
+// repo before processing starts, reporting whether it was applied
+func (up *UnifiedProcessor) applyHoldingLabel(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) bool {
+	nt := up.cfg.Triage.NeedsTriage
+	if !nt.Enabled || up.dryRun || !up.execute {
+		return false
+	}
+	if repoConfig := up.cfg.GetRepoConfig(issue.Org, issue.Repo); repoConfig == nil || !repoConfig.Enabled {
+		return false
+	}
+
+	if err := up.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{nt.Label}); err != nil {
+		result.Warnf("failed to add %s label: %v", nt.Label, err)
+		return false
+	}
+	return true
+}
+
+// removeHoldingLabel removes the needs-triage label once processing completes
+func (up *UnifiedProcessor) removeHoldingLabel(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) {
+	label := up.cfg.Triage.NeedsTriage.Label
+	if err := up.gh.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, label); err != nil && !errors.Is(err, github.ErrNotFound) {
+		result.Warnf("failed to remove %s label: %v", label, err)
+	}
+}
+
+// Plan runs the read-only part of the pipeline (similarity, transfer matching,
+// triage, comment building) and returns the comment that would be posted.
+// No writes are performed regardless of the execute flag.
+func (up *UnifiedProcessor) Plan(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, string, error) {
+	pCtx := &core.Context{
+		Ctx:    ctx,
+		Issue:  issue,
+		Config: up.cfg,
+		Result: &core.UnifiedResult{IssueNumber: issue.Number},
+	}
+
+	if err := up.runSteps(pCtx, func(name string) bool { return !isWriteStep(name) }); err != nil {
+		return nil, "", err
+	}
+
+	return pCtx.Result, pCtx.CommentBody, nil
+}
+
+// runSteps runs the configured steps accepted by include, stopping on ErrSkipPipeline
+func (up *UnifiedProcessor) runSteps(pCtx *core.Context, include func(name string) bool) error {
+	for _, step := range up.pipeline {
+		if !include(step.Name()) {
+			continue
+		}
+		if err := step.Run(pCtx); err != nil {
+			if errors.Is(err, core.ErrSkipPipeline) {
+				logging.Debug("step stopped the pipeline", "repo", pCtx.Issue.FullRepo(), "issue", pCtx.Issue.Number, "step", step.Name(), "reason", pCtx.SkipReason)
+				pCtx.Result.SkipReason = pCtx.SkipReason
+				return nil
+			}
+			return fmt.Errorf("step %s failed: %w", step.Name(), err)
+		}
+	}
+	return nil
+}
+
+// handleTransferred drops the vector of an issue that was moved out of its
+// repository and indexes it at its new location when that repo is enabled
+func (up *UnifiedProcessor) handleTransferred(ctx context.Context, issue, moved *models.Issue) (*core.UnifiedResult, error) {
+	if err := up.indexer.DeleteIssue(ctx, issue.Org, issue.Repo, issue.Number); err != nil {
+		return nil, fmt.Errorf("failed to delete from index: %w", err)
+	}
+	result := &core.UnifiedResult{IssueNumber: issue.Number, Indexed: true}
+
+	if moved == nil {
+		return result, nil
+	}
+	if repoConfig := up.cfg.GetRepoConfig(moved.Org, moved.Repo); repoConfig == nil || !repoConfig.Enabled {
+		return result, nil
+	}
+	if err := up.indexer.IndexSingleIssue(ctx, moved); err != nil {
+		result.Warnf("failed to index %s/%s#%d: %v", moved.Org, moved.Repo, moved.Number, err)
+	}
+	return result, nil
+}
+
+// ReevaluateEdited re-runs similarity and triage for an edited issue and
+// refreshes the existing summary comment, since the edit may have added
+// detail. Edits never create a new comment, and a summary updated within
+// the comment cooldown is left alone.
+func (up *UnifiedProcessor) ReevaluateEdited(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, error) {
+	result := &core.UnifiedResult{IssueNumber: issue.Number}
+
+	if up.debounced(ctx, issue, result) {
+		result.Skipped = true
+		result.SkipReason = "debounced: unchanged since last run"
+		return result, nil
+	}
+
+	// The issue may have been moved by hand since the event fired
+	if _, err := up.gh.GetIssue(ctx, issue.Org, issue.Repo, issue.Number); errors.Is(err, github.ErrTransferred) {
+		logging.Info("issue was transferred, removing stale index entry", "repo", issue.FullRepo(), "issue", issue.Number, "error", err)
+		if err := up.indexer.DeleteIssue(ctx, issue.Org, issue.Repo, issue.Number); err != nil {
+			return nil, fmt.Errorf("failed to delete from index: %w", err)
+		}
+		result.Indexed = true
+		result.Skipped = true
+		result.SkipReason = "issue transferred"
+		return result, nil
+	} else if err != nil {
+		result.Warnf("failed to check issue location: %v", err)
+	}
+
+	// The index always tracks the latest text
+	if err := up.indexer.IndexSingleIssue(ctx, issue); err != nil {
+		return nil, fmt.Errorf("failed to update index: %w", err)
+	}
+	result.Indexed = true
+
+	return up.refreshSummary(ctx, issue, result, false)
+}
+
+// openingLabelWindow is how soon after creation an issue may be last updated
+// for its labeled events to count as labels applied when it was opened
+const openingLabelWindow = 10 * time.Second
+
+// ReevaluateLabeled re-checks transfer rules after a label is added, when
+// defaults.transfer_on_label is set. Rules often key on labels that
+// maintainers only apply after the issue was opened. Nothing else is re-run.
+// Labels applied when the issue was opened are left to the opened run.
+func (up *UnifiedProcessor) ReevaluateLabeled(ctx context.Context, issue *models.Issue, label string) (*core.UnifiedResult, error) {
+	result := &core.UnifiedResult{IssueNumber: issue.Number}
+
+	skip := func(reason string) (*core.UnifiedResult, error) {
+		result.Skipped = true
+		result.SkipReason = reason
+		return result, nil
+	}
+
+	switch repoConfig := up.cfg.GetRepoConfig(issue.Org, issue.Repo); {
+	case !up.cfg.Defaults.TransferOnLabel:
+		return skip("transfer_on_label disabled")
+	case repoConfig == nil || !repoConfig.Enabled:
+		return skip("repository not enabled")
+	case issue.State == "closed":
+		return skip("issue closed")
+	case issue.HasLabel(pending.LabelIgnored):
+		return skip(fmt.Sprintf("%s label present", pending.LabelIgnored))
+	case issue.HasLabel(pending.LabelPendingTransfer):
+		return skip("transfer already pending")
+	case up.cfg.Defaults.NoBot.Label != "" && issue.HasLabel(up.cfg.Defaults.NoBot.Label):
+		// Every transfer path comments, which opted-out issues never get
+		return skip(fmt.Sprintf("%s label present", up.cfg.Defaults.NoBot.Label))
+	case !issue.CreatedAt.IsZero() && issue.UpdatedAt.Sub(issue.CreatedAt) < openingLabelWindow:
+		return skip("label applied when the issue was opened")
+	}
+
+	// An opened or sync run still working on the issue may schedule the same transfer
+	if !up.claim(ctx, issue, result) {
+		return skip("claimed by another run")
+	}
+
+	pCtx := &core.Context{
+		Ctx:    ctx,
+		Issue:  issue,
+		Config: up.cfg,
+		Result: result,
+	}
+	if up.cfg.Defaults.ActionCooldowns.Enabled() {
+		log, _, err := up.gh.RecentBotActions(ctx, issue.Org, issue.Repo, issue.Number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check recent bot actions: %w", err)
+		}
+		pCtx.RecentActions = log
+	}
+
+	if err := steps.NewTransferCheck(up.similarity).Run(pCtx); err != nil {
+		return nil, fmt.Errorf("transfer check failed: %w", err)
+	}
+	if pCtx.TransferTarget == "" {
+		return skip("no transfer rule matches")
+	}
+	target := pCtx.TransferTarget
+	result.TransferTarget = target
+	logging.Info("label matched a transfer rule", "repo", issue.FullRepo(), "issue", issue.Number, "label", label, "target", target)
+
+	if up.dryRun || !up.execute {
+		logging.Info("[DRY RUN] would transfer", "repo", issue.FullRepo(), "issue", issue.Number, "action", "transfer", "target", target)
+		return result, nil
+	}
+
+	// Transfer schedules instead of moving when delayed actions are enabled
+	executor := transfer.NewExecutor(up.transferClient, up.gh, up.vdb, up.cfg, up.dryRun)
+	if err := executor.Transfer(ctx, issue, target, nil); err != nil {
+		return nil, fmt.Errorf("failed to transfer: %w", err)
+	}
+	delayed := up.cfg.Defaults.DelayedActions
+	result.TransferScheduled = delayed.Enabled && !delayed.OptimisticTransfers
+	result.Transferred = !result.TransferScheduled
+	result.ActionsExecuted = 1
+	return result, nil
+}
+
+// debounced reports whether the summary was stamped within
+// edit_debounce_minutes for the issue's current title and body
+func (up *UnifiedProcessor) debounced(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) bool {
+	window := time.Duration(up.cfg.Defaults.EditDebounceMinutes) * time.Minute
+	if window <= 0 {
+		return false
+	}
+	existing, err := up.gh.FindBotComment(ctx, issue.Org, issue.Repo, issue.Number, steps.SummaryHeading)
+	if err != nil {
+		result.Warnf("failed to look up summary comment: %v", err)
+		return false
+	}
+	return existing != nil && github.ParseProcessedMarker(existing.Body).Covers(issue, window)
+}
+
+// refreshSummary re-runs similarity and triage and rewrites the summary
+// comment. Forced refreshes ignore the comment cooldown and post a new
+// summary when the issue has none.
+func (up *UnifiedProcessor) refreshSummary(ctx context.Context, issue *models.Issue, result *core.UnifiedResult, force bool) (*core.UnifiedResult, error) {
+	repoConfig := up.cfg.GetRepoConfig(issue.Org, issue.Repo)
+	if repoConfig == nil || !repoConfig.Enabled {
+		return result, nil
+	}
+
+	// Opted-out issues keep their index entry current but get no comment updates
+	if label := up.cfg.Defaults.NoBot.Label; label != "" && issue.HasLabel(label) {
+		return result, nil
+	}
+	if issue.HasLabel(pending.LabelIgnored) && !force {
+		return result, nil
+	}
+
+	existing, err := up.gh.FindBotComment(ctx, issue.Org, issue.Repo, issue.Number, steps.SummaryHeading)
+	if err != nil {
+		result.Warnf("failed to look up summary comment: %v", err)
+		return result, nil
+	}
+	if existing == nil && !force {
+		return result, nil
+	}
+
+	// Comments carrying pending-action metadata are owned by the delayed action flow
+	if existing != nil && strings.Contains(existing.Body, "simili-pending-action") {
+		return result, nil
+	}
+
+	if existing != nil && !force {
+		cooldown := time.Duration(up.cfg.Defaults.CommentCooldownHours) * time.Hour
+		lastChange := existing.UpdatedAt
+		if lastChange.IsZero() {
+			lastChange = existing.CreatedAt
+		}
+		if time.Since(lastChange) < cooldown {
+			result.SkipReason = "cooldown active"
+			return result, nil
+		}
+	}
+
+	pCtx := &core.Context{
+		Ctx:    ctx,
+		Issue:  issue,
+		Config: up.cfg,
+		Result: result,
+	}
+	reevaluate := func(name string) bool {
+		switch name {
+		case "similarity_search", "triage", "response_builder":
+			return true
+		}
+		return false
+	}
+	if err := up.runSteps(pCtx, reevaluate); err != nil {
+		return nil, err
+	}
+
+	if pCtx.CommentBody == "" || (existing != nil && pCtx.CommentBody == github.StripProcessedMarker(existing.Body)) {
+		return result, nil
+	}
+	if up.cfg.Defaults.EditDebounceMinutes > 0 {
+		pCtx.CommentBody = github.WithProcessedMarker(pCtx.CommentBody, github.NewProcessedMarker(issue))
+	}
+	if up.dryRun || !up.execute {
+		logging.Info("dry run or execute=false, not writing summary comment", "repo", issue.FullRepo(), "issue", issue.Number)
+		return result, nil
+	}
+
+	if existing == nil {
+		if err := up.gh.PostComment(ctx, issue.Org, issue.Repo, issue.Number, pCtx.CommentBody); err != nil {
+			result.Warnf("failed to post summary comment: %v", err)
+			return result, nil
+		}
+		result.CommentPosted = true
+		return result, nil
+	}
+
+	if err := up.gh.UpdateComment(ctx, issue.Org, issue.Repo, existing.ID, pCtx.CommentBody); err != nil {
+		result.Warnf("failed to update summary comment: %v", err)
+		return result, nil
+	}
+	result.CommentPosted = true
+
+	return result, nil
+}
+
+// isWriteStep reports whether a pipeline step has side effects on GitHub or Qdrant
+func isWriteStep(name string) bool {
+	switch name {
+	case "vectordb_prep", "action_executor", "indexer":
+		return true
+	}
+	return false
+}
+
 // ProcessCommentEvent keeps the legacy logic for now, as it handles specific interactions
 // TODO: Refactor this into a separate "InteractionPipeline" in future.
 func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, error) {
@@ -260,7 +720,7 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 	// Check if this issue has a pending action
 	action, err := pendingMgr.GetPendingAction(ctx, issue)
 	if err != nil {
-		log.Printf("Error checking pending action: %v", err)
+		logging.Error("failed to check pending action", "repo", issue.FullRepo(), "issue", issue.Number, "error", err)
 		result.Skipped = true
 		result.SkipReason = "error checking pending action"
 		return result, nil
@@ -270,11 +730,11 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 	revertMgr := transfer.NewRevertManager(up.gh, up.cfg)
 	revertAction, err := revertMgr.CheckForRevert(ctx, issue)
 	if err != nil {
-		log.Printf("Error checking for revert: %v", err)
+		logging.Error("failed to check for revert", "repo", issue.FullRepo(), "issue", issue.Number, "error", err)
 	}
 
 	if revertAction != nil {
-		log.Printf("Found revert action for issue #%d, executing...", issue.Number)
+		logging.Info("executing revert", "repo", issue.FullRepo(), "issue", issue.Number)
 		executor := transfer.NewExecutor(up.transferClient, up.gh, up.vdb, up.cfg, up.dryRun)
 		if err := revertMgr.Revert(ctx, issue, revertAction, executor); err != nil {
 			return nil, fmt.Errorf("failed to execute revert: %w", err)
@@ -291,7 +751,7 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 	}
 
 	// Action found! Check if we should execute it
-	log.Printf("Found pending %s action for issue #%d, checking status...", action.Type, issue.Number)
+	logging.Debug("checking pending action", "repo", issue.FullRepo(), "issue", issue.Number, "action", action.Type)
 
 	switch action.Type {
 	case pending.ActionTypeTransfer:
@@ -308,6 +768,12 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 			return nil, fmt.Errorf("failed to process pending close: %w", err)
 		}
 		result.ActionsExecuted = 1
+
+	case pending.ActionTypeComment:
+		if err := pendingMgr.ProcessPendingComment(ctx, action, up.dryRun); err != nil {
+			return nil, fmt.Errorf("failed to process pending comment: %w", err)
+		}
+		result.ActionsExecuted = 1
 	}
 
 	return result, nil
@@ -329,9 +795,12 @@ func PrintUnifiedResult(result *core.UnifiedResult) {
 	}
 
 	if result.TransferTarget != "" {
-		status := "scheduled"
-		if result.Transferred {
+		status := "not run"
+		switch {
+		case result.Transferred:
 			status = "executed"
+		case result.TransferScheduled:
+			status = "scheduled"
 		}
 		fmt.Printf("Transfer to %s: %s\n", result.TransferTarget, status)
 	}
@@ -364,4 +833,58 @@ func PrintUnifiedResult(result *core.UnifiedResult) {
 	if result.ActionsExecuted > 0 {
 		fmt.Printf("Actions Executed: %d\n", result.ActionsExecuted)
 	}
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("Errors: %d (partially processed)\n", len(result.Errors))
+		for _, e := range result.Errors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+
+	if len(result.Timings) > 0 {
+		PrintTimings(result.Timings)
+	}
+}
+
+// WriteGitHubOutput appends the result to a GitHub Actions output file
+// (normally $GITHUB_OUTPUT) so later workflow steps can alert on it
+func WriteGitHubOutput(path string, result *core.UnifiedResult) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open GitHub output file: %w", err)
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "skipped=%t\n", result.Skipped)
+	fmt.Fprintf(&b, "comment_posted=%t\n", result.CommentPosted)
+	fmt.Fprintf(&b, "transferred=%t\n", result.Transferred)
+	fmt.Fprintf(&b, "transfer_scheduled=%t\n", result.TransferScheduled)
+	fmt.Fprintf(&b, "error_count=%d\n", len(result.Errors))
+	// Multi-line values use the heredoc-style delimiter syntax
+	fmt.Fprintf(&b, "errors<<SIMILI_EOF\n%s\nSIMILI_EOF\n", strings.Join(result.Errors, "\n"))
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write GitHub output: %w", err)
+	}
+	return nil
+}
+
+// PrintTimings outputs per-stage timings, slowest first
+func PrintTimings(timings map[string]int) {
+	names := make([]string, 0, len(timings))
+	for name := range timings {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if timings[names[i]] != timings[names[j]] {
+			return timings[names[i]] > timings[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Println("Timings:")
+	for _, name := range names {
+		fmt.Printf("  %-28s %6d ms\n", name, timings[name])
+	}
 }
@@ -0,0 +1,273 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestPrepareReprocess(t *testing.T) {
+	up := &UnifiedProcessor{
+		pipeline: []core.Step{
+			namedStep("gatekeeper"), namedStep("similarity_search"), namedStep("transfer_check"),
+			namedStep("triage"), namedStep("response_builder"), namedStep("action_executor"), namedStep("indexer"),
+		},
+		similarity: processor.NewSimilarityFinder(&config.Config{}, nil, nil),
+	}
+
+	up.PrepareReprocess()
+
+	want := []string{"gatekeeper", "similarity_search", "triage", "action_executor"}
+	if got := stepNames(up.pipeline); !slices.Equal(got, want) {
+		t.Errorf("pipeline = %v, want %v", got, want)
+	}
+}
+
+func TestReevaluateLabeled_Skips(t *testing.T) {
+	opened := time.Now().Add(-time.Hour)
+	issue := func(labels ...string) *models.Issue {
+		return &models.Issue{Org: "org", Repo: "app", Number: 1, State: "open", Labels: labels, CreatedAt: opened, UpdatedAt: time.Now()}
+	}
+	atOpening := issue("bug")
+	atOpening.UpdatedAt = opened.Add(2 * time.Second)
+
+	tests := []struct {
+		name    string
+		disable bool
+		issue   *models.Issue
+		want    string
+	}{
+		{"transfer_on_label off", true, issue("bug"), "transfer_on_label disabled"},
+		{"opted out", false, issue("bug", "no-bot"), "no-bot label present"},
+		{"pending transfer", false, issue(pending.LabelPendingTransfer), "transfer already pending"},
+		{"label applied at opening", false, atOpening, "label applied when the issue was opened"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "app", Enabled: true}}}
+			cfg.Defaults.TransferOnLabel = !tt.disable
+			cfg.Defaults.NoBot.Label = "no-bot"
+
+			result, err := (&UnifiedProcessor{cfg: cfg}).ReevaluateLabeled(context.Background(), tt.issue, "bug")
+			if err != nil {
+				t.Fatalf("ReevaluateLabeled() error = %v", err)
+			}
+			if !result.Skipped || result.SkipReason != tt.want {
+				t.Errorf("skipped = %v, reason = %q, want %q", result.Skipped, result.SkipReason, tt.want)
+			}
+		})
+	}
+}
+
+// fakeIndex records the index writes made while handling an event
+type fakeIndex struct {
+	indexed  []string
+	deleted  []string
+	indexErr error
+}
+
+func (f *fakeIndex) IndexSingleIssue(_ context.Context, issue *models.Issue) error {
+	f.indexed = append(f.indexed, fmt.Sprintf("%s#%d", issue.FullRepo(), issue.Number))
+	return f.indexErr
+}
+
+func (f *fakeIndex) DeleteIssue(_ context.Context, org, repo string, number int) error {
+	f.deleted = append(f.deleted, fmt.Sprintf("%s/%s#%d", org, repo, number))
+	return nil
+}
+
+func (f *fakeIndex) Close() error { return nil }
+
+func TestHandleTransferred(t *testing.T) {
+	issue := &models.Issue{Org: "org", Repo: "app", Number: 3}
+	moved := &models.Issue{Org: "org", Repo: "docs", Number: 12}
+
+	tests := []struct {
+		name        string
+		moved       *models.Issue
+		enabled     bool
+		indexErr    error
+		wantIndexed []string
+		wantErrors  int
+	}{
+		{"new location unknown", nil, true, nil, nil, 0},
+		{"target not managed", moved, false, nil, nil, 0},
+		{"target enabled", moved, true, nil, []string{"org/docs#12"}, 0},
+		{"reindex fails", moved, true, errors.New("qdrant down"), []string{"org/docs#12"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Repositories: []config.RepositoryConfig{
+				{Org: "org", Repo: "app", Enabled: true},
+				{Org: "org", Repo: "docs", Enabled: tt.enabled},
+			}}
+			index := &fakeIndex{indexErr: tt.indexErr}
+			up := &UnifiedProcessor{cfg: cfg, indexer: index}
+
+			result, err := up.handleTransferred(context.Background(), issue, tt.moved)
+			if err != nil {
+				t.Fatalf("handleTransferred() error = %v", err)
+			}
+			if want := []string{"org/app#3"}; !slices.Equal(index.deleted, want) {
+				t.Errorf("deleted = %v, want %v", index.deleted, want)
+			}
+			if !slices.Equal(index.indexed, tt.wantIndexed) {
+				t.Errorf("indexed = %v, want %v", index.indexed, tt.wantIndexed)
+			}
+			if !result.Indexed || len(result.Errors) != tt.wantErrors {
+				t.Errorf("result = %+v, want indexed with %d errors", result, tt.wantErrors)
+			}
+		})
+	}
+}
+
+// triageStep stands in for the triage step, optionally reporting a failure
+type triageStep struct{ fail bool }
+
+func (s triageStep) Name() string { return "triage" }
+func (s triageStep) Run(ctx *core.Context) error {
+	ctx.TriageFailed = s.fail
+	return nil
+}
+
+func TestProcessIssue_HoldingLabel(t *testing.T) {
+	const (
+		add    = "POST /repos/org/app/issues/5/labels"
+		remove = "DELETE /repos/org/app/issues/5/labels/needs-triage"
+	)
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		repoEnabled bool
+		dryRun      bool
+		triageFails bool
+		want        []string
+	}{
+		{"disabled", false, true, false, false, nil},
+		{"repo not enabled", true, false, false, false, nil},
+		{"dry run", true, true, true, false, nil},
+		{"triage succeeds", true, true, false, false, []string{add, remove}},
+		{"triage fails", true, true, false, true, []string{add}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "app", Enabled: tt.repoEnabled}}}
+			cfg.Triage.NeedsTriage = config.NeedsTriageConfig{Enabled: tt.enabled, Label: "needs-triage"}
+
+			api := &githubAPI{}
+			gh, err := github.NewClientWithTransport("test", api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			up := &UnifiedProcessor{
+				cfg:      cfg,
+				gh:       gh,
+				dryRun:   tt.dryRun,
+				execute:  true,
+				pipeline: []core.Step{triageStep{fail: tt.triageFails}},
+			}
+
+			if _, err := up.ProcessIssue(context.Background(), &models.Issue{Org: "org", Repo: "app", Number: 5}); err != nil {
+				t.Fatalf("ProcessIssue() error = %v", err)
+			}
+			if !slices.Equal(api.other, tt.want) {
+				t.Errorf("requests = %v, want %v", api.other, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessIssue_ClaimAfterGatekeeper(t *testing.T) {
+	const claim = "POST /repos/org/app/issues/5/comments"
+
+	tests := []struct {
+		name        string
+		repoEnabled bool
+		labels      []string
+		skipAll     bool
+		wantClaim   bool
+	}{
+		{"repo not enabled", false, nil, false, false},
+		{"ignored", true, []string{pending.LabelIgnored}, false, false},
+		{"no-bot skip all", true, []string{"no-bot"}, true, false},
+		{"no-bot", true, []string{"no-bot"}, false, false},
+		{"admitted", true, nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "app", Enabled: tt.repoEnabled}}}
+			cfg.Defaults.ClaimWindowMinutes = 10
+			cfg.Defaults.NoBot = config.NoBotConfig{Label: "no-bot", SkipAll: tt.skipAll}
+
+			api := &githubAPI{}
+			gh, err := github.NewClientWithTransport("test", api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			up := &UnifiedProcessor{
+				cfg:      cfg,
+				gh:       gh,
+				execute:  true,
+				runID:    "run-1",
+				pipeline: []core.Step{steps.NewRepoGatekeeper(gh), triageStep{}},
+			}
+
+			issue := &models.Issue{Org: "org", Repo: "app", Number: 5, Labels: tt.labels}
+			if _, err := up.ProcessIssue(context.Background(), issue); err != nil {
+				t.Fatalf("ProcessIssue() error = %v", err)
+			}
+			if got := slices.Contains(api.other, claim); got != tt.wantClaim {
+				t.Errorf("requests = %v, want claim comment = %v", api.other, tt.wantClaim)
+			}
+		})
+	}
+}
+
+func TestWriteGitHubOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github_output")
+	if err := os.WriteFile(path, []byte("earlier=step\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	result := &core.UnifiedResult{
+		CommentPosted: true,
+		Errors:        []string{"triage: llm timeout", "indexer: qdrant unavailable"},
+	}
+	if err := WriteGitHubOutput(os.Getenv("GITHUB_OUTPUT"), result); err != nil {
+		t.Fatalf("WriteGitHubOutput() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "earlier=step\n" +
+		"skipped=false\n" +
+		"comment_posted=true\n" +
+		"transferred=false\n" +
+		"transfer_scheduled=false\n" +
+		"error_count=2\n" +
+		"errors<<SIMILI_EOF\ntriage: llm timeout\nindexer: qdrant unavailable\nSIMILI_EOF\n"
+	if string(got) != want {
+		t.Errorf("GITHUB_OUTPUT = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,269 @@
+package processor
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// DuplicatePair is a labeled duplicate: Issue duplicates Original
+type DuplicatePair struct {
+	Issue    int
+	Original int
+}
+
+// EvalOptions controls an evaluation run
+type EvalOptions struct {
+	Concurrency  int     // Issues searched in parallel
+	MinThreshold float64 // Lowest threshold scored; neighbors below it are ignored
+}
+
+// EvalPoint is the quality of duplicate detection at one threshold
+type EvalPoint struct {
+	Threshold      float64 `json:"threshold"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+}
+
+// EvalReport scores similarity thresholds against labeled duplicates
+type EvalReport struct {
+	Repo   string      `json:"repo"`
+	Issues int         `json:"issues"`
+	Pairs  int         `json:"pairs"`
+	Failed int         `json:"failed"` // Issues whose search failed
+	Points []EvalPoint `json:"points"`
+	Best   EvalPoint   `json:"best"` // Highest F1
+}
+
+// pairKey is an unordered issue pair
+type pairKey struct{ a, b int }
+
+func newPairKey(x, y int) pairKey {
+	if x > y {
+		x, y = y, x
+	}
+	return pairKey{x, y}
+}
+
+// LoadDuplicatePairs reads "issue,original" rows of issue numbers from CSV.
+// A header row is skipped.
+func LoadDuplicatePairs(r io.Reader) ([]DuplicatePair, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var pairs []DuplicatePair
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read duplicates: %w", err)
+		}
+
+		issue, errIssue := strconv.Atoi(strings.TrimPrefix(record[0], "#"))
+		original, errOriginal := strconv.Atoi(strings.TrimPrefix(record[1], "#"))
+		if errIssue != nil || errOriginal != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: expected two issue numbers, got %q", line, record)
+		}
+		pairs = append(pairs, DuplicatePair{Issue: issue, Original: original})
+	}
+
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no duplicate pairs found")
+	}
+	return pairs, nil
+}
+
+// Evaluate searches the nearest neighbors of every indexed issue in a
+// repository and scores each threshold from opts.MinThreshold up against
+// the labeled duplicate pairs. Searches run opts.Concurrency at a time,
+// started no faster than the configured embedding request rate.
+func (s *Syncer) Evaluate(ctx context.Context, fullRepo string, pairs []DuplicatePair, opts EvalOptions) (*EvalReport, error) {
+	org, repo, err := github.ParseRepo(fullRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := s.gh.ListAllIssues(ctx, org, repo, "all", 100, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	collection := vectordb.CollectionName(&s.cfg.Qdrant, org, repo)
+	stored, err := s.vdb.ScrollIssues(ctx, collection, org, repo)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(map[int]bool, len(stored))
+	for _, si := range stored {
+		indexed[si.Issue.Number] = true
+	}
+
+	var issues []*models.Issue
+	for _, issue := range live {
+		if indexed[issue.Number] {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no indexed issues in %s", fullRepo)
+	}
+
+	scores, failed := s.neighborScores(ctx, issues, opts)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	truth := make(map[pairKey]bool, len(pairs))
+	for _, p := range pairs {
+		truth[newPairKey(p.Issue, p.Original)] = true
+	}
+
+	points := sweepThresholds(scores, truth, opts.MinThreshold)
+	report := &EvalReport{
+		Repo:   fullRepo,
+		Issues: len(issues),
+		Pairs:  len(truth),
+		Failed: failed,
+		Points: points,
+	}
+	for _, p := range points {
+		if p.F1 > report.Best.F1 {
+			report.Best = p
+		}
+	}
+
+	return report, nil
+}
+
+// neighborSearch returns the neighbors of issue scoring at least the
+// evaluation's minimum threshold
+type neighborSearch func(ctx context.Context, issue *models.Issue) ([]vectordb.SearchResult, error)
+
+// neighborScores returns the best score seen for every same-repo pair of
+// issues scoring at least opts.MinThreshold, and how many searches failed
+func (s *Syncer) neighborScores(ctx context.Context, issues []*models.Issue, opts EvalOptions) (map[pairKey]float64, int) {
+	finder := NewSimilarityFinder(s.cfg, s.embedder, s.vdb)
+	search := func(ctx context.Context, issue *models.Issue) ([]vectordb.SearchResult, error) {
+		return finder.FindSimilarAbove(ctx, issue, opts.MinThreshold)
+	}
+	return collectNeighborScores(ctx, issues, opts.Concurrency, s.cfg.RateLimits.EmbeddingRPS, search)
+}
+
+// collectNeighborScores runs search for every issue on up to concurrency
+// workers, starting searches no faster than rps per second (0 means
+// unthrottled), and keeps each pair's best same-repo score
+func collectNeighborScores(ctx context.Context, issues []*models.Issue, concurrency, rps int, search neighborSearch) (map[pairKey]float64, int) {
+	workers := min(max(concurrency, 1), len(issues))
+
+	// Each search spends one embedding request, however many workers run
+	var throttle <-chan time.Time
+	if rps > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rps))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var (
+		mu     sync.Mutex
+		scores = map[pairKey]float64{}
+		failed int
+		wg     sync.WaitGroup
+	)
+	queue := make(chan *models.Issue)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for issue := range queue {
+				results, err := search(ctx, issue)
+				mu.Lock()
+				if err != nil {
+					logging.Warn("search failed", "repo", issue.FullRepo(), "issue", issue.Number, "error", err)
+					failed++
+				}
+				for _, r := range results {
+					if r.Issue.Org != issue.Org || r.Issue.Repo != issue.Repo {
+						continue
+					}
+					key := newPairKey(issue.Number, r.Issue.Number)
+					scores[key] = math.Max(scores[key], r.Score)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, issue := range issues {
+		if ctx.Err() != nil {
+			break
+		}
+		if throttle != nil {
+			<-throttle
+		}
+		queue <- issue
+		if (i+1)%50 == 0 {
+			logging.Info("evaluation progress", "evaluated", i+1, "total", len(issues))
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return scores, failed
+}
+
+// sweepThresholds scores every threshold from minThreshold to 0.99 in steps
+// of 0.01, predicting a duplicate for each pair scoring at least the threshold
+func sweepThresholds(scores map[pairKey]float64, truth map[pairKey]bool, minThreshold float64) []EvalPoint {
+	var points []EvalPoint
+	// Round rather than Ceil: 0.7*100 is 70.00000000000001 in floating point
+	for step := int(math.Round(minThreshold * 100)); step <= 99; step++ {
+		threshold := float64(step) / 100
+		p := EvalPoint{Threshold: threshold}
+		for key, score := range scores {
+			if score < threshold {
+				continue
+			}
+			if truth[key] {
+				p.TruePositives++
+			} else {
+				p.FalsePositives++
+			}
+		}
+		p.FalseNegatives = len(truth) - p.TruePositives
+
+		if predicted := p.TruePositives + p.FalsePositives; predicted > 0 {
+			p.Precision = float64(p.TruePositives) / float64(predicted)
+		}
+		if len(truth) > 0 {
+			p.Recall = float64(p.TruePositives) / float64(len(truth))
+		}
+		if p.Precision+p.Recall > 0 {
+			p.F1 = 2 * p.Precision * p.Recall / (p.Precision + p.Recall)
+		}
+		points = append(points, p)
+	}
+	return points
+}
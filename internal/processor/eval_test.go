@@ -0,0 +1,133 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestLoadDuplicatePairs(t *testing.T) {
+	pairs, err := LoadDuplicatePairs(strings.NewReader("issue,original\n12,3\n#40, #7\n"))
+	if err != nil {
+		t.Fatalf("LoadDuplicatePairs() error = %v", err)
+	}
+	if len(pairs) != 2 || pairs[0] != (DuplicatePair{12, 3}) || pairs[1] != (DuplicatePair{40, 7}) {
+		t.Errorf("LoadDuplicatePairs() = %v, want [{12 3} {40 7}]", pairs)
+	}
+
+	if _, err := LoadDuplicatePairs(strings.NewReader("12,3\nfoo,4\n")); err == nil {
+		t.Error("LoadDuplicatePairs() accepted a non-numeric row after the header")
+	}
+	if _, err := LoadDuplicatePairs(strings.NewReader("issue,original\n")); err == nil {
+		t.Error("LoadDuplicatePairs() accepted a file with no pairs")
+	}
+}
+
+func TestSweepThresholds(t *testing.T) {
+	scores := map[pairKey]float64{
+		newPairKey(2, 1): 0.95, // labeled duplicate
+		newPairKey(3, 1): 0.90, // not a duplicate
+		newPairKey(5, 4): 0.85, // labeled duplicate
+	}
+	truth := map[pairKey]bool{
+		newPairKey(1, 2): true,
+		newPairKey(4, 5): true,
+		newPairKey(6, 7): true, // never found
+	}
+
+	// 0.7*100 is just above 70 in floating point; the sweep must still start at 0.70
+	if points := sweepThresholds(scores, truth, 0.70); points[0].Threshold != 0.70 {
+		t.Errorf("sweepThresholds(0.70) starts at %v", points[0].Threshold)
+	}
+
+	points := sweepThresholds(scores, truth, 0.80)
+	if len(points) != 20 || points[0].Threshold != 0.80 || points[19].Threshold != 0.99 {
+		t.Fatalf("sweepThresholds() returned %d points from %v", len(points), points[0].Threshold)
+	}
+
+	byThreshold := map[float64]EvalPoint{}
+	for _, p := range points {
+		byThreshold[p.Threshold] = p
+	}
+
+	low := byThreshold[0.85]
+	if low.TruePositives != 2 || low.FalsePositives != 1 || low.FalseNegatives != 1 {
+		t.Errorf("at 0.85 got %+v, want 2 TP, 1 FP, 1 FN", low)
+	}
+	high := byThreshold[0.91]
+	if high.TruePositives != 1 || high.FalsePositives != 0 || high.Precision != 1 {
+		t.Errorf("at 0.91 got %+v, want 1 TP, 0 FP, precision 1", high)
+	}
+}
+
+func TestCollectNeighborScores(t *testing.T) {
+	var issues []*models.Issue
+	for n := 1; n <= 12; n++ {
+		issues = append(issues, &models.Issue{Org: "octo", Repo: "app", Number: n})
+	}
+
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+		searched       = map[int]bool{}
+	)
+	search := func(ctx context.Context, issue *models.Issue) ([]vectordb.SearchResult, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		searched[issue.Number] = true
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		switch issue.Number {
+		case 1:
+			return []vectordb.SearchResult{
+				{Issue: models.Issue{Org: "octo", Repo: "app", Number: 2}, Score: 0.80},
+				{Issue: models.Issue{Org: "octo", Repo: "lib", Number: 2}, Score: 0.99}, // other repo
+			}, nil
+		case 2:
+			return []vectordb.SearchResult{{Issue: models.Issue{Org: "octo", Repo: "app", Number: 1}, Score: 0.90}}, nil
+		case 3, 4:
+			return nil, errors.New("embedding failed")
+		}
+		return nil, nil
+	}
+
+	scores, failed := collectNeighborScores(context.Background(), issues, 4, 0, search)
+	if len(searched) != len(issues) {
+		t.Errorf("searched %d issues, want %d", len(searched), len(issues))
+	}
+	if peak > 4 || peak < 2 {
+		t.Errorf("peak concurrency = %d, want 2-4", peak)
+	}
+	if failed != 2 {
+		t.Errorf("failed = %d, want 2", failed)
+	}
+	if len(scores) != 1 || scores[newPairKey(1, 2)] != 0.90 {
+		t.Errorf("scores = %v, want the best same-repo score 0.90 for 1-2", scores)
+	}
+}
+
+func TestCollectNeighborScores_ThrottlesOneWorker(t *testing.T) {
+	issues := []*models.Issue{{Number: 1}, {Number: 2}, {Number: 3}}
+	search := func(ctx context.Context, issue *models.Issue) ([]vectordb.SearchResult, error) {
+		return nil, nil
+	}
+
+	start := time.Now()
+	collectNeighborScores(context.Background(), issues, 1, 50, search)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("3 searches at 50/s took %s, want at least 50ms", elapsed)
+	}
+}
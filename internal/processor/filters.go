@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// SimilarityFilter decides whether a search result is kept for a query issue.
+// Filters run after the vector search, in order, and a result must pass all.
+type SimilarityFilter interface {
+	Keep(query *models.Issue, result vectordb.SearchResult) bool
+}
+
+// SimilarityFilterFunc adapts a plain function to SimilarityFilter
+type SimilarityFilterFunc func(query *models.Issue, result vectordb.SearchResult) bool
+
+// Keep calls f
+func (f SimilarityFilterFunc) Keep(query *models.Issue, result vectordb.SearchResult) bool {
+	return f(query, result)
+}
+
+// SameRepoOnly keeps only matches from the query issue's repository
+func SameRepoOnly() SimilarityFilter {
+	return SimilarityFilterFunc(func(query *models.Issue, result vectordb.SearchResult) bool {
+		return result.Issue.Org == query.Org && result.Issue.Repo == query.Repo
+	})
+}
+
+// ExcludeLabels drops matches carrying any of the labels (case-insensitive)
+func ExcludeLabels(labels []string) SimilarityFilter {
+	return SimilarityFilterFunc(func(_ *models.Issue, result vectordb.SearchResult) bool {
+		for _, label := range labels {
+			if result.Issue.HasLabel(label) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// MinAge drops matches created within window of the query issue, such as
+// bulk imports and double-submits
+func MinAge(window time.Duration) SimilarityFilter {
+	return SimilarityFilterFunc(func(query *models.Issue, result vectordb.SearchResult) bool {
+		if result.Issue.CreatedAt.IsZero() {
+			return true
+		}
+		reference := query.CreatedAt
+		if reference.IsZero() {
+			reference = time.Now()
+		}
+		delta := reference.Sub(result.Issue.CreatedAt)
+		if delta < 0 {
+			delta = -delta
+		}
+		return delta >= window
+	})
+}
+
+// CreatedBefore keeps only matches created before the query issue, so an
+// issue re-triaged later is never treated as a duplicate of a newer report.
+// Matches or queries without a creation time are kept.
+func CreatedBefore() SimilarityFilter {
+	return SimilarityFilterFunc(func(query *models.Issue, result vectordb.SearchResult) bool {
+		if query.CreatedAt.IsZero() || result.Issue.CreatedAt.IsZero() {
+			return true
+		}
+		return result.Issue.CreatedAt.Before(query.CreatedAt)
+	})
+}
+
+// DefaultFilters builds the built-in filter chain from configuration
+func DefaultFilters(cfg *config.Config) []SimilarityFilter {
+	var filters []SimilarityFilter
+	if cfg.Defaults.SimilarityFilters.SameRepoOnly {
+		filters = append(filters, SameRepoOnly())
+	}
+	if len(cfg.Defaults.SimilarityFilters.ExcludeLabels) > 0 {
+		filters = append(filters, ExcludeLabels(cfg.Defaults.SimilarityFilters.ExcludeLabels))
+	}
+	if cfg.Defaults.MinMatchAgeMinutes > 0 {
+		filters = append(filters, MinAge(time.Duration(cfg.Defaults.MinMatchAgeMinutes)*time.Minute))
+	}
+	return filters
+}
+
+// applyFilters keeps the results that pass every filter
+func applyFilters(results []vectordb.SearchResult, query *models.Issue, filters []SimilarityFilter) []vectordb.SearchResult {
+	if len(filters) == 0 {
+		return results
+	}
+
+	kept := make([]vectordb.SearchResult, 0, len(results))
+	for _, r := range results {
+		keep := true
+		for _, f := range filters {
+			if !f.Keep(query, r) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestApplyFilters(t *testing.T) {
+	now := time.Now()
+	query := &models.Issue{Org: "org", Repo: "app", Number: 10, CreatedAt: now}
+	results := []vectordb.SearchResult{
+		{Issue: models.Issue{Org: "org", Repo: "app", Number: 1, CreatedAt: now.Add(-48 * time.Hour)}},
+		{Issue: models.Issue{Org: "org", Repo: "other", Number: 2, CreatedAt: now.Add(-48 * time.Hour)}},
+		{Issue: models.Issue{Org: "org", Repo: "app", Number: 3, Labels: []string{"Invalid"}, CreatedAt: now.Add(-48 * time.Hour)}},
+		{Issue: models.Issue{Org: "org", Repo: "app", Number: 4, CreatedAt: now.Add(-time.Minute)}},
+	}
+
+	cfg := &config.Config{}
+	cfg.Defaults.MinMatchAgeMinutes = 10
+	cfg.Defaults.SimilarityFilters = config.SimilarityFiltersConfig{SameRepoOnly: true, ExcludeLabels: []string{"invalid"}}
+
+	got := applyFilters(results, query, DefaultFilters(cfg))
+	if len(got) != 1 || got[0].Issue.Number != 1 {
+		t.Fatalf("applyFilters() = %+v, want only #1", got)
+	}
+
+	even := SimilarityFilterFunc(func(_ *models.Issue, r vectordb.SearchResult) bool { return r.Issue.Number%2 == 0 })
+	got = applyFilters(results, query, []SimilarityFilter{even})
+	if len(got) != 2 || got[0].Issue.Number != 2 || got[1].Issue.Number != 4 {
+		t.Errorf("applyFilters(custom) = %+v, want #2 and #4", got)
+	}
+}
+
+func TestCreatedBefore(t *testing.T) {
+	now := time.Now()
+	query := &models.Issue{Number: 10, CreatedAt: now}
+	filter := CreatedBefore()
+
+	tests := []struct {
+		name    string
+		created time.Time
+		want    bool
+	}{
+		{"older original", now.Add(-time.Hour), true},
+		{"newer report", now.Add(time.Hour), false},
+		{"same instant", now, false},
+		{"unknown creation time", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		result := vectordb.SearchResult{Issue: models.Issue{Number: 1, CreatedAt: tt.created}}
+		if got := filter.Keep(query, result); got != tt.want {
+			t.Errorf("CreatedBefore(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !filter.Keep(&models.Issue{}, vectordb.SearchResult{Issue: models.Issue{CreatedAt: now}}) {
+		t.Error("CreatedBefore() dropped a match for a query without a creation time")
+	}
+}
@@ -3,30 +3,47 @@ package processor
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
+// minBulkBudget is the GitHub request budget required before a bulk fetch
+const minBulkBudget = 100
+
 // Indexer handles bulk indexing of issues
 type Indexer struct {
-	cfg      *config.Config
-	gh       *github.Client
-	embedder *embedding.FallbackProvider
-	vdb      *vectordb.Client
-	dryRun   bool
+	cfg       *config.Config
+	gh        *github.Client
+	embedder  *embedding.FallbackProvider
+	vdb       *vectordb.Client
+	dryRun    bool
+	sinceNum  int // Only index issues numbered above this
+	maxIssues int // Stop fetching after this many issues; 0 means all
+
+	discussions bool // Also index the repo's GitHub Discussions
+
+	statePath string // Progress file; empty disables tracking
+	resume    bool   // Continue from the progress recorded in statePath
 }
 
+// indexBatchAttempts is how many times a failed batch is tried before it counts as errors
+const indexBatchAttempts = 2
+
 // NewIndexer creates a new bulk indexer
 func NewIndexer(cfg *config.Config, dryRun bool) (*Indexer, error) {
 	gh, err := github.NewClient()
 	if err != nil {
 		return nil, err
 	}
+	gh.SetRequestsPerSecond(cfg.RateLimits.GitHubRPS)
 
 	embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
 	if err != nil {
@@ -37,6 +54,7 @@ func NewIndexer(cfg *config.Config, dryRun bool) (*Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
+	vdb.SetMultiVector(&cfg.Embedding.MultiVector)
 
 	return &Indexer{
 		cfg:      cfg,
@@ -47,6 +65,28 @@ func NewIndexer(cfg *config.Config, dryRun bool) (*Indexer, error) {
 	}, nil
 }
 
+// SetSinceNumber restricts indexing to issues with a number greater than n
+func (idx *Indexer) SetSinceNumber(n int) {
+	idx.sinceNum = n
+}
+
+// SetIncludeDiscussions also indexes discussions, tagged with kind "discussion"
+func (idx *Indexer) SetIncludeDiscussions(include bool) {
+	idx.discussions = include
+}
+
+// SetMaxIssues caps how many issues are fetched and indexed
+func (idx *Indexer) SetMaxIssues(n int) {
+	idx.maxIssues = n
+}
+
+// SetResume records progress to statePath after each batch and, when resume
+// is set, skips issues already indexed by an interrupted run
+func (idx *Indexer) SetResume(statePath string, resume bool) {
+	idx.statePath = statePath
+	idx.resume = resume
+}
+
 // Close releases resources
 func (idx *Indexer) Close() error {
 	idx.embedder.Close()
@@ -64,23 +104,75 @@ func (idx *Indexer) IndexRepo(ctx context.Context, fullRepo string, batchSize in
 	}
 
 	// Ensure collection exists
-	collection := vectordb.CollectionName(org)
+	collection := vectordb.CollectionName(&idx.cfg.Qdrant, org, repo)
 	if !idx.dryRun {
 		if err := idx.vdb.EnsureCollection(ctx, collection); err != nil {
 			return nil, fmt.Errorf("failed to ensure collection: %w", err)
 		}
 	}
 
-	// Fetch all issues
+	// Don't start a full fetch that would run out of API budget halfway
+	if err := idx.gh.EnsureBudget(ctx, minBulkBudget); err != nil {
+		return nil, err
+	}
+
+	var state indexState
+	if idx.statePath != "" && !idx.dryRun {
+		if state, err = loadIndexState(idx.statePath); err != nil {
+			return nil, err
+		}
+	}
+
+	floor := idx.sinceNum
+	var progress indexProgress
+	if idx.resume {
+		if saved, ok := state[fullRepo]; ok && saved.LastNumber > floor {
+			progress = saved
+			fmt.Printf("Resuming %s after #%d (recorded %s)\n", fullRepo, progress.LastNumber, progress.UpdatedAt.Format(time.RFC3339))
+			floor = progress.LastNumber
+		} else {
+			fmt.Printf("No saved progress for %s, starting from the beginning\n", fullRepo)
+		}
+	}
+
+	// Fetch issues, past the saved cursor when resuming
 	fmt.Printf("Fetching issues from %s...\n", fullRepo)
-	issues, err := idx.gh.ListAllIssues(ctx, org, repo, "all", batchSize)
+	issues, cursors, err := idx.gh.ListIssuesGraphQLAfter(ctx, org, repo, "all", progress.Cursor, idx.maxIssues)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		logging.Warn("GraphQL issue listing failed, falling back to REST", "repo", fullRepo, "error", err)
+		cursors = nil
+		issues, err = idx.gh.ListAllIssues(ctx, org, repo, "all", batchSize, idx.maxIssues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
 	}
-	stats.TotalIssues = len(issues)
 	fmt.Printf("Found %d issues\n", len(issues))
 
+	// listed keeps the listing order that cursors refer to
+	listed := slices.Clone(issues)
+
+	// Discussions share the issue number space, so they fit the same watermark
+	if idx.discussions {
+		discussions, err := idx.gh.ListDiscussions(ctx, org, repo, idx.maxIssues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch discussions: %w", err)
+		}
+		fmt.Printf("Found %d discussions\n", len(discussions))
+		issues = append(issues, discussions...)
+	}
+	stats.TotalIssues = len(issues)
+
+	// Index oldest first so progress is a single "last number" watermark
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+
+	if floor > 0 {
+		issues = filterAboveNumber(issues, floor)
+		stats.Skipped = stats.TotalIssues - len(issues)
+		fmt.Printf("Skipped %d issues numbered #%d or below\n", stats.Skipped, floor)
+	}
+
 	// Process in batches
+	advancing := true // Progress only moves past contiguous successful batches
 	for i := 0; i < len(issues); i += batchSize {
 		end := i + batchSize
 		if end > len(issues) {
@@ -88,26 +180,102 @@ func (idx *Indexer) IndexRepo(ctx context.Context, fullRepo string, batchSize in
 		}
 		batch := issues[i:end]
 
-		if err := idx.indexBatch(ctx, collection, batch); err != nil {
-			fmt.Printf("Warning: batch %d-%d failed: %v\n", i, end, err)
+		if err := idx.indexBatchWithRetry(ctx, collection, batch); err != nil {
+			logging.Warn("batch failed", "repo", fullRepo, "from", i, "to", end, "error", err)
 			stats.Errors += len(batch)
+			advancing = false
 			continue
 		}
 
 		stats.Indexed += len(batch)
-		fmt.Printf("Indexed %d/%d issues\n", stats.Indexed, stats.TotalIssues)
+		fmt.Printf("Indexed %d/%d issues\n", stats.Indexed, len(issues))
+
+		if state != nil && advancing {
+			last := batch[len(batch)-1].Number
+			progress = indexProgress{
+				LastNumber: last,
+				Cursor:     resumeCursor(listed, cursors, last, progress.Cursor),
+				UpdatedAt:  time.Now(),
+			}
+			state[fullRepo] = progress
+			if err := state.save(idx.statePath); err != nil {
+				logging.Warnf("%v", err)
+			}
+		}
+	}
+
+	// A clean run leaves nothing to resume
+	if state != nil && stats.Errors == 0 {
+		delete(state, fullRepo)
+		if err := state.save(idx.statePath); err != nil {
+			logging.Warnf("%v", err)
+		}
 	}
 
 	stats.DurationMs = int(time.Since(start).Milliseconds())
 	return stats, nil
 }
 
+// resumeCursor returns the cursor a resumed listing can start after: that of
+// the last issue in listing order up to which every issue is numbered at or
+// below watermark, and so already indexed. Listing order is creation order,
+// which issues moved in from other repos can break, so the cursor may trail
+// the watermark; issues past it that are already indexed are skipped by number.
+// prev is returned when no listed issue qualifies.
+func resumeCursor(listed []*models.Issue, cursors []string, watermark int, prev string) string {
+	if len(cursors) != len(listed) {
+		return prev
+	}
+	cursor := prev
+	for i, issue := range listed {
+		if issue.Number > watermark {
+			break
+		}
+		cursor = cursors[i]
+	}
+	return cursor
+}
+
+// filterAboveNumber keeps issues numbered above n
+func filterAboveNumber(issues []*models.Issue, n int) []*models.Issue {
+	filtered := issues[:0]
+	for _, issue := range issues {
+		if issue.Number > n {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// indexBatchWithRetry indexes a batch, retrying transient failures
+func (idx *Indexer) indexBatchWithRetry(ctx context.Context, collection string, issues []*models.Issue) error {
+	var err error
+	for attempt := 1; attempt <= indexBatchAttempts; attempt++ {
+		if err = idx.indexBatch(ctx, collection, issues); err == nil {
+			return nil
+		}
+		if attempt < indexBatchAttempts {
+			logging.Warn("batch failed, retrying", "collection", collection, "attempt", attempt, "max_attempts", indexBatchAttempts, "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * 2 * time.Second):
+			}
+		}
+	}
+	return err
+}
+
 // indexBatch processes and indexes a batch of issues
 func (idx *Indexer) indexBatch(ctx context.Context, collection string, issues []*models.Issue) error {
+	if idx.vdb.MultiVector() {
+		return idx.indexViews(ctx, collection, issues)
+	}
+
 	// Prepare texts for embedding
 	texts := make([]string, len(issues))
 	for i, issue := range issues {
-		texts[i] = embedding.PrepareIssueText(issue.Title, issue.Body)
+		texts[i] = embedding.PrepareIssueTextWithConfig(&idx.cfg.Embedding, issue)
 	}
 
 	// Generate embeddings
@@ -128,11 +296,44 @@ func (idx *Indexer) indexBatch(ctx context.Context, collection string, issues []
 	return nil
 }
 
+// indexViews embeds titles and bodies separately in one batch and stores
+// them as named vectors
+func (idx *Indexer) indexViews(ctx context.Context, collection string, issues []*models.Issue) error {
+	texts := make([]string, 0, 2*len(issues))
+	for _, issue := range issues {
+		title, body := embedding.PrepareViewTexts(&idx.cfg.Embedding, issue)
+		texts = append(texts, title, body)
+	}
+
+	vectors, err := idx.embedder.EmbedBatch(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to generate embeddings: %w", err)
+	}
+
+	if idx.dryRun {
+		return nil
+	}
+
+	views := make([]vectordb.Views, len(issues))
+	for i := range issues {
+		views[i] = vectordb.Views{Title: vectors[2*i], Body: vectors[2*i+1]}
+	}
+	if err := idx.vdb.UpsertViewsBatch(ctx, collection, issues, views); err != nil {
+		return fmt.Errorf("failed to upsert batch: %w", err)
+	}
+
+	return nil
+}
+
 // IndexSingleIssue indexes a single issue
 func (idx *Indexer) IndexSingleIssue(ctx context.Context, issue *models.Issue) error {
-	collection := vectordb.CollectionName(issue.Org)
+	collection := vectordb.CollectionName(&idx.cfg.Qdrant, issue.Org, issue.Repo)
+
+	if idx.vdb.MultiVector() {
+		return idx.indexViews(ctx, collection, []*models.Issue{issue})
+	}
 
-	text := embedding.PrepareIssueText(issue.Title, issue.Body)
+	text := embedding.PrepareIssueTextWithConfig(&idx.cfg.Embedding, issue)
 	vector, err := idx.embedder.Embed(ctx, text)
 	if err != nil {
 		return fmt.Errorf("failed to generate embedding: %w", err)
@@ -155,7 +356,7 @@ func (idx *Indexer) DeleteIssue(ctx context.Context, org, repo string, number in
 		return nil
 	}
 
-	collection := vectordb.CollectionName(org)
+	collection := vectordb.CollectionName(&idx.cfg.Qdrant, org, repo)
 	id := models.IssueUUID(org, repo, number)
 	return idx.vdb.Delete(ctx, collection, id)
 }
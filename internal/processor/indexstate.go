@@ -0,0 +1,58 @@
+package processor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultIndexStatePath is where index progress is recorded for --resume
+const DefaultIndexStatePath = ".simili-index-state"
+
+// indexProgress records how far an interrupted index run got for one repo
+type indexProgress struct {
+	LastNumber int       `json:"last_number"`
+	Cursor     string    `json:"cursor,omitempty"` // Issue listing position to resume after
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// indexState maps "org/repo" to its progress
+type indexState map[string]indexProgress
+
+// loadIndexState reads the state file; a missing file is an empty state
+func loadIndexState(path string) (indexState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return indexState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index state: %w", err)
+	}
+
+	state := indexState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse index state: %w", err)
+	}
+	return state, nil
+}
+
+// save writes the state file, removing it once no repo has progress left
+func (s indexState) save(path string) error {
+	if len(s) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove index state: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal index state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write index state: %w", err)
+	}
+	return nil
+}
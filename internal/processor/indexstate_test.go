@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestIndexState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultIndexStatePath)
+
+	state, err := loadIndexState(path)
+	if err != nil {
+		t.Fatalf("loadIndexState() on missing file: %v", err)
+	}
+	if len(state) != 0 {
+		t.Fatalf("loadIndexState() on missing file = %v, want empty", state)
+	}
+
+	state["org/repo"] = indexProgress{LastNumber: 150, Cursor: "Y3Vyc29yOjE1MA==", UpdatedAt: time.Now()}
+	if err := state.save(path); err != nil {
+		t.Fatalf("save() error: %v", err)
+	}
+
+	loaded, err := loadIndexState(path)
+	if err != nil {
+		t.Fatalf("loadIndexState() error: %v", err)
+	}
+	if got := loaded["org/repo"]; got.LastNumber != 150 || got.Cursor != "Y3Vyc29yOjE1MA==" {
+		t.Errorf("progress = %+v, want #150 at the saved cursor", got)
+	}
+
+	// Clearing the last repo removes the file
+	delete(loaded, "org/repo")
+	if err := loaded.save(path); err != nil {
+		t.Fatalf("save() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("state file still exists after clearing: %v", err)
+	}
+}
+
+func TestResumeCursor(t *testing.T) {
+	// Listing (creation) order; #3 was moved in from another repo and got
+	// a high number despite its early creation date
+	listed := []*models.Issue{{Number: 1}, {Number: 2}, {Number: 9}, {Number: 4}, {Number: 5}}
+	cursors := []string{"c1", "c2", "c9", "c4", "c5"}
+
+	tests := []struct {
+		name      string
+		watermark int
+		cursors   []string
+		want      string
+	}{
+		{"nothing indexed yet", 0, cursors, "prev"},
+		{"prefix indexed", 2, cursors, "c2"},
+		{"stops at an unindexed issue", 5, cursors, "c2"},
+		{"everything indexed", 9, cursors, "c5"},
+		{"no cursors from the REST fallback", 9, nil, "prev"},
+	}
+
+	for _, tt := range tests {
+		if got := resumeCursor(listed, tt.cursors, tt.watermark, "prev"); got != tt.want {
+			t.Errorf("resumeCursor(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+var (
+	// issueURLRegex matches links to issues, pull requests and discussions
+	issueURLRegex = regexp.MustCompile(`https?://github\.com/([\w.-]+)/([\w.-]+)/(?:issues|pull|discussions)/(\d+)`)
+	// crossRefRegex matches org/repo#123 references
+	crossRefRegex = regexp.MustCompile(`\b([\w.-]+)/([\w.-]+)#(\d+)\b`)
+	// shortRefRegex matches bare #123 references, skipping HTML entities like &#123;
+	shortRefRegex = regexp.MustCompile(`(?:^|[^\w/&#])#(\d+)\b`)
+)
+
+// referencedIssues returns the issues an issue's body already links to,
+// keyed by lowercase "org/repo#number"
+func referencedIssues(issue *models.Issue) map[string]bool {
+	refs := make(map[string]bool)
+	add := func(org, repo, number string) {
+		if n, err := strconv.Atoi(number); err == nil {
+			refs[referenceKey(org, repo, n)] = true
+		}
+	}
+
+	for _, m := range issueURLRegex.FindAllStringSubmatch(issue.Body, -1) {
+		add(m[1], m[2], m[3])
+	}
+	for _, m := range crossRefRegex.FindAllStringSubmatch(issue.Body, -1) {
+		add(m[1], m[2], m[3])
+	}
+	for _, m := range shortRefRegex.FindAllStringSubmatch(issue.Body, -1) {
+		add(issue.Org, issue.Repo, m[1])
+	}
+	return refs
+}
+
+// referenceKey normalizes an issue reference for lookups
+func referenceKey(org, repo string, number int) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s#%d", org, repo, number))
+}
+
+// FilterReferenced drops matches the issue's author already linked, since
+// echoing them back in the similar-issues table adds nothing. Duplicate
+// detection still sees them: a linked issue is often the original.
+func FilterReferenced(results []vectordb.SearchResult, issue *models.Issue) []vectordb.SearchResult {
+	refs := referencedIssues(issue)
+	if len(refs) == 0 {
+		return results
+	}
+
+	filtered := make([]vectordb.SearchResult, 0, len(results))
+	for _, r := range results {
+		if refs[referenceKey(r.Issue.Org, r.Issue.Repo, r.Issue.Number)] {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestFilterReferenced(t *testing.T) {
+	issue := &models.Issue{
+		Org:  "org",
+		Repo: "app",
+		Body: "Looks like #12 again, see also Org/Lib#7 and https://github.com/org/docs/issues/3.\nNot a ref: &#40; or abc#99",
+	}
+
+	result := func(repo string, number int) vectordb.SearchResult {
+		return vectordb.SearchResult{Issue: models.Issue{Org: "org", Repo: repo, Number: number}}
+	}
+	results := []vectordb.SearchResult{
+		result("app", 12),
+		result("lib", 7),
+		result("docs", 3),
+		result("app", 40),
+		result("app", 99),
+		result("app", 13),
+	}
+
+	got := FilterReferenced(results, issue)
+
+	want := []int{40, 99, 13}
+	if len(got) != len(want) {
+		t.Fatalf("FilterReferenced() kept %d results, want %d: %+v", len(got), len(want), got)
+	}
+	for i, n := range want {
+		if got[i].Issue.Number != n {
+			t.Errorf("got[%d] = #%d, want #%d", i, got[i].Issue.Number, n)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
@@ -14,6 +15,8 @@ type Searcher struct {
 	cfg      *config.Config
 	embedder *embedding.FallbackProvider
 	vdb      *vectordb.Client
+
+	visibility VisibilityChecker // nil disables private match handling
 }
 
 // NewSearcher creates a new searcher
@@ -27,6 +30,7 @@ func NewSearcher(cfg *config.Config) (*Searcher, error) {
 	if err != nil {
 		return nil, err
 	}
+	vdb.SetMultiVector(&cfg.Embedding.MultiVector)
 
 	return &Searcher{
 		cfg:      cfg,
@@ -42,19 +46,47 @@ func (s *Searcher) Close() error {
 }
 
 // Search finds similar issues for a query
-func (s *Searcher) Search(ctx context.Context, query string, org string, limit int) ([]models.SearchResult, error) {
-	// If no org specified, use first configured repo's org
+func (s *Searcher) Search(ctx context.Context, query string, org, repo string, limit int) ([]models.SearchResult, error) {
+	// If no org specified, use first configured repo
 	if org == "" && len(s.cfg.Repositories) > 0 {
 		org = s.cfg.Repositories[0].Org
+		repo = s.cfg.Repositories[0].Repo
+	}
+	if repo == "" && s.cfg.Qdrant.CollectionScope == vectordb.CollectionScopeRepo {
+		return nil, fmt.Errorf("a repository is required when collection_scope is repo")
 	}
 
-	finder := NewSimilarityFinder(s.cfg, s.embedder, s.vdb)
-	results, err := finder.FindSimilarByText(ctx, query, org, limit)
+	results, err := s.finder().FindSimilarByText(ctx, query, org, repo, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toModelResults(results), nil
+}
+
+// SetVisibilityChecker enables defaults.private_matches handling of results
+func (s *Searcher) SetVisibilityChecker(v VisibilityChecker) {
+	s.visibility = v
+}
+
+// SearchDraft finds issues similar to one about to be filed in org/repo
+func (s *Searcher) SearchDraft(ctx context.Context, query string, org, repo string, limit int) ([]models.SearchResult, error) {
+	results, err := s.finder().FindSimilarToDraft(ctx, query, org, repo, limit)
 	if err != nil {
 		return nil, err
 	}
+	return toModelResults(results), nil
+}
+
+func (s *Searcher) finder() *SimilarityFinder {
+	finder := NewSimilarityFinder(s.cfg, s.embedder, s.vdb)
+	if s.visibility != nil {
+		finder.SetVisibilityChecker(s.visibility)
+	}
+	return finder
+}
 
-	// Convert to models.SearchResult
+// toModelResults converts search results to models.SearchResult
+func toModelResults(results []vectordb.SearchResult) []models.SearchResult {
 	modelResults := make([]models.SearchResult, len(results))
 	for i, r := range results {
 		modelResults[i] = models.SearchResult{
@@ -62,6 +94,5 @@ func (s *Searcher) Search(ctx context.Context, query string, org string, limit i
 			Score: r.Score,
 		}
 	}
-
-	return modelResults, nil
+	return modelResults
 }
@@ -3,10 +3,17 @@ package processor
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/profile"
+	"github.com/Kavirubc/gh-simili/internal/style"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 	"github.com/qdrant/go-client/qdrant"
@@ -17,6 +24,14 @@ type SimilarityFinder struct {
 	cfg      *config.Config
 	embedder *embedding.FallbackProvider
 	vdb      *vectordb.Client
+	filters  []SimilarityFilter
+
+	visibility VisibilityChecker // nil disables private match handling
+}
+
+// VisibilityChecker reports a repository's visibility (public, private, internal)
+type VisibilityChecker interface {
+	GetRepoVisibility(ctx context.Context, org, repo string) (string, error)
 }
 
 // NewSimilarityFinder creates a new similarity finder
@@ -25,49 +40,71 @@ func NewSimilarityFinder(cfg *config.Config, embedder *embedding.FallbackProvide
 		cfg:      cfg,
 		embedder: embedder,
 		vdb:      vdb,
+		filters:  DefaultFilters(cfg),
 	}
 }
 
+// AddFilters appends custom post-search filters after the built-in ones
+func (sf *SimilarityFinder) AddFilters(filters ...SimilarityFilter) {
+	sf.filters = append(sf.filters, filters...)
+}
+
+// SetVisibilityChecker enables defaults.private_matches handling of matches
+// from non-public repositories on public repos' issues
+func (sf *SimilarityFinder) SetVisibilityChecker(v VisibilityChecker) {
+	sf.visibility = v
+}
+
 // FindSimilar finds similar issues for a given issue
 func (sf *SimilarityFinder) FindSimilar(ctx context.Context, issue *models.Issue, excludeSelf bool) ([]vectordb.SearchResult, error) {
-	text := embedding.PrepareIssueText(issue.Title, issue.Body)
-	vector, err := sf.embedder.Embed(ctx, text)
+	return sf.findSimilar(ctx, issue, excludeSelf, sf.cfg.GetSearchThreshold(issue.Org, issue.Repo))
+}
+
+// FindSimilarAbove finds other issues scoring at least threshold, ignoring
+// the configured similarity threshold
+func (sf *SimilarityFinder) FindSimilarAbove(ctx context.Context, issue *models.Issue, threshold float64) ([]vectordb.SearchResult, error) {
+	return sf.findSimilar(ctx, issue, true, threshold)
+}
+
+func (sf *SimilarityFinder) findSimilar(ctx context.Context, issue *models.Issue, excludeSelf bool, threshold float64) ([]vectordb.SearchResult, error) {
+	stopEmbed := profile.Track(ctx, "embed")
+	query, err := sf.embedQuery(ctx, issue)
+	stopEmbed()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+		return nil, err
 	}
 
-	collection := vectordb.CollectionName(issue.Org)
-	threshold := sf.cfg.GetSimilarityThreshold(issue.Org, issue.Repo)
-	limit := sf.cfg.Defaults.MaxSimilarToShow
-	closedWeight := sf.cfg.Defaults.ClosedIssueWeight
+	collection := vectordb.CollectionName(&sf.cfg.Qdrant, issue.Org, issue.Repo)
+	limit := sf.cfg.Defaults.MaxSimilarToFetch
+	closed := sf.closedRanking()
 
-	var filter *qdrant.Filter
+	var mustNot []*qdrant.Condition
 	if excludeSelf {
 		// Exclude the issue itself from results (must match all: org, repo, and number)
-		filter = &qdrant.Filter{
-			MustNot: []*qdrant.Condition{
-				{
-					ConditionOneOf: &qdrant.Condition_Filter{
-						Filter: &qdrant.Filter{
-							Must: []*qdrant.Condition{
-								qdrant.NewMatchKeyword("org", issue.Org),
-								qdrant.NewMatchKeyword("repo", issue.Repo),
-								qdrant.NewMatchInt("number", int64(issue.Number)),
-							},
-						},
+		mustNot = append(mustNot, &qdrant.Condition{
+			ConditionOneOf: &qdrant.Condition_Filter{
+				Filter: &qdrant.Filter{
+					Must: []*qdrant.Condition{
+						qdrant.NewMatchKeyword("org", issue.Org),
+						qdrant.NewMatchKeyword("repo", issue.Repo),
+						qdrant.NewMatchInt("number", int64(issue.Number)),
 					},
 				},
 			},
-		}
+		})
 	}
+	// Excluded repos never supply matches, but an issue still matches its own repo
+	mustNot = append(mustNot, excludedRepoConditions(sf.cfg.Defaults.CrossRepoExclude, issue.Org+"/"+issue.Repo)...)
 
-	var results []vectordb.SearchResult
-	if filter != nil {
-		results, err = sf.vdb.SearchFiltered(ctx, collection, vector, limit+1, threshold, closedWeight, filter)
-	} else {
-		results, err = sf.vdb.Search(ctx, collection, vector, limit+1, threshold, closedWeight)
+	var filter *qdrant.Filter
+	if len(mustNot) > 0 {
+		filter = &qdrant.Filter{MustNot: mustNot}
 	}
 
+	stopSearch := profile.Track(ctx, "search")
+	results, err := sf.search(ctx, collection, query, limit+1, threshold, closed, filter)
+	stopSearch()
+
 	if err != nil {
 		return nil, err
 	}
@@ -84,71 +121,308 @@ func (sf *SimilarityFinder) FindSimilar(ctx context.Context, issue *models.Issue
 		results = filtered
 	}
 
+	// Configured and custom filters (same repo, excluded labels, minimum age, ...)
+	results = applyFilters(results, issue, sf.filters)
+
+	// Keep private repos' titles out of public comments
+	results = sf.hidePrivateMatches(ctx, issue, results)
+
+	// Let same-repo matches win close calls before trimming
+	if boost := sf.cfg.Defaults.SameRepoBoost; boost > 0 && sf.cfg.Defaults.CrossRepoSearch {
+		results = preferSameRepo(results, issue, boost)
+	}
+
 	// Trim to limit
-	if len(results) > limit {
-		results = results[:limit]
+	results = vectordb.TrimResults(results, limit)
+
+	logging.Debug("similarity search", "repo", issue.FullRepo(), "issue", issue.Number, "collection", collection, "matches", len(results), "threshold", threshold)
+	for _, r := range results {
+		logging.Debug("similarity match", "issue", issue.Number, "match_repo", r.Issue.FullRepo(), "match", r.Issue.Number, "score", r.Score, "state", r.Issue.State)
 	}
 
 	return results, nil
 }
 
-// FindSimilarByText finds similar issues for a text query
-func (sf *SimilarityFinder) FindSimilarByText(ctx context.Context, text string, org string, limit int) ([]vectordb.SearchResult, error) {
+// hidePrivateMatches redacts or drops matches from non-public repositories
+// when the issue's own repository is public, per defaults.private_matches.
+// A repository whose visibility can't be read is treated as private, and an
+// issue whose own repository can't be read as public.
+func (sf *SimilarityFinder) hidePrivateMatches(ctx context.Context, issue *models.Issue, results []vectordb.SearchResult) []vectordb.SearchResult {
+	mode := sf.cfg.Defaults.PrivateMatches
+	if sf.visibility == nil || mode == "show" {
+		return results
+	}
+
+	own, err := sf.visibility.GetRepoVisibility(ctx, issue.Org, issue.Repo)
+	if err != nil {
+		logging.Warn("failed to read repository visibility, treating as public", "repo", issue.FullRepo(), "error", err)
+	} else if own != github.VisibilityPublic {
+		return results
+	}
+
+	kept := make([]vectordb.SearchResult, 0, len(results))
+	for _, r := range results {
+		if r.Issue.Org == issue.Org && r.Issue.Repo == issue.Repo {
+			kept = append(kept, r)
+			continue
+		}
+		visibility, err := sf.visibility.GetRepoVisibility(ctx, r.Issue.Org, r.Issue.Repo)
+		if err != nil {
+			logging.Warn("failed to read repository visibility, treating as private", "repo", r.Issue.FullRepo(), "error", err)
+		} else if visibility == github.VisibilityPublic {
+			kept = append(kept, r)
+			continue
+		}
+		if mode == "drop" {
+			continue
+		}
+		kept = append(kept, redact(r))
+	}
+	return kept
+}
+
+// redact keeps only a match's score and state, so nothing identifying the
+// private issue reaches comments, LLM prompts, or JSON output
+func redact(r vectordb.SearchResult) vectordb.SearchResult {
+	return vectordb.SearchResult{
+		Issue:    models.Issue{State: r.Issue.State},
+		Score:    r.Score,
+		Separate: r.Separate,
+		Redacted: true,
+	}
+}
+
+// preferSameRepo boosts same-repo matches by boost and reorders results by
+// the boosted score, leaving Score unchanged. The boost stays on the results
+// so later reordering ranks them the same way. The separate closed bucket stays last.
+func preferSameRepo(results []vectordb.SearchResult, issue *models.Issue, boost float64) []vectordb.SearchResult {
+	ranked := make([]vectordb.SearchResult, len(results))
+	copy(ranked, results)
+
+	for i := range ranked {
+		if ranked[i].Issue.Org == issue.Org && ranked[i].Issue.Repo == issue.Repo {
+			ranked[i].Boost = boost
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Separate != ranked[j].Separate {
+			return !ranked[i].Separate
+		}
+		return ranked[i].RankScore() > ranked[j].RankScore()
+	})
+	return ranked
+}
+
+// FindSimilarByText finds similar issues for a text query.
+// repo selects the collection only when collections are scoped per repo.
+func (sf *SimilarityFinder) FindSimilarByText(ctx context.Context, text string, org, repo string, limit int) ([]vectordb.SearchResult, error) {
+	results, err := sf.findByText(ctx, text, org, repo, limit, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query stands in for a new issue in org/repo
+	if org != "" && repo != "" {
+		results = sf.hidePrivateMatches(ctx, &models.Issue{Org: org, Repo: repo}, results)
+	}
+	return results, nil
+}
+
+// FindSimilarToDraft finds issues similar to one about to be filed in
+// org/repo, treating it like a new issue there: matches come from that repo
+// unless cross_repo_search is on, and pass the configured filters and
+// private_matches handling. Redacted matches are left out.
+func (sf *SimilarityFinder) FindSimilarToDraft(ctx context.Context, text string, org, repo string, limit int) ([]vectordb.SearchResult, error) {
+	var must []*qdrant.Condition
+	if !sf.cfg.Defaults.CrossRepoSearch {
+		must = append(must, qdrant.NewMatchKeyword("org", org), qdrant.NewMatchKeyword("repo", repo))
+	}
+
+	results, err := sf.findByText(ctx, text, org, repo, limit, must)
+	if err != nil {
+		return nil, err
+	}
+	return sf.scopeToDraft(ctx, &models.Issue{Org: org, Repo: repo}, results), nil
+}
+
+// scopeToDraft applies the filtering a new issue in draft's repo would get
+func (sf *SimilarityFinder) scopeToDraft(ctx context.Context, draft *models.Issue, results []vectordb.SearchResult) []vectordb.SearchResult {
+	filters := sf.filters
+	if !sf.cfg.Defaults.CrossRepoSearch {
+		filters = append(slices.Clip(filters), SameRepoOnly())
+	}
+	results = applyFilters(results, draft, filters)
+	results = sf.hidePrivateMatches(ctx, draft, results)
+	return slices.DeleteFunc(results, func(r vectordb.SearchResult) bool { return r.Redacted })
+}
+
+func (sf *SimilarityFinder) findByText(ctx context.Context, text string, org, repo string, limit int, must []*qdrant.Condition) ([]vectordb.SearchResult, error) {
 	vector, err := sf.embedder.Embed(ctx, text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	collection := vectordb.CollectionName(org)
+	collection := vectordb.CollectionName(&sf.cfg.Qdrant, org, repo)
 	threshold := sf.cfg.Defaults.SimilarityThreshold
-	closedWeight := sf.cfg.Defaults.ClosedIssueWeight
 
-	return sf.vdb.Search(ctx, collection, vector, limit, threshold, closedWeight)
+	var filter *qdrant.Filter
+	if excluded := excludedRepoConditions(sf.cfg.Defaults.CrossRepoExclude, ""); len(excluded) > 0 || len(must) > 0 {
+		filter = &qdrant.Filter{Must: must, MustNot: excluded}
+	}
+
+	// A free-text query stands in for both the title and the body view
+	query := vectordb.Views{Title: vector, Body: vector}
+	return sf.search(ctx, collection, query, limit, threshold, sf.closedRanking(), filter)
+}
+
+// excludedRepoConditions returns a condition matching each excluded org/repo
+// other than own, for use in a MustNot filter
+func excludedRepoConditions(excluded []string, own string) []*qdrant.Condition {
+	var conditions []*qdrant.Condition
+	for _, entry := range excluded {
+		org, repo, ok := strings.Cut(entry, "/")
+		if !ok || strings.EqualFold(entry, own) {
+			continue
+		}
+		conditions = append(conditions, &qdrant.Condition{
+			ConditionOneOf: &qdrant.Condition_Filter{
+				Filter: &qdrant.Filter{
+					Must: []*qdrant.Condition{
+						qdrant.NewMatchKeyword("org", org),
+						qdrant.NewMatchKeyword("repo", repo),
+					},
+				},
+			},
+		})
+	}
+	return conditions
+}
+
+// TopScoreInRepo returns the highest similarity between issue and any issue
+// indexed for org/repo, or 0 when that repo has no indexed issues
+func (sf *SimilarityFinder) TopScoreInRepo(ctx context.Context, issue *models.Issue, org, repo string) (float64, error) {
+	query, err := sf.embedQuery(ctx, issue)
+	if err != nil {
+		return 0, err
+	}
+
+	collection := vectordb.CollectionName(&sf.cfg.Qdrant, org, repo)
+	filter := &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+		},
+	}
+
+	// Raw scores: the closed-issue weight only affects how matches are shown
+	raw := vectordb.ClosedRanking{Strategy: vectordb.ClosedStrategyDemote}
+	results, err := sf.search(ctx, collection, query, 1, 0, raw, filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return results[0].Score, nil
+}
+
+// embedQuery embeds an issue for searching. Without multi-vector mode both
+// views hold the same combined vector.
+func (sf *SimilarityFinder) embedQuery(ctx context.Context, issue *models.Issue) (vectordb.Views, error) {
+	if !sf.vdb.MultiVector() {
+		text := embedding.PrepareIssueTextWithConfig(&sf.cfg.Embedding, issue)
+		vector, err := sf.embedder.Embed(ctx, text)
+		if err != nil {
+			return vectordb.Views{}, fmt.Errorf("failed to generate embedding: %w", err)
+		}
+		return vectordb.Views{Title: vector, Body: vector}, nil
+	}
+
+	title, body := embedding.PrepareViewTexts(&sf.cfg.Embedding, issue)
+	vectors, err := sf.embedder.EmbedBatch(ctx, []string{title, body})
+	if err != nil {
+		return vectordb.Views{}, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+	return vectordb.Views{Title: vectors[0], Body: vectors[1]}, nil
+}
+
+// search runs a single-vector or fused multi-vector query as configured
+func (sf *SimilarityFinder) search(ctx context.Context, collection string, query vectordb.Views, limit int, threshold float64, closed vectordb.ClosedRanking, filter *qdrant.Filter) ([]vectordb.SearchResult, error) {
+	if sf.vdb.MultiVector() {
+		return sf.vdb.SearchViews(ctx, collection, query, limit, threshold, closed, filter)
+	}
+	if filter != nil {
+		return sf.vdb.SearchFiltered(ctx, collection, query.Title, limit, threshold, closed, filter)
+	}
+	return sf.vdb.Search(ctx, collection, query.Title, limit, threshold, closed)
+}
+
+// closedRanking returns the configured closed-issue ranking
+func (sf *SimilarityFinder) closedRanking() vectordb.ClosedRanking {
+	return vectordb.ClosedRanking{
+		Strategy: sf.cfg.Defaults.ClosedIssueStrategy,
+		Weight:   sf.cfg.Defaults.ClosedIssueWeight,
+	}
 }
 
 // FormatSimilarityComment creates the similarity comment for posting
-func FormatSimilarityComment(results []vectordb.SearchResult, crossRepo bool) string {
+func FormatSimilarityComment(results []vectordb.SearchResult, crossRepo bool, st style.Style) string {
 	if len(results) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("👋 Thanks for opening this issue!\n\n")
-	sb.WriteString("I found some potentially related issues that might be helpful:\n\n")
+	sb.WriteString(st.Icon("👋") + st.Text(locale.SimilarThanks) + "\n\n")
+	sb.WriteString(st.Text(locale.SimilarFound) + "\n\n")
 
 	if crossRepo {
-		sb.WriteString("| Issue | Repository | Similarity | Status |\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", st.Text(locale.ColumnIssue), st.Text(locale.ColumnRepository), st.Text(locale.ColumnSimilarity), st.Text(locale.ColumnStatus)))
 		sb.WriteString("|-------|------------|------------|--------|\n")
 	} else {
-		sb.WriteString("| Issue | Similarity | Status |\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", st.Text(locale.ColumnIssue), st.Text(locale.ColumnSimilarity), st.Text(locale.ColumnStatus)))
 		sb.WriteString("|-------|------------|--------|\n")
 	}
 
 	for _, r := range results {
-		status := "🟢 Open"
-		if r.Issue.State == "closed" {
-			status = "🔴 Closed"
-		}
-
-		title := truncateString(r.Issue.Title, 50)
-		link := fmt.Sprintf("[#%d - %s](%s)", r.Issue.Number, title, r.Issue.URL)
+		status := StatusCell(st, &r.Issue)
+		link, repo := MatchCells(st, r)
 		similarity := fmt.Sprintf("%.0f%%", r.Score*100)
 
 		if crossRepo {
-			repo := fmt.Sprintf("%s/%s", r.Issue.Org, r.Issue.Repo)
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", link, repo, similarity, status))
 		} else {
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", link, similarity, status))
 		}
 	}
 
-	sb.WriteString("\nIf any of these address your problem, please let us know and we can close this as a duplicate.\n\n")
-	sb.WriteString("---\n")
-	sb.WriteString("<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>")
+	sb.WriteString("\n" + st.Text(locale.SimilarPrompt) + "\n\n")
+	sb.WriteString(st.Footer("Simili"))
 
 	return sb.String()
 }
 
+// MatchCells renders the issue link and repository cells for a match;
+// redacted matches name neither
+func MatchCells(st style.Style, r vectordb.SearchResult) (link, repo string) {
+	if r.Redacted {
+		return st.Text(locale.RedactedMatch), "—"
+	}
+	title := EscapeTableCell(truncateString(r.Issue.Title, 50))
+	link = fmt.Sprintf("[#%d - %s](%s)", r.Issue.Number, title, r.Issue.URL)
+	return link, fmt.Sprintf("%s/%s", r.Issue.Org, r.Issue.Repo)
+}
+
+// StatusCell renders the status column for a match, marking discussions as such
+func StatusCell(st style.Style, issue *models.Issue) string {
+	if !issue.IsDiscussion() {
+		return st.State(issue.State)
+	}
+	if issue.State == "closed" {
+		return st.Icon("💬") + st.Text(locale.DiscussionClosed)
+	}
+	return st.Icon("💬") + st.Text(locale.StateDiscussion)
+}
+
 // truncateString truncates a string to maxLen with ellipsis
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -157,6 +431,20 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// tableCellEscaper neutralises characters that break markdown table cells
+var tableCellEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"`", "\\`",
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// EscapeTableCell makes text safe to place inside a markdown table cell
+func EscapeTableCell(s string) string {
+	return tableCellEscaper.Replace(s)
+}
+
 // HasCrossRepoResults checks if results span multiple repos
 func HasCrossRepoResults(results []vectordb.SearchResult, sourceOrg, sourceRepo string) bool {
 	for _, r := range results {
@@ -0,0 +1,256 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestEscapeTableCell(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Plain title", want: "Plain title"},
+		{input: "Fix | bar \n baz", want: `Fix \| bar   baz`},
+		{input: "Use `go test`", want: "Use \\`go test\\`"},
+		{input: "a\r\nb\rc", want: "a b c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := EscapeTableCell(tt.input); got != tt.want {
+				t.Errorf("EscapeTableCell(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSimilarityComment_PathologicalTitle(t *testing.T) {
+	results := []vectordb.SearchResult{
+		{
+			Issue: models.Issue{Number: 7, Title: "Fix | bar \n baz", State: "open", URL: "https://example.com/7"},
+			Score: 0.9,
+		},
+	}
+
+	comment := FormatSimilarityComment(results, false, style.Style{})
+
+	var row string
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.HasPrefix(line, "| [#7") {
+			row = line
+		}
+	}
+	if row == "" {
+		t.Fatalf("table row for #7 not found in comment:\n%s", comment)
+	}
+
+	// Three columns mean four unescaped pipes
+	unescaped := strings.Count(row, "|") - strings.Count(row, `\|`)
+	if unescaped != 4 {
+		t.Errorf("row has %d unescaped pipes, want 4: %q", unescaped, row)
+	}
+}
+
+func TestFormatSimilarityComment_PlainStyle(t *testing.T) {
+	results := []vectordb.SearchResult{
+		{Issue: models.Issue{Number: 1, Title: "Crash", State: "closed", URL: "https://example.com/1"}, Score: 0.9},
+	}
+
+	comment := FormatSimilarityComment(results, false, style.New(style.Plain))
+
+	for _, emoji := range []string{"👋", "🔴", "🤖"} {
+		if strings.Contains(comment, emoji) {
+			t.Errorf("plain comment contains %q:\n%s", emoji, comment)
+		}
+	}
+	if !strings.Contains(comment, "| Closed |") {
+		t.Errorf("plain comment missing status text:\n%s", comment)
+	}
+}
+
+func TestStatusCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue models.Issue
+		st    style.Style
+		want  string
+	}{
+		{name: "open issue", issue: models.Issue{State: "open"}, want: "🟢 Open"},
+		{name: "closed issue plain", issue: models.Issue{State: "closed"}, st: style.New(style.Plain), want: "Closed"},
+		{name: "discussion", issue: models.Issue{State: "open", Kind: models.KindDiscussion}, want: "💬 Discussion"},
+		{name: "closed discussion plain", issue: models.Issue{State: "closed", Kind: models.KindDiscussion}, st: style.New(style.Plain), want: "Discussion (closed)"},
+		{name: "localized discussion", issue: models.Issue{State: "closed", Kind: models.KindDiscussion}, st: style.New(style.Plain).Localized("es"), want: "Discusión (cerrada)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCell(tt.st, &tt.issue); got != tt.want {
+				t.Errorf("StatusCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludedRepoConditions(t *testing.T) {
+	excluded := []string{"org/sandbox", "org/app", "malformed"}
+
+	got := excludedRepoConditions(excluded, "org/App")
+	if len(got) != 1 {
+		t.Fatalf("excludedRepoConditions() returned %d conditions, want 1 (own repo and malformed entries skipped)", len(got))
+	}
+	must := got[0].GetFilter().GetMust()
+	if len(must) != 2 || must[1].GetField().GetMatch().GetKeyword() != "sandbox" {
+		t.Errorf("excludedRepoConditions() = %v, want org/sandbox", got[0])
+	}
+
+	if got := excludedRepoConditions(excluded, ""); len(got) != 2 {
+		t.Errorf("excludedRepoConditions() without own repo returned %d conditions, want 2", len(got))
+	}
+}
+
+type fakeVisibility map[string]string
+
+func (f fakeVisibility) GetRepoVisibility(ctx context.Context, org, repo string) (string, error) {
+	if v, ok := f[org+"/"+repo]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
+func TestHidePrivateMatches(t *testing.T) {
+	issue := &models.Issue{Org: "octo", Repo: "public", Number: 1}
+	results := []vectordb.SearchResult{
+		{Issue: models.Issue{Org: "octo", Repo: "public", Number: 2, Title: "Same repo"}},
+		{Issue: models.Issue{Org: "octo", Repo: "docs", Number: 3, Title: "Public match"}},
+		{Issue: models.Issue{Org: "octo", Repo: "secret", Number: 4, Title: "Secret plans", URL: "https://github.com/octo/secret/issues/4"}},
+		{Issue: models.Issue{Org: "octo", Repo: "unknown", Number: 5, Title: "Unreadable"}},
+	}
+	visibility := fakeVisibility{"octo/public": "public", "octo/docs": "public", "octo/secret": "private"}
+
+	for _, mode := range []string{"redact", "drop", "show"} {
+		cfg := &config.Config{Defaults: config.DefaultsConfig{PrivateMatches: mode}}
+		sf := &SimilarityFinder{cfg: cfg}
+		sf.SetVisibilityChecker(visibility)
+
+		got := sf.hidePrivateMatches(context.Background(), issue, append([]vectordb.SearchResult{}, results...))
+		switch mode {
+		case "show":
+			if len(got) != 4 || got[2].Redacted {
+				t.Errorf("show: got %+v, want all matches unchanged", got)
+			}
+		case "drop":
+			if len(got) != 2 || got[0].Issue.Number != 2 || got[1].Issue.Number != 3 {
+				t.Errorf("drop: got %+v, want #2 and #3", got)
+			}
+		case "redact":
+			if len(got) != 4 || !got[2].Redacted || !got[3].Redacted || got[1].Redacted {
+				t.Fatalf("redact: got %+v, want #4 and #5 redacted", got)
+			}
+			link, repo := MatchCells(style.Style{}, got[2])
+			if strings.Contains(link, "Secret") || strings.Contains(repo, "secret") {
+				t.Errorf("redacted cells leak the match: %q, %q", link, repo)
+			}
+			if link, _ := MatchCells(style.Style{}.Localized("fr"), got[2]); link != "une issue interne liée" {
+				t.Errorf("localized redacted link = %q, want the French placeholder", link)
+			}
+		}
+	}
+
+	// Redaction leaves nothing that identifies the private issue
+	sf := &SimilarityFinder{cfg: &config.Config{Defaults: config.DefaultsConfig{PrivateMatches: "redact"}}}
+	sf.SetVisibilityChecker(visibility)
+	secret := []vectordb.SearchResult{{Issue: models.Issue{Org: "octo", Repo: "secret", Number: 4, Title: "Secret plans", Body: "details", Author: "mona", Labels: []string{"infra"}, State: "open"}, Score: 0.9}}
+	got := sf.hidePrivateMatches(context.Background(), issue, secret)
+	if want := (models.Issue{State: "open"}); len(got) != 1 || !reflect.DeepEqual(got[0].Issue, want) || got[0].Score != 0.9 {
+		t.Errorf("redacted match = %+v, want only state and score", got)
+	}
+
+	// An issue whose own repo visibility can't be read is treated as public
+	unreadable := &models.Issue{Org: "octo", Repo: "unknown", Number: 1}
+	if got := sf.hidePrivateMatches(context.Background(), unreadable, secret); !got[0].Redacted {
+		t.Error("unreadable own visibility should fail closed and redact private matches")
+	}
+
+	// Private repos may show their own private matches
+	sf = &SimilarityFinder{cfg: &config.Config{Defaults: config.DefaultsConfig{PrivateMatches: "redact"}}}
+	sf.SetVisibilityChecker(fakeVisibility{"octo/secret": "private"})
+	own := &models.Issue{Org: "octo", Repo: "secret", Number: 9}
+	if got := sf.hidePrivateMatches(context.Background(), own, results); got[2].Redacted {
+		t.Error("matches on a private repo's issue should not be redacted")
+	}
+}
+
+func TestPreferSameRepo(t *testing.T) {
+	issue := &models.Issue{Org: "octo", Repo: "app"}
+	results := []vectordb.SearchResult{
+		{Issue: models.Issue{Org: "octo", Repo: "lib", Number: 1}, Score: 0.90},
+		{Issue: models.Issue{Org: "octo", Repo: "app", Number: 2}, Score: 0.88},
+		{Issue: models.Issue{Org: "octo", Repo: "lib", Number: 3}, Score: 0.95},
+		{Issue: models.Issue{Org: "octo", Repo: "app", Number: 4, State: "closed"}, Score: 0.99, Separate: true},
+	}
+
+	got := preferSameRepo(results, issue, 0.03)
+	var order []int
+	for _, r := range got {
+		order = append(order, r.Issue.Number)
+	}
+	if want := []int{3, 2, 1, 4}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("preferSameRepo() order = %v, want %v", order, want)
+	}
+	if got[1].Score != 0.88 {
+		t.Errorf("boost changed the reported score to %v", got[1].Score)
+	}
+
+	// Reordering for display keeps the boosted order
+	order = nil
+	for _, r := range vectordb.SortForDisplay(got, vectordb.SortScore) {
+		order = append(order, r.Issue.Number)
+	}
+	if want := []int{3, 2, 1, 4}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("SortForDisplay() after the boost = %v, want %v", order, want)
+	}
+}
+
+func TestScopeToDraft(t *testing.T) {
+	draft := &models.Issue{Org: "octo", Repo: "public"}
+	results := []vectordb.SearchResult{
+		{Issue: models.Issue{Org: "octo", Repo: "public", Number: 2, Title: "Same repo"}},
+		{Issue: models.Issue{Org: "octo", Repo: "docs", Number: 3, Title: "Public match"}},
+		{Issue: models.Issue{Org: "octo", Repo: "secret", Number: 4, Title: "Secret plans"}},
+	}
+	visibility := fakeVisibility{"octo/public": "public", "octo/docs": "public", "octo/secret": "private"}
+
+	tests := []struct {
+		name      string
+		crossRepo bool
+		want      []int
+	}{
+		{"same repo only", false, []int{2}},
+		{"cross repo without private matches", true, []int{2, 3}},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{Defaults: config.DefaultsConfig{PrivateMatches: "redact", CrossRepoSearch: tt.crossRepo}}
+		sf := &SimilarityFinder{cfg: cfg}
+		sf.SetVisibilityChecker(visibility)
+
+		got := sf.scopeToDraft(context.Background(), draft, append([]vectordb.SearchResult{}, results...))
+		var numbers []int
+		for _, r := range got {
+			numbers = append(numbers, r.Issue.Number)
+		}
+		if !reflect.DeepEqual(numbers, tt.want) {
+			t.Errorf("%s: got matches %v, want %v", tt.name, numbers, tt.want)
+		}
+	}
+}
@@ -2,12 +2,18 @@ package processor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
@@ -20,6 +26,19 @@ type Syncer struct {
 	vdb      *vectordb.Client
 	indexer  *Indexer
 	dryRun   bool
+
+	// reportPath, when set in dry-run, receives a JSON SyncReport
+	reportPath string
+}
+
+// SyncReport previews index churn for a dry-run sync
+type SyncReport struct {
+	Repo      string    `json:"repo"`
+	Since     time.Time `json:"since"`
+	Total     int       `json:"total"`
+	New       []int     `json:"new"`
+	Updated   []int     `json:"updated"`
+	Unchanged []int     `json:"unchanged"`
 }
 
 // NewSyncer creates a new syncer
@@ -28,6 +47,7 @@ func NewSyncer(cfg *config.Config, dryRun bool) (*Syncer, error) {
 	if err != nil {
 		return nil, err
 	}
+	gh.SetRequestsPerSecond(cfg.RateLimits.GitHubRPS)
 
 	embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
 	if err != nil {
@@ -38,6 +58,7 @@ func NewSyncer(cfg *config.Config, dryRun bool) (*Syncer, error) {
 	if err != nil {
 		return nil, err
 	}
+	vdb.SetMultiVector(&cfg.Embedding.MultiVector)
 
 	indexer, err := NewIndexer(cfg, dryRun)
 	if err != nil {
@@ -61,6 +82,11 @@ func (s *Syncer) Close() error {
 	return s.vdb.Close()
 }
 
+// SetReportPath writes a dry-run SyncReport to path after each SyncRepo
+func (s *Syncer) SetReportPath(path string) {
+	s.reportPath = path
+}
+
 // SyncRepo syncs issues updated since a given duration
 func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration string) (*models.IndexStats, error) {
 	start := time.Now()
@@ -78,7 +104,7 @@ func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration st
 	}
 
 	// Ensure collection exists
-	collection := vectordb.CollectionName(org)
+	collection := vectordb.CollectionName(&s.cfg.Qdrant, org, repo)
 	if !s.dryRun {
 		if err := s.vdb.EnsureCollection(ctx, collection); err != nil {
 			return nil, fmt.Errorf("failed to ensure collection: %w", err)
@@ -97,10 +123,26 @@ func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration st
 	stats.TotalIssues = len(issues)
 	fmt.Printf("Found %d updated issues\n", len(issues))
 
+	if s.dryRun {
+		report, err := s.buildReport(ctx, collection, fullRepo, since, issues)
+		if err != nil {
+			logging.Warn("failed to build dry-run report", "repo", fullRepo, "error", err)
+		} else {
+			fmt.Printf("Dry run: %d new, %d updated, %d unchanged\n",
+				len(report.New), len(report.Updated), len(report.Unchanged))
+			if s.reportPath != "" {
+				if err := writeSyncReport(s.reportPath, report); err != nil {
+					return nil, err
+				}
+				fmt.Printf("Dry-run report written to %s\n", s.reportPath)
+			}
+		}
+	}
+
 	// Process each issue
 	for _, issue := range issues {
 		if err := s.indexer.IndexSingleIssue(ctx, issue); err != nil {
-			fmt.Printf("Warning: failed to sync issue #%d: %v\n", issue.Number, err)
+			logging.Warn("failed to sync issue", "repo", fullRepo, "issue", issue.Number, "error", err)
 			stats.Errors++
 			continue
 		}
@@ -111,22 +153,115 @@ func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration st
 	return stats, nil
 }
 
-// parseSinceDuration parses duration strings like "24h", "7d"
-func parseSinceDuration(s string) (time.Time, error) {
-	// Handle day suffix
-	if len(s) > 1 && s[len(s)-1] == 'd' {
-		days := s[:len(s)-1]
-		d, err := time.ParseDuration(days + "h")
+// buildReport classifies issues as new, updated, or unchanged against the index
+func (s *Syncer) buildReport(ctx context.Context, collection, fullRepo string, since time.Time, issues []*models.Issue) (*SyncReport, error) {
+	report := &SyncReport{
+		Repo:      fullRepo,
+		Since:     since,
+		Total:     len(issues),
+		New:       []int{},
+		Updated:   []int{},
+		Unchanged: []int{},
+	}
+
+	exists, err := s.vdb.CollectionExists(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check collection: %w", err)
+	}
+
+	stored := map[string]vectordb.StoredIssue{}
+	if exists {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.UUID()
+		}
+		stored, err = s.vdb.GetIssues(ctx, collection, ids)
 		if err != nil {
-			return time.Time{}, err
+			return nil, err
 		}
-		return time.Now().Add(-d * 24), nil
 	}
 
-	// Standard duration
-	d, err := time.ParseDuration(s)
+	for _, issue := range issues {
+		prev, ok := stored[issue.UUID()]
+		switch {
+		case !ok:
+			report.New = append(report.New, issue.Number)
+		case isStale(prev, issue):
+			report.Updated = append(report.Updated, issue.Number)
+		default:
+			report.Unchanged = append(report.Unchanged, issue.Number)
+		}
+	}
+
+	return report, nil
+}
+
+// isStale reports whether the indexed copy of an issue is out of date
+func isStale(prev vectordb.StoredIssue, issue *models.Issue) bool {
+	return prev.BodyHash != issue.BodyHash() || prev.Issue.Title != issue.Title || prev.Issue.State != issue.State
+}
+
+// writeSyncReport writes the report as indented JSON
+func writeSyncReport(path string, report *SyncReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// parseSinceDuration parses duration strings like "24h", "7d", "2w", "1w3d"
+func parseSinceDuration(s string) (time.Time, error) {
+	d, err := parseHumanDuration(s)
 	if err != nil {
 		return time.Time{}, err
 	}
 	return time.Now().Add(-d), nil
 }
+
+// durationPart matches one number+unit component of a duration
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)([a-zµ]+)`)
+
+// parseHumanDuration extends time.ParseDuration with "w" (weeks) and
+// "d" (days) units, which may be combined with the standard ones.
+func parseHumanDuration(s string) (time.Duration, error) {
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total time.Duration
+	for rest != "" {
+		m := durationPart.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		rest = rest[len(m[0]):]
+
+		var unit time.Duration
+		switch m[2] {
+		case "w":
+			unit = 7 * 24 * time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		default:
+			d, err := time.ParseDuration(m[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			total += d
+			continue
+		}
+
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		total += time.Duration(n * float64(unit))
+	}
+
+	return total, nil
+}
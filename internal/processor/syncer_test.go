@@ -0,0 +1,47 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHumanDuration(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "24h", want: 24 * time.Hour},
+		{input: "90m", want: 90 * time.Minute},
+		{input: "7d", want: 7 * day},
+		{input: "2w", want: 14 * day},
+		{input: "1w3d", want: 10 * day},
+		{input: "1d12h", want: day + 12*time.Hour},
+		{input: "1w2d6h30m", want: 9*day + 6*time.Hour + 30*time.Minute},
+		{input: "1.5d", want: 36 * time.Hour},
+		{input: "", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "3x", wantErr: true},
+		{input: "1w junk", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseHumanDuration(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseHumanDuration(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHumanDuration(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseHumanDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// VerifyReport lists where the index disagrees with a repository's issues
+type VerifyReport struct {
+	Repo     string `json:"repo"`
+	Live     int    `json:"live"`
+	Indexed  int    `json:"indexed"`
+	Missing  []int  `json:"missing"`          // Live issues with no vector
+	Stale    []int  `json:"stale"`            // Indexed with an outdated title, body or state
+	Orphaned []int  `json:"orphaned"`         // Indexed issues that no longer exist
+	Repaired int    `json:"repaired"`         // Issues fixed by --repair
+	Failed   []int  `json:"failed,omitempty"` // Issues --repair could not fix
+}
+
+// Consistent reports whether the index matches the repository
+func (r *VerifyReport) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Stale) == 0 && len(r.Orphaned) == 0
+}
+
+// VerifyRepo compares every live issue in a repository against the index
+// and, with repair set, deletes orphaned vectors and reindexes missing and
+// stale issues. A repair that fails for any issue returns the report along
+// with an error.
+func (s *Syncer) VerifyRepo(ctx context.Context, fullRepo string, repair bool) (*VerifyReport, error) {
+	org, repo, err := github.ParseRepo(fullRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// GraphQL only: the REST listing includes pull requests, which are never
+	// indexed and would all be reported (and repaired) as missing
+	live, err := s.gh.ListAllIssuesGraphQL(ctx, org, repo, "all", 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	collection := vectordb.CollectionName(&s.cfg.Qdrant, org, repo)
+	exists, err := s.vdb.CollectionExists(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check collection: %w", err)
+	}
+	stored := map[string]vectordb.StoredIssue{}
+	if exists {
+		stored, err = s.vdb.ScrollIssues(ctx, collection, org, repo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := diffIndex(live, stored)
+	report.Repo = fullRepo
+	if !repair || report.Consistent() {
+		return report, nil
+	}
+
+	byNumber := make(map[int]*models.Issue, len(live))
+	for _, issue := range live {
+		byNumber[issue.Number] = issue
+	}
+	err = repairIndex(report, byNumber,
+		func(number int) error { return s.indexer.DeleteIssue(ctx, org, repo, number) },
+		func(issue *models.Issue) error { return s.indexer.IndexSingleIssue(ctx, issue) },
+	)
+	return report, err
+}
+
+// repairIndex deletes the report's orphaned vectors and reindexes its
+// missing and stale issues, counting the fixes that succeed in Repaired and
+// the rest in Failed
+func repairIndex(report *VerifyReport, live map[int]*models.Issue, remove func(int) error, index func(*models.Issue) error) error {
+	for _, number := range report.Orphaned {
+		if err := remove(number); err != nil {
+			logging.Warn("failed to delete from index", "repo", report.Repo, "issue", number, "error", err)
+			report.Failed = append(report.Failed, number)
+			continue
+		}
+		report.Repaired++
+	}
+	for _, number := range append(append([]int{}, report.Missing...), report.Stale...) {
+		if err := index(live[number]); err != nil {
+			logging.Warn("failed to reindex", "repo", report.Repo, "issue", number, "error", err)
+			report.Failed = append(report.Failed, number)
+			continue
+		}
+		report.Repaired++
+	}
+
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("failed to repair %d of %d issues: %v", len(report.Failed), len(report.Failed)+report.Repaired, report.Failed)
+	}
+	return nil
+}
+
+// diffIndex classifies live issues and stored points as missing, stale or
+// orphaned. Indexed discussions are left alone.
+func diffIndex(live []*models.Issue, stored map[string]vectordb.StoredIssue) *VerifyReport {
+	report := &VerifyReport{
+		Live:     len(live),
+		Missing:  []int{},
+		Stale:    []int{},
+		Orphaned: []int{},
+	}
+
+	seen := make(map[string]bool, len(live))
+	for _, issue := range live {
+		id := issue.UUID()
+		seen[id] = true
+		prev, ok := stored[id]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, issue.Number)
+		case isStale(prev, issue):
+			report.Stale = append(report.Stale, issue.Number)
+		}
+	}
+
+	for id, prev := range stored {
+		if prev.Issue.IsDiscussion() {
+			continue
+		}
+		report.Indexed++
+		if !seen[id] {
+			report.Orphaned = append(report.Orphaned, prev.Issue.Number)
+		}
+	}
+	sort.Ints(report.Orphaned)
+
+	return report
+}
@@ -0,0 +1,87 @@
+package processor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestDiffIndex(t *testing.T) {
+	current := &models.Issue{Org: "o", Repo: "r", Number: 1, Title: "Same", Body: "body", State: "open"}
+	edited := &models.Issue{Org: "o", Repo: "r", Number: 2, Title: "New title", Body: "body", State: "open"}
+	unindexed := &models.Issue{Org: "o", Repo: "r", Number: 3, Title: "New", State: "open"}
+	deleted := &models.Issue{Org: "o", Repo: "r", Number: 4, Title: "Gone", State: "open"}
+	discussion := &models.Issue{Org: "o", Repo: "r", Number: 5, Title: "Q&A", Kind: models.KindDiscussion}
+
+	stored := map[string]vectordb.StoredIssue{
+		current.UUID():    {Issue: *current, BodyHash: current.BodyHash()},
+		edited.UUID():     {Issue: models.Issue{Number: 2, Title: "Old title", State: "open"}, BodyHash: edited.BodyHash()},
+		deleted.UUID():    {Issue: *deleted, BodyHash: deleted.BodyHash()},
+		discussion.UUID(): {Issue: *discussion},
+	}
+
+	report := diffIndex([]*models.Issue{current, edited, unindexed}, stored)
+
+	if report.Live != 3 || report.Indexed != 3 {
+		t.Errorf("Live, Indexed = %d, %d, want 3, 3", report.Live, report.Indexed)
+	}
+	if !reflect.DeepEqual(report.Missing, []int{3}) {
+		t.Errorf("Missing = %v, want [3]", report.Missing)
+	}
+	if !reflect.DeepEqual(report.Stale, []int{2}) {
+		t.Errorf("Stale = %v, want [2]", report.Stale)
+	}
+	if !reflect.DeepEqual(report.Orphaned, []int{4}) {
+		t.Errorf("Orphaned = %v, want [4]", report.Orphaned)
+	}
+	if report.Consistent() {
+		t.Error("Consistent() = true, want false")
+	}
+}
+
+func TestRepairIndex(t *testing.T) {
+	report := &VerifyReport{Repo: "o/r", Missing: []int{3}, Stale: []int{2, 5}, Orphaned: []int{4, 6}}
+	live := map[int]*models.Issue{
+		2: {Number: 2},
+		3: {Number: 3},
+		5: {Number: 5},
+	}
+
+	var indexed []int
+	err := repairIndex(report, live,
+		func(number int) error {
+			if number == 6 {
+				return errors.New("delete failed")
+			}
+			return nil
+		},
+		func(issue *models.Issue) error {
+			if issue.Number == 5 {
+				return errors.New("embed failed")
+			}
+			indexed = append(indexed, issue.Number)
+			return nil
+		},
+	)
+
+	if err == nil {
+		t.Fatal("repairIndex() error = nil, want an error for the failed issues")
+	}
+	if report.Repaired != 3 {
+		t.Errorf("Repaired = %d, want 3", report.Repaired)
+	}
+	if !reflect.DeepEqual(report.Failed, []int{6, 5}) {
+		t.Errorf("Failed = %v, want [6 5]", report.Failed)
+	}
+	if !reflect.DeepEqual(indexed, []int{3, 2}) {
+		t.Errorf("indexed = %v, want [3 2]", indexed)
+	}
+
+	clean := &VerifyReport{Missing: []int{3}}
+	if err := repairIndex(clean, live, nil, func(*models.Issue) error { return nil }); err != nil || clean.Repaired != 1 {
+		t.Errorf("repairIndex() = %v with %d repaired, want nil and 1", err, clean.Repaired)
+	}
+}
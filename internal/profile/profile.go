@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type recorderKey struct{}
+
+// Recorder accumulates wall-clock time spent in named stages
+type Recorder struct {
+	mu      sync.Mutex
+	timings map[string]time.Duration
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{timings: make(map[string]time.Duration)}
+}
+
+// WithRecorder attaches a recorder to the context
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the recorder attached to ctx, or nil
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Track starts timing a stage and returns a func that stops it.
+// It is a no-op when no recorder is attached, so callers can always use
+// `defer profile.Track(ctx, "embed")()`.
+func Track(ctx context.Context, name string) func() {
+	r := FromContext(ctx)
+	if r == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		r.Add(name, time.Since(start))
+	}
+}
+
+// Add records d against a stage; repeated stages are summed
+func (r *Recorder) Add(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings[name] += d
+}
+
+// Milliseconds returns the recorded timings in milliseconds
+func (r *Recorder) Milliseconds() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[string]int, len(r.timings))
+	for name, d := range r.timings {
+		out[name] = int(d.Milliseconds())
+	}
+	return out
+}
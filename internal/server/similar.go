@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// maxLimit bounds how many matches one request may ask for
+const maxLimit = 50
+
+// Searcher is the subset of processor.Searcher the API needs
+type Searcher interface {
+	SearchDraft(ctx context.Context, query string, org, repo string, limit int) ([]models.SearchResult, error)
+}
+
+// Options configures the similarity API. The API is meant to be called from
+// public issue forms, so it only answers for repositories RepoAllowed admits
+// and never relies on a secret a browser could read.
+type Options struct {
+	// Token, when set, must be sent as a Bearer token. It only keeps out
+	// casual callers: once embedded in a browser form it is public.
+	Token        string
+	AllowOrigin  string                                           // Access-Control-Allow-Origin for browser callers; empty disables CORS
+	DefaultLimit int                                              // Matches returned when the request has no limit
+	RepoAllowed  func(ctx context.Context, org, repo string) bool // Repositories the API answers for
+}
+
+// Match is one similar issue in an API response
+type Match struct {
+	Repo   string  `json:"repo"`
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	State  string  `json:"state"`
+	URL    string  `json:"url"`
+	Score  float64 `json:"score"`
+}
+
+// SimilarResponse is the body returned by GET /similar
+type SimilarResponse struct {
+	Matches []Match `json:"matches"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler serves GET /similar?repo=owner/name&title=...&body=... and GET /healthz
+func NewHandler(searcher Searcher, opts Options) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/similar", withCORS(opts.AllowOrigin, withToken(opts.Token, similarHandler(searcher, opts))))
+	return mux
+}
+
+func similarHandler(searcher Searcher, opts Options) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"only GET is supported"})
+			return
+		}
+
+		q := r.URL.Query()
+		org, repo, err := github.ParseRepo(q.Get("repo"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+		if opts.RepoAllowed != nil && !opts.RepoAllowed(r.Context(), org, repo) {
+			writeJSON(w, http.StatusForbidden, errorResponse{"repository not enabled"})
+			return
+		}
+
+		title := strings.TrimSpace(q.Get("title"))
+		body := strings.TrimSpace(q.Get("body"))
+		if title == "" && body == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"title or body is required"})
+			return
+		}
+
+		limit := opts.DefaultLimit
+		if raw := q.Get("limit"); raw != "" {
+			if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"limit must be a positive integer"})
+				return
+			}
+		}
+		limit = min(max(limit, 1), maxLimit)
+
+		results, err := searcher.SearchDraft(r.Context(), strings.TrimSpace(title+"\n\n"+body), org, repo, limit)
+		if err != nil {
+			logging.Error("similarity API search failed", "repo", org+"/"+repo, "error", err)
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"search failed"})
+			return
+		}
+
+		resp := SimilarResponse{Matches: make([]Match, len(results))}
+		for i, res := range results {
+			resp.Matches[i] = Match{
+				Repo:   res.Issue.FullRepo(),
+				Number: res.Issue.Number,
+				Title:  res.Issue.Title,
+				State:  res.Issue.State,
+				URL:    res.Issue.URL,
+				Score:  res.Score,
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// withToken rejects requests without "Authorization: Bearer <token>" when a
+// token is configured
+func withToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{"missing or invalid token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withCORS lets an issue form on origin call the API from the browser,
+// answering preflight requests before authentication
+func withCORS(origin string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+			w.Header().Set("Access-Control-Allow-Methods", http.MethodGet)
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
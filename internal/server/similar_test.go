@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+type fakeSearcher struct {
+	query, org, repo string
+	limit            int
+}
+
+func (f *fakeSearcher) SearchDraft(ctx context.Context, query string, org, repo string, limit int) ([]models.SearchResult, error) {
+	f.query, f.org, f.repo, f.limit = query, org, repo, limit
+	return []models.SearchResult{
+		{Issue: models.Issue{Org: "org", Repo: "app", Number: 7, Title: "Login fails", State: "open", URL: "https://github.com/org/app/issues/7"}, Score: 0.91},
+	}, nil
+}
+
+func TestSimilarHandler(t *testing.T) {
+	searcher := &fakeSearcher{}
+	handler := NewHandler(searcher, Options{
+		Token:        "secret",
+		DefaultLimit: 5,
+		RepoAllowed:  func(ctx context.Context, org, repo string) bool { return org == "org" },
+	})
+
+	tests := []struct {
+		name   string
+		url    string
+		token  string
+		status int
+	}{
+		{name: "ok", url: "/similar?repo=org/app&title=Login+broken&body=500+error", token: "secret", status: http.StatusOK},
+		{name: "missing token", url: "/similar?repo=org/app&title=x", status: http.StatusUnauthorized},
+		{name: "wrong token", url: "/similar?repo=org/app&title=x", token: "nope", status: http.StatusUnauthorized},
+		{name: "repo not enabled", url: "/similar?repo=other/app&title=x", token: "secret", status: http.StatusForbidden},
+		{name: "bad repo", url: "/similar?repo=app&title=x", token: "secret", status: http.StatusBadRequest},
+		{name: "no text", url: "/similar?repo=org/app", token: "secret", status: http.StatusBadRequest},
+		{name: "bad limit", url: "/similar?repo=org/app&title=x&limit=-1", token: "secret", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+
+	if searcher.query != "Login broken\n\n500 error" || searcher.org != "org" || searcher.repo != "app" || searcher.limit != 5 {
+		t.Errorf("unexpected search call: %+v", searcher)
+	}
+}
+
+func TestSimilarHandler_NoToken(t *testing.T) {
+	// Browser forms can't keep a secret, so the API may run without one and
+	// relies on RepoAllowed to keep private repositories out
+	handler := NewHandler(&fakeSearcher{}, Options{
+		DefaultLimit: 5,
+		RepoAllowed:  func(ctx context.Context, org, repo string) bool { return repo != "private" },
+	})
+
+	for url, want := range map[string]int{
+		"/similar?repo=org/app&title=x":     http.StatusOK,
+		"/similar?repo=org/private&title=x": http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", url, rec.Code, want)
+		}
+	}
+}
+
+func TestSimilarHandler_Response(t *testing.T) {
+	handler := NewHandler(&fakeSearcher{}, Options{Token: "secret", DefaultLimit: 5})
+
+	req := httptest.NewRequest(http.MethodGet, "/similar?repo=org/app&title=login&limit=500", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp SimilarResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].Repo != "org/app" || resp.Matches[0].Number != 7 || resp.Matches[0].Score != 0.91 {
+		t.Errorf("unexpected matches: %+v", resp.Matches)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	handler := NewHandler(&fakeSearcher{}, Options{Token: "secret", AllowOrigin: "https://github.com"})
+
+	req := httptest.NewRequest(http.MethodOptions, "/similar", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://github.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package style
+
+import (
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/locale"
+)
+
+// Comment styles accepted by defaults.comment_style
+const (
+	Emoji = "emoji"
+	Plain = "plain"
+)
+
+const poweredByURL = "https://github.com/Kavirubc/gh-simili"
+
+// signatureMarker keeps comments recognisable as the bot's when the visible
+// footer is replaced or removed
+const signatureMarker = "<!-- Simili -->"
+
+// Style renders the decorative parts of bot comments. The zero value uses emoji.
+type Style struct {
+	plain  bool
+	footer *string // Overrides the "Powered by" footer when set
+	lang   string  // Language of static strings; empty means English
+}
+
+// New returns the style for a comment_style config value
+func New(name string) Style {
+	return Style{plain: name == Plain}
+}
+
+// ForDefaults returns the style for the comment_style and comment_footer settings
+func ForDefaults(d *config.DefaultsConfig) Style {
+	s := New(d.CommentStyle)
+	s.footer = d.CommentFooter
+	return s
+}
+
+// ForRepo returns the defaults style in the repository's configured language
+func ForRepo(cfg *config.Config, org, repo string) Style {
+	return ForDefaults(&cfg.Defaults).Localized(cfg.GetLanguage(org, repo))
+}
+
+// Localized returns the style rendering static strings in lang
+func (s Style) Localized(lang string) Style {
+	s.lang = lang
+	return s
+}
+
+// Text returns the static string for key in the style's language
+func (s Style) Text(key locale.Key) string {
+	return locale.Message(s.lang, key)
+}
+
+// Textf formats the static string for key in the style's language
+func (s Style) Textf(key locale.Key, args ...any) string {
+	return fmt.Sprintf(s.Text(key), args...)
+}
+
+// IsPlain reports whether emoji are suppressed
+func (s Style) IsPlain() bool {
+	return s.plain
+}
+
+// Icon returns emoji followed by a space, or nothing in plain style
+func (s Style) Icon(emoji string) string {
+	if s.plain {
+		return ""
+	}
+	return emoji + " "
+}
+
+// Heading renders a markdown heading, prefixed with emoji unless plain
+func (s Style) Heading(level int, emoji, text string) string {
+	hashes := "######"[:min(max(level, 1), 6)]
+	return fmt.Sprintf("%s %s%s", hashes, s.Icon(emoji), text)
+}
+
+// State renders an issue state for a similarity table cell
+func (s Style) State(state string) string {
+	if state == "closed" {
+		return s.Icon("🔴") + s.Text(locale.StateClosed)
+	}
+	return s.Icon("🟢") + s.Text(locale.StateOpen)
+}
+
+// Reaction renders a reaction choice such as "👍 (+1)", or just "`+1`" when plain
+func (s Style) Reaction(emoji, name string) string {
+	if s.plain {
+		return "`" + name + "`"
+	}
+	return fmt.Sprintf("%s (%s)", emoji, name)
+}
+
+// Footer renders the "Powered by" footer linking to product, or the
+// configured comment_footer (which may be empty) in its place
+func (s Style) Footer(product string) string {
+	if s.footer != nil {
+		if *s.footer == "" {
+			return signatureMarker
+		}
+		return fmt.Sprintf("---\n<sub>%s</sub>\n%s", *s.footer, signatureMarker)
+	}
+	link := fmt.Sprintf("[%s](%s)", product, poweredByURL)
+	return fmt.Sprintf("---\n<sub>%s%s</sub>", s.Icon("🤖"), s.Textf(locale.PoweredBy, link))
+}
@@ -0,0 +1,48 @@
+package style
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestFooter(t *testing.T) {
+	custom := "Triaged by Acme"
+	empty := ""
+
+	tests := []struct {
+		name    string
+		footer  *string
+		want    string
+		notWant string
+	}{
+		{"default", nil, "Powered by [Simili]", ""},
+		{"custom", &custom, "<sub>Triaged by Acme</sub>", "Powered by"},
+		{"removed", &empty, signatureMarker, "---"},
+	}
+
+	for _, tt := range tests {
+		got := ForDefaults(&config.DefaultsConfig{CommentFooter: tt.footer}).Footer("Simili")
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: Footer() = %q, want it to contain %q", tt.name, got, tt.want)
+		}
+		if tt.notWant != "" && strings.Contains(got, tt.notWant) {
+			t.Errorf("%s: Footer() = %q, should not contain %q", tt.name, got, tt.notWant)
+		}
+		// Bot comments are recognised by this signature
+		if !strings.Contains(got, "Simili") {
+			t.Errorf("%s: Footer() = %q lost the bot signature", tt.name, got)
+		}
+	}
+}
+
+func TestLocalized(t *testing.T) {
+	st := New(Plain).Localized("fr")
+	if got := st.State("closed"); got != "Fermée" {
+		t.Errorf("State() = %q, want %q", got, "Fermée")
+	}
+	if got := st.Footer("Simili"); !strings.Contains(got, "Propulsé par [Simili]") {
+		t.Errorf("Footer() = %q, want the French footer", got)
+	}
+}
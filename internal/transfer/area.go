@@ -0,0 +1,42 @@
+package transfer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// AreaMatcher resolves the team that owns an issue from a repository's area rules
+type AreaMatcher struct {
+	rules []config.AreaRule
+}
+
+// NewAreaMatcher creates a matcher for a repository's area rules
+func NewAreaMatcher(rules []config.AreaRule) *AreaMatcher {
+	// Sort rules by priority (lower = higher priority)
+	sorted := make([]config.AreaRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	return &AreaMatcher{rules: sorted}
+}
+
+// Match returns the first area rule with a keyword in the issue title or body, or nil
+func (m *AreaMatcher) Match(issue *models.Issue) *config.AreaRule {
+	for i := range m.rules {
+		rule := &m.rules[i]
+		if containsAny(issue.Title, rule.Keywords) || containsAny(issue.Body, rule.Keywords) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// TeamMention formats an org/team slug as an @-mention
+func TeamMention(team string) string {
+	return "@" + strings.TrimPrefix(team, "@")
+}
@@ -0,0 +1,48 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestAreaMatcher_Match(t *testing.T) {
+	rules := []config.AreaRule{
+		{Keywords: []string{"dashboard"}, Team: "org/web", Priority: 2},
+		{Keywords: []string{"Postgres", "migration"}, Team: "org/data", Target: "org/data-platform", Priority: 1},
+	}
+
+	matcher := NewAreaMatcher(rules)
+
+	tests := []struct {
+		name     string
+		issue    *models.Issue
+		wantTeam string
+	}{
+		{name: "keyword in title", issue: &models.Issue{Title: "Dashboard is blank"}, wantTeam: "org/web"},
+		{name: "keyword in body is case-insensitive", issue: &models.Issue{Title: "Deploy fails", Body: "the postgres pod restarts"}, wantTeam: "org/data"},
+		{name: "priority wins when several match", issue: &models.Issue{Title: "Dashboard migration broke"}, wantTeam: "org/data"},
+		{name: "no match", issue: &models.Issue{Title: "Typo in docs"}, wantTeam: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var team string
+			if rule := matcher.Match(tt.issue); rule != nil {
+				team = rule.Team
+			}
+			if team != tt.wantTeam {
+				t.Errorf("Match() team = %q, want %q", team, tt.wantTeam)
+			}
+		})
+	}
+}
+
+func TestTeamMention(t *testing.T) {
+	for _, team := range []string{"org/web", "@org/web"} {
+		if got := TeamMention(team); got != "@org/web" {
+			t.Errorf("TeamMention(%q) = %q, want @org/web", team, got)
+		}
+	}
+}
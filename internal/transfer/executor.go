@@ -2,13 +2,17 @@ package transfer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/style"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
@@ -20,7 +24,7 @@ type Executor struct {
 	vectordb       *vectordb.Client
 	pendingManager *pending.Manager
 	cfg            *config.Config
-	dryRun         bool
+	dryRun         config.DryRunPolicy // Consulted for transfers
 }
 
 // NewExecutor creates a new transfer executor
@@ -33,7 +37,7 @@ func NewExecutor(transferClient *github.Client, commentClient *github.Client, vd
 		vectordb:       vdb,
 		pendingManager: pending.NewManager(commentClient, cfg),
 		cfg:            cfg,
-		dryRun:         dryRun,
+		dryRun:         config.NewDryRunPolicy(dryRun, cfg.Defaults.DryRun),
 	}
 }
 
@@ -74,7 +78,7 @@ func (e *Executor) Transfer(ctx context.Context, issue *models.Issue, targetRepo
 
 // ScheduleTransfer schedules a delayed transfer
 func (e *Executor) ScheduleTransfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule) error {
-	if e.dryRun {
+	if e.dryRun.Transfers() {
 		return nil
 	}
 
@@ -93,7 +97,7 @@ func (e *Executor) ScheduleTransfer(ctx context.Context, issue *models.Issue, ta
 	}
 
 	// Post warning comment
-	comment, err := formatDelayedTransferComment(targetRepo, rule, expiresAt, e.cfg.Defaults.DelayedActions, action)
+	comment, err := formatDelayedTransferComment(targetRepo, rule, expiresAt, e.cfg.Defaults.DelayedActions, action, e.style(issue.Org, issue.Repo))
 	if err != nil {
 		return fmt.Errorf("failed to format warning comment: %w", err)
 	}
@@ -111,7 +115,7 @@ func (e *Executor) ScheduleTransfer(ctx context.Context, issue *models.Issue, ta
 // ScheduleTransferSilent schedules a delayed transfer without posting a comment
 // Used when the comment is already posted (e.g. by unified processor)
 func (e *Executor) ScheduleTransferSilent(ctx context.Context, issue *models.Issue, targetRepo string, commentID int) error {
-	if e.dryRun {
+	if e.dryRun.Transfers() {
 		return nil
 	}
 
@@ -141,8 +145,9 @@ func (e *Executor) ProcessPendingTransfer(ctx context.Context, action *pending.P
 		action.Org,
 		action.Repo,
 		action.CommentID,
-		e.cfg.Defaults.DelayedActions.ApproveReaction,
-		e.cfg.Defaults.DelayedActions.CancelReaction,
+		e.cfg.Defaults.DelayedActions.Approvals(),
+		e.cfg.Defaults.DelayedActions.Cancellations(),
+		action.ExtendReaction(e.cfg.Defaults.DelayedActions.ExtendReaction),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to check reactions: %w", err)
@@ -153,10 +158,17 @@ func (e *Executor) ProcessPendingTransfer(ctx context.Context, action *pending.P
 		if err := e.pendingManager.Cancel(ctx, action); err != nil {
 			return err
 		}
-		cancelComment := formatTransferCancelledComment(action.Target)
+		cancelComment := formatTransferCancelledComment(action.Target, e.style(action.Org, action.Repo))
 		return e.commentClient.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
 	}
 
+	if decision == "extend" {
+		if e.dryRun.Transfers() {
+			return nil
+		}
+		return e.pendingManager.Extend(ctx, action, e.cfg.Defaults.DelayedActions.DelayHours)
+	}
+
 	if decision == "approve" && e.cfg.Defaults.DelayedActions.ExecuteOnApprove {
 		// User approved, execute immediately
 		issue := &models.Issue{
@@ -182,104 +194,131 @@ func (e *Executor) ProcessPendingTransfer(ctx context.Context, action *pending.P
 
 // executeTransfer performs the actual transfer
 func (e *Executor) executeTransfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule) error {
-	if e.dryRun {
+	if e.dryRun.Transfers() {
 		return nil
 	}
 
 	// Post transfer comment
 	var comment string
 	if e.cfg.Defaults.DelayedActions.Enabled && e.cfg.Defaults.DelayedActions.OptimisticTransfers {
-		comment = formatOptimisticTransferComment(issue, targetRepo, rule, e.cfg.Defaults.DelayedActions.CancelReaction)
+		comment = formatOptimisticTransferComment(issue, targetRepo, rule, e.cfg.Defaults.DelayedActions.CancelReaction, e.style(issue.Org, issue.Repo))
 	} else {
-		comment = formatTransferComment(targetRepo, rule)
+		comment = formatTransferComment(targetRepo, rule, e.style(issue.Org, issue.Repo))
 	}
-	if err := e.commentClient.PostComment(ctx, issue.Org, issue.Repo, issue.Number, comment); err != nil {
+	posted, err := e.commentClient.CreateComment(ctx, issue.Org, issue.Repo, issue.Number, comment)
+	if err != nil {
 		return fmt.Errorf("failed to post transfer comment: %w", err)
 	}
 
 	// Execute transfer
-	if err := e.transferClient.TransferIssue(ctx, issue.Org, issue.Repo, issue.Number, targetRepo); err != nil {
+	moved, err := e.transferClient.TransferIssue(ctx, issue.Org, issue.Repo, issue.Number, targetRepo)
+	if err != nil {
 		return fmt.Errorf("failed to transfer issue: %w", err)
 	}
 
+	// The comment travels with the issue; point it at the new location so
+	// links to the old number still lead somewhere useful. It now belongs to
+	// the target repo, which only the transfer client is sure to reach.
+	if moved.Number > 0 {
+		targetOrg, targetName, _ := github.ParseRepo(targetRepo)
+		updated := withContinuedAt(comment, targetRepo, moved, e.style(issue.Org, issue.Repo))
+		if err := e.transferClient.UpdateComment(ctx, targetOrg, targetName, posted.ID, updated); err != nil {
+			logging.Warnf("failed to add new location to transfer comment on %s#%d: %v", targetRepo, moved.Number, err)
+		}
+		logging.Infof("Transferred %s/%s#%d to %s#%d", issue.Org, issue.Repo, issue.Number, targetRepo, moved.Number)
+	}
+
 	// Remove pending label if exists
-	if err := e.commentClient.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, pending.LabelPendingTransfer); err != nil {
-		fmt.Printf("Warning: failed to remove pending-transfer label from %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	if err := e.commentClient.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, pending.LabelPendingTransfer); err != nil && !errors.Is(err, github.ErrNotFound) {
+		logging.Warnf("failed to remove pending-transfer label from %s/%s#%d: %v", issue.Org, issue.Repo, issue.Number, err)
 	}
 
 	// Delete old vector
-	collection := vectordb.CollectionName(issue.Org)
+	collection := vectordb.CollectionName(&e.cfg.Qdrant, issue.Org, issue.Repo)
 	if err := e.vectordb.Delete(ctx, collection, issue.UUID()); err != nil {
-		fmt.Printf("Warning: failed to delete old vector: %v\n", err)
+		logging.Warnf("failed to delete old vector: %v", err)
 	}
 
 	return nil
 }
 
-// formatTransferComment creates the transfer notification comment
-func formatTransferComment(targetRepo string, rule *config.TransferRule) string {
-	matchDesc := formatMatchDescription(rule)
+// style returns the configured comment style in org/repo's language
+func (e *Executor) style(org, repo string) style.Style {
+	return style.ForRepo(e.cfg, org, repo)
+}
 
-	return fmt.Sprintf(`🚚 This issue has been automatically transferred to **%s** because it matches our routing rules.
+// formatTransferComment creates the transfer notification comment
+func formatTransferComment(targetRepo string, rule *config.TransferRule, st style.Style) string {
+	matchDesc := formatMatchDescription(rule, st)
 
-**Matched rule:** %s
+	return fmt.Sprintf("%s%s\n\n%s\n\n%s\n\n%s", st.Icon("🚚"), st.Textf(locale.TransferDone, targetRepo),
+		st.Textf(locale.MatchedRule, matchDesc), st.Text(locale.TransferContinue), st.Footer("Simili"))
+}
 
-The discussion will continue there. Thanks for your report!
+// withContinuedAt adds the issue's new location to a transfer comment,
+// ahead of the footer when there is one
+func withContinuedAt(comment, targetRepo string, moved *github.TransferredIssue, st style.Style) string {
+	ref := fmt.Sprintf("%s#%d", targetRepo, moved.Number)
+	if moved.URL != "" {
+		ref = fmt.Sprintf("[%s](%s)", ref, moved.URL)
+	}
+	line := st.Textf(locale.ContinuedAt, ref)
 
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo, matchDesc)
+	footer := st.Footer("Simili")
+	if i := strings.LastIndex(comment, footer); i >= 0 {
+		return comment[:i] + line + "\n\n" + comment[i:]
+	}
+	return comment + "\n\n" + line
 }
 
 // formatDelayedTransferComment creates a warning comment for delayed transfer
-func formatDelayedTransferComment(targetRepo string, rule *config.TransferRule, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
-	matchDesc := formatMatchDescription(rule)
-	deadline := expiresAt.Format("2006-01-02 15:04 MST")
+func formatDelayedTransferComment(targetRepo string, rule *config.TransferRule, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction, st style.Style) (string, error) {
+	matchDesc := formatMatchDescription(rule, st)
+	deadline := expiresAt.Format(pending.DeadlineLayout)
 
 	metadata, err := pending.FormatPendingActionMetadata(action)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf(`⚠️ **This issue will be transferred to %s in %d hours**
+	return fmt.Sprintf(`%s**%s**
 
-**Matched rule:** %s
+%s
 
-**React to this comment:**
-- 👍 (%s) to approve and proceed with this transfer
-- 👎 (%s) to cancel this transfer
+**%s**
+- %s %s
+- %s %s
 
-**Deadline**: %s
+**%s**: %s
 
-If no reaction is provided, the transfer will proceed automatically.
+%s
 
 %s
 
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`,
-		targetRepo,
-		cfg.DelayHours,
-		matchDesc,
-		cfg.ApproveReaction,
-		cfg.CancelReaction,
-		deadline,
+%s`,
+		st.Icon("⚠️"),
+		st.Textf(locale.TransferWarning, targetRepo, cfg.DelayHours),
+		st.Textf(locale.MatchedRule, matchDesc),
+		st.Text(locale.ReactPrompt),
+		st.Reaction("👍", cfg.ApproveReaction), st.Text(locale.TransferApprove),
+		st.Reaction("👎", cfg.CancelReaction), st.Text(locale.TransferCancel),
+		st.Text(locale.Deadline), deadline,
+		st.Text(locale.TransferAuto),
 		metadata,
+		st.Footer("Simili"),
 	), nil
 }
 
 // formatTransferCancelledComment creates a cancellation comment
-func formatTransferCancelledComment(targetRepo string) string {
-	return fmt.Sprintf(`✅ Transfer to **%s** has been cancelled based on your reaction.
-
-The issue will remain in this repository.
-
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo)
+func formatTransferCancelledComment(targetRepo string, st style.Style) string {
+	return fmt.Sprintf("%s%s\n\n%s\n\n%s", st.Icon("✅"), st.Textf(locale.TransferCancelled, targetRepo),
+		st.Text(locale.TransferKept), st.Footer("Simili"))
 }
 
 // formatMatchDescription creates a human-readable match description
-func formatMatchDescription(rule *config.TransferRule) string {
+func formatMatchDescription(rule *config.TransferRule, st style.Style) string {
 	if rule == nil {
-		return "routing rules"
+		return st.Text(locale.RoutingRules)
 	}
 
 	var parts []string
@@ -296,9 +335,21 @@ func formatMatchDescription(rule *config.TransferRule) string {
 	if rule.Match.Author != "" {
 		parts = append(parts, fmt.Sprintf("`author: %s`", rule.Match.Author))
 	}
+	if len(rule.Match.IssueType) > 0 {
+		parts = append(parts, fmt.Sprintf("`issue_type: [%s]`", strings.Join(rule.Match.IssueType, ", ")))
+	}
+	if len(rule.Match.Milestone) > 0 {
+		parts = append(parts, fmt.Sprintf("`milestone: [%s]`", strings.Join(rule.Match.Milestone, ", ")))
+	}
+	if len(rule.Match.TitleRegex) > 0 {
+		parts = append(parts, fmt.Sprintf("`title_regex: [%s]`", strings.Join(rule.Match.TitleRegex, ", ")))
+	}
+	if rule.Match.AuthorRegex != "" {
+		parts = append(parts, fmt.Sprintf("`author_regex: %s`", rule.Match.AuthorRegex))
+	}
 
 	if len(parts) == 0 {
-		return "routing rules"
+		return st.Text(locale.RoutingRules)
 	}
 	return strings.Join(parts, " + ")
 }
@@ -310,20 +361,13 @@ type TransferSourceMetadata struct {
 }
 
 // formatOptimisticTransferComment creates the transfer notification comment for optimistic transfers
-func formatOptimisticTransferComment(issue *models.Issue, targetRepo string, rule *config.TransferRule, cancelReaction string) string {
-	matchDesc := formatMatchDescription(rule)
+func formatOptimisticTransferComment(issue *models.Issue, targetRepo string, rule *config.TransferRule, cancelReaction string, st style.Style) string {
+	matchDesc := formatMatchDescription(rule, st)
 
 	// Create metadata for potential revert
 	metadata := fmt.Sprintf(`<!-- simili-transfer-source: {"org": "%s", "repo": "%s"} -->`, issue.Org, issue.Repo)
 
-	return fmt.Sprintf(`🚚 This issue is being automatically transferred to **%s** because it matches our routing rules.
-%s
-**Matched rule:** %s
-
-**Mistake?** React with 👎 (%s) to this comment to revert this transfer.
-
-The discussion will continue there. Thanks for your report!
-
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo, metadata, matchDesc, cancelReaction)
+	return fmt.Sprintf("%s%s\n%s\n%s\n\n%s\n\n%s\n\n%s", st.Icon("🚚"), st.Textf(locale.TransferMoving, targetRepo), metadata,
+		st.Textf(locale.MatchedRule, matchDesc), st.Textf(locale.TransferRevert, st.Reaction("👎", cancelReaction)),
+		st.Text(locale.TransferContinue), st.Footer("Simili"))
 }
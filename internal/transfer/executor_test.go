@@ -0,0 +1,49 @@
+package transfer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/style"
+)
+
+func TestWithContinuedAt(t *testing.T) {
+	st := style.New(style.Plain)
+	comment := formatTransferComment("org/backend", nil, st)
+	moved := &github.TransferredIssue{Number: 321, URL: "https://github.com/org/backend/issues/321"}
+
+	got := withContinuedAt(comment, "org/backend", moved, st)
+
+	line := "**Continued at:** [org/backend#321](https://github.com/org/backend/issues/321)"
+	if !strings.Contains(got, line) {
+		t.Fatalf("comment missing new location:\n%s", got)
+	}
+	if strings.Index(got, line) > strings.Index(got, st.Footer("Simili")) {
+		t.Errorf("new location should come before the footer:\n%s", got)
+	}
+}
+
+func TestFormatDelayedTransferComment_Localized(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "docs-de", Language: "de"}}}
+	e := &Executor{cfg: cfg}
+	action := &pending.PendingAction{Type: pending.ActionTypeTransfer, Org: "org", Repo: "docs-de", IssueNumber: 1, Target: "org/backend"}
+	delayed := config.DelayedActionsConfig{DelayHours: 24, ApproveReaction: "+1", CancelReaction: "-1"}
+
+	got, err := formatDelayedTransferComment("org/backend", nil, time.Now(), delayed, action, e.style("org", "docs-de"))
+	if err != nil {
+		t.Fatalf("formatDelayedTransferComment() error = %v", err)
+	}
+	for _, want := range []string{"Dieses Issue wird nach org/backend übertragen (in 24 Stunden)", "Routing-Regeln", "Frist"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("comment missing %q:\n%s", want, got)
+		}
+	}
+
+	if got := formatTransferCancelledComment("org/backend", e.style("org", "other")); !strings.Contains(got, "Transfer to **org/backend** has been cancelled") {
+		t.Errorf("repo without a language should stay in English:\n%s", got)
+	}
+}
@@ -61,7 +61,7 @@ func (m *RevertManager) CheckForRevert(ctx context.Context, issue *models.Issue)
 		}
 
 		// Check for cancel reaction (which triggers revert in this context)
-		hasRevert, err := m.gh.HasReaction(ctx, issue.Org, issue.Repo, comment.ID, m.cfg.Defaults.DelayedActions.CancelReaction)
+		hasRevert, err := m.gh.HasReaction(ctx, issue.Org, issue.Repo, comment.ID, m.cfg.Defaults.DelayedActions.Cancellations()...)
 		if err != nil {
 			continue
 		}
@@ -1,16 +1,25 @@
 package transfer
 
 import (
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 // RuleMatcher evaluates transfer rules against issues
 type RuleMatcher struct {
-	rules []config.TransferRule
+	rules    []config.TransferRule
+	patterns []rulePatterns // Compiled regexes, parallel to rules
+}
+
+// rulePatterns holds a rule's compiled regular expressions
+type rulePatterns struct {
+	title  []*regexp.Regexp
+	author *regexp.Regexp
 }
 
 // NewRuleMatcher creates a matcher for a repository's transfer rules
@@ -22,14 +31,49 @@ func NewRuleMatcher(rules []config.TransferRule) *RuleMatcher {
 		return sorted[i].Priority < sorted[j].Priority
 	})
 
-	return &RuleMatcher{rules: sorted}
+	patterns := make([]rulePatterns, len(sorted))
+	for i, rule := range sorted {
+		patterns[i] = compileRulePatterns(&rule.Match)
+	}
+
+	return &RuleMatcher{rules: sorted, patterns: patterns}
+}
+
+// compileRulePatterns compiles a condition's regexes. Invalid patterns are
+// rejected by config validation; any that slip through never match.
+func compileRulePatterns(cond *config.MatchCondition) rulePatterns {
+	var p rulePatterns
+	for _, pattern := range cond.TitleRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			logging.Warnf("invalid title_regex %q: %v", pattern, err)
+			continue
+		}
+		p.title = append(p.title, re)
+	}
+	if cond.AuthorRegex != "" {
+		re, err := regexp.Compile(cond.AuthorRegex)
+		if err != nil {
+			logging.Warnf("invalid author_regex %q: %v", cond.AuthorRegex, err)
+		} else {
+			p.author = re
+		}
+	}
+	return p
 }
 
 // Match finds the first matching rule for an issue
 // Returns target repo and the matched rule, or empty string if no match
 func (m *RuleMatcher) Match(issue *models.Issue) (string, *config.TransferRule) {
+	return m.MatchWith(issue, nil)
+}
+
+// MatchWith is like Match, but a rule whose conditions match only fires when
+// accept (if non-nil) also approves it; otherwise matching falls through to
+// the next rule
+func (m *RuleMatcher) MatchWith(issue *models.Issue, accept func(rule *config.TransferRule) bool) (string, *config.TransferRule) {
 	for i := range m.rules {
-		if m.matchesRule(issue, &m.rules[i]) {
+		if m.matchesRule(issue, &m.rules[i], &m.patterns[i]) && (accept == nil || accept(&m.rules[i])) {
 			return m.rules[i].Target, &m.rules[i]
 		}
 	}
@@ -39,7 +83,7 @@ func (m *RuleMatcher) Match(issue *models.Issue) (string, *config.TransferRule)
 // matchesRule checks if an issue matches a single rule
 // Multiple conditions in same rule = AND logic
 // Multiple values in same condition = OR logic
-func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule) bool {
+func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule, patterns *rulePatterns) bool {
 	cond := &rule.Match
 	matchCount := 0
 	condCount := 0
@@ -55,7 +99,7 @@ func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule
 	// Check title contains (OR logic within)
 	if len(cond.TitleContains) > 0 {
 		condCount++
-		if m.containsAny(issue.Title, cond.TitleContains) {
+		if containsAny(issue.Title, cond.TitleContains) {
 			matchCount++
 		}
 	}
@@ -63,7 +107,7 @@ func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule
 	// Check body contains (OR logic within)
 	if len(cond.BodyContains) > 0 {
 		condCount++
-		if m.containsAny(issue.Body, cond.BodyContains) {
+		if containsAny(issue.Body, cond.BodyContains) {
 			matchCount++
 		}
 	}
@@ -76,6 +120,38 @@ func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule
 		}
 	}
 
+	// Check native issue type (OR logic within)
+	if len(cond.IssueType) > 0 {
+		condCount++
+		if m.matchesAnyLabel([]string{issue.IssueType}, cond.IssueType) {
+			matchCount++
+		}
+	}
+
+	// Check milestone (OR logic within)
+	if len(cond.Milestone) > 0 {
+		condCount++
+		if issue.Milestone != "" && m.matchesAnyLabel([]string{issue.Milestone}, cond.Milestone) {
+			matchCount++
+		}
+	}
+
+	// Check title regexes (OR logic within)
+	if len(cond.TitleRegex) > 0 {
+		condCount++
+		if matchesAnyRegex(issue.Title, patterns.title) {
+			matchCount++
+		}
+	}
+
+	// Check author regex
+	if cond.AuthorRegex != "" {
+		condCount++
+		if patterns.author != nil && patterns.author.MatchString(issue.Author) {
+			matchCount++
+		}
+	}
+
 	// AND logic: all conditions must match
 	return condCount > 0 && matchCount == condCount
 }
@@ -93,7 +169,7 @@ func (m *RuleMatcher) matchesAnyLabel(issueLabels, ruleLabels []string) bool {
 }
 
 // containsAny checks if text contains any of the substrings (case-insensitive)
-func (m *RuleMatcher) containsAny(text string, substrings []string) bool {
+func containsAny(text string, substrings []string) bool {
 	lowerText := strings.ToLower(text)
 	for _, sub := range substrings {
 		if strings.Contains(lowerText, strings.ToLower(sub)) {
@@ -102,3 +178,13 @@ func (m *RuleMatcher) containsAny(text string, substrings []string) bool {
 	}
 	return false
 }
+
+// matchesAnyRegex checks if text matches any of the patterns
+func matchesAnyRegex(text string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
@@ -176,3 +176,123 @@ func TestRuleMatcher_Match_ANDLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleMatcher_Match_IssueType(t *testing.T) {
+	rules := []config.TransferRule{
+		{
+			Match:    config.MatchCondition{IssueType: []string{"Bug"}},
+			Target:   "org/bugs",
+			Priority: 1,
+		},
+	}
+
+	matcher := NewRuleMatcher(rules)
+
+	tests := []struct {
+		name      string
+		issueType string
+		wantMatch bool
+	}{
+		{name: "matches type", issueType: "Bug", wantMatch: true},
+		{name: "case insensitive match", issueType: "bug", wantMatch: true},
+		{name: "different type", issueType: "Feature", wantMatch: false},
+		{name: "no type", issueType: "", wantMatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &models.Issue{IssueType: tt.issueType}
+			target, _ := matcher.Match(issue)
+			gotMatch := target != ""
+			if gotMatch != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", gotMatch, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestRuleMatcher_Match_Milestone(t *testing.T) {
+	matcher := NewRuleMatcher([]config.TransferRule{
+		{
+			Match:    config.MatchCondition{Milestone: []string{"v2.0"}},
+			Target:   "org/next",
+			Priority: 1,
+		},
+	})
+
+	tests := []struct {
+		name      string
+		milestone string
+		wantMatch bool
+	}{
+		{name: "matches milestone", milestone: "V2.0", wantMatch: true},
+		{name: "different milestone", milestone: "v1.9", wantMatch: false},
+		{name: "no milestone", milestone: "", wantMatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, _ := matcher.Match(&models.Issue{Milestone: tt.milestone})
+			if gotMatch := target != ""; gotMatch != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", gotMatch, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestRuleMatcher_Match_Regex(t *testing.T) {
+	rules := []config.TransferRule{
+		{
+			Match:    config.MatchCondition{AuthorRegex: `-team-bot$`},
+			Target:   "org/bots",
+			Priority: 1,
+		},
+		{
+			Match:    config.MatchCondition{TitleRegex: []string{`(?i)^\[docs?\]`, `(?i)typo`}},
+			Target:   "org/docs",
+			Priority: 2,
+		},
+	}
+
+	matcher := NewRuleMatcher(rules)
+
+	tests := []struct {
+		name       string
+		title      string
+		author     string
+		wantTarget string
+	}{
+		{name: "author regex", title: "Nightly failure", author: "ci-team-bot", wantTarget: "org/bots"},
+		{name: "author regex anchored", title: "Nightly failure", author: "ci-team-bot-2", wantTarget: ""},
+		{name: "first title regex", title: "[Doc] Broken link", author: "alice", wantTarget: "org/docs"},
+		{name: "second title regex", title: "Fix TYPO in readme", author: "alice", wantTarget: "org/docs"},
+		{name: "no match", title: "Crash on start", author: "alice", wantTarget: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &models.Issue{Title: tt.title, Author: tt.author}
+			target, _ := matcher.Match(issue)
+			if target != tt.wantTarget {
+				t.Errorf("Match() = %q, want %q", target, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestRuleMatcher_MatchWith_FallsThrough(t *testing.T) {
+	rules := []config.TransferRule{
+		{Match: config.MatchCondition{TitleContains: []string{"crash"}}, Target: "org/core", Priority: 1, MinSimilarityToTarget: 0.8},
+		{Match: config.MatchCondition{TitleContains: []string{"crash"}}, Target: "org/triage", Priority: 2},
+	}
+	matcher := NewRuleMatcher(rules)
+	issue := &models.Issue{Title: "Crash on start"}
+
+	reject := func(rule *config.TransferRule) bool { return rule.MinSimilarityToTarget == 0 }
+	if target, _ := matcher.MatchWith(issue, reject); target != "org/triage" {
+		t.Errorf("MatchWith() = %q, want %q", target, "org/triage")
+	}
+	if target, _ := matcher.MatchWith(issue, nil); target != "org/core" {
+		t.Errorf("MatchWith(nil) = %q, want %q", target, "org/core")
+	}
+}
@@ -4,58 +4,85 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 // Executor executes triage actions
 type Executor struct {
-	client        *github.Client
-	dryRun        bool
-	cfg           *config.Config
+	client           *github.Client
+	dryRun           config.DryRunPolicy
+	cfg              *config.Config
 	duplicateChecker *DuplicateChecker
+	onFailure        func(action Action, err error)
+	retry            github.RetryPolicy
 }
 
 // NewExecutor creates a new action executor
 func NewExecutor(client *github.Client, dryRun bool) *Executor {
 	return &Executor{
 		client: client,
-		dryRun: dryRun,
+		dryRun: config.NewDryRunPolicy(dryRun, config.DryRunConfig{}),
 	}
 }
 
 // NewExecutorWithDelayedActions creates an executor with delayed action support
 func NewExecutorWithDelayedActions(client *github.Client, cfg *config.Config, duplicateChecker *DuplicateChecker, dryRun bool) *Executor {
 	return &Executor{
-		client:          client,
-		dryRun:           dryRun,
+		client:           client,
+		dryRun:           config.NewDryRunPolicy(dryRun, cfg.Defaults.DryRun),
 		cfg:              cfg,
 		duplicateChecker: duplicateChecker,
 	}
 }
 
+// SetDryRunPolicy sets which action categories are kept in dry-run
+func (e *Executor) SetDryRunPolicy(policy config.DryRunPolicy) {
+	e.dryRun = policy
+}
+
+// SetFailureHandler registers fn to be called for every action that fails
+func (e *Executor) SetFailureHandler(fn func(action Action, err error)) {
+	e.onFailure = fn
+}
+
+// SetRetryPolicy retries actions that fail transiently and are safe to
+// repeat; see retryable
+func (e *Executor) SetRetryPolicy(policy github.RetryPolicy) {
+	e.retry = policy
+}
+
 // Execute performs all actions in a triage result
 func (e *Executor) Execute(ctx context.Context, issue *models.Issue, result *Result) error {
 	for _, action := range result.Actions {
-		if err := e.executeAction(ctx, issue, action, result); err != nil {
-			log.Printf("Error executing action %s: %v", action.Type, err)
+		run := func() error { return e.executeAction(ctx, issue, action, result) }
+		var err error
+		if e.retryable(action, result) {
+			err = e.retry.Do(ctx, string(action.Type), run)
+		} else {
+			err = run()
+		}
+		if err != nil {
+			logging.Errorf("failed to execute action %s: %v", action.Type, err)
+			if e.onFailure != nil {
+				e.onFailure(action, err)
+			}
 			// Continue with other actions
 		}
 	}
 	return nil
 }
 
-
 // executeAction performs a single action
 func (e *Executor) executeAction(ctx context.Context, issue *models.Issue, action Action, result *Result) error {
-	log.Printf("Executing action: %s (reason: %s)", action.Type, action.Reason)
+	logging.Debugf("Executing action: %s (reason: %s)", action.Type, action.Reason)
 
-	if e.dryRun {
-		log.Printf("[DRY RUN] Would execute: %s", action.Type)
+	if e.skipsAction(action.Type) {
+		logging.Infof("[DRY RUN] Would execute: %s", action.Type)
 		return nil
 	}
 
@@ -70,22 +97,62 @@ func (e *Executor) executeAction(ctx context.Context, issue *models.Issue, actio
 		return e.client.PostComment(ctx, issue.Org, issue.Repo, issue.Number, action.Comment)
 
 	case ActionClose:
-		// Check if delayed actions are enabled - if so, schedule instead of closing immediately
-		if e.cfg != nil && e.cfg.Defaults.DelayedActions.Enabled && e.duplicateChecker != nil && result != nil {
-			// Check if this is a duplicate close action
-			if dupResult := result.Duplicate; dupResult != nil && dupResult.IsDuplicate {
-				// Schedule delayed close instead of closing immediately
-				return e.duplicateChecker.ScheduleClose(ctx, issue, dupResult)
+		// Duplicates and spam are scheduled instead of closed when delayed actions are enabled
+		if e.schedulesClose(result) {
+			if result.Spam != nil && result.Spam.IsSpam {
+				return e.duplicateChecker.ScheduleSpamClose(ctx, issue, result.Spam)
 			}
+			return e.duplicateChecker.ScheduleClose(ctx, issue, result.Duplicate)
 		}
 		// Fall back to immediate close if delayed actions not enabled or not a duplicate
+		if result != nil && result.Duplicate != nil && result.Duplicate.IsDuplicate && result.Duplicate.Original != nil {
+			return e.client.MarkAsDuplicate(ctx, issue.Org, issue.Repo, issue.Number, result.Duplicate.Original.URL)
+		}
 		return e.client.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, "not_planned")
 
+	case ActionLock:
+		return e.client.LockIssue(ctx, issue.Org, issue.Repo, issue.Number, action.LockReason)
+
 	default:
 		return fmt.Errorf("unknown action type: %s", action.Type)
 	}
 }
 
+// retryable reports whether an action is safe to repeat after a transient
+// failure. Label changes, locks and plain closes are; comments and scheduled
+// closes, which post a warning comment, would be posted twice.
+func (e *Executor) retryable(action Action, result *Result) bool {
+	switch action.Type {
+	case ActionAddLabel, ActionRemoveLabel, ActionLock:
+		return true
+	case ActionClose:
+		return !e.schedulesClose(result)
+	default:
+		return false
+	}
+}
+
+// schedulesClose reports whether closing would schedule a delayed close of
+// a duplicate or spam rather than close the issue immediately
+func (e *Executor) schedulesClose(result *Result) bool {
+	return e.cfg != nil && e.cfg.Defaults.DelayedActions.Enabled && e.duplicateChecker != nil &&
+		result.SchedulesClose()
+}
+
+// skipsAction reports whether the dry-run policy covers an action type
+func (e *Executor) skipsAction(t ActionType) bool {
+	switch t {
+	case ActionAddLabel, ActionRemoveLabel:
+		return e.dryRun.Labels()
+	case ActionComment:
+		return e.dryRun.Comments()
+	case ActionClose, ActionLock:
+		return e.dryRun.Closes()
+	default:
+		return e.dryRun.All()
+	}
+}
+
 // ExecuteSelective executes only specific action types
 func (e *Executor) ExecuteSelective(ctx context.Context, issue *models.Issue, result *Result, allowedTypes []ActionType) error {
 	allowed := make(map[ActionType]bool)
@@ -98,7 +165,10 @@ func (e *Executor) ExecuteSelective(ctx context.Context, issue *models.Issue, re
 			continue
 		}
 		if err := e.executeAction(ctx, issue, action, result); err != nil {
-			log.Printf("Error executing action %s: %v", action.Type, err)
+			logging.Errorf("failed to execute action %s: %v", action.Type, err)
+			if e.onFailure != nil {
+				e.onFailure(action, err)
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,113 @@
+package triage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestExecutor_Retryable(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Enabled = true
+	delayed := NewExecutorWithDelayedActions(nil, cfg, &DuplicateChecker{}, false)
+	immediate := NewExecutor(nil, false)
+
+	duplicate := &Result{Duplicate: &DuplicateResult{IsDuplicate: true}}
+
+	tests := []struct {
+		name     string
+		executor *Executor
+		action   ActionType
+		result   *Result
+		want     bool
+	}{
+		{"label", delayed, ActionAddLabel, duplicate, true},
+		{"remove label", delayed, ActionRemoveLabel, nil, true},
+		{"lock", immediate, ActionLock, nil, true},
+		{"comment", immediate, ActionComment, nil, false},
+		{"immediate close", immediate, ActionClose, duplicate, true},
+		{"close of a non-duplicate", delayed, ActionClose, &Result{}, true},
+		{"scheduled close posts a warning", delayed, ActionClose, duplicate, false},
+		{"scheduled spam close posts a warning", delayed, ActionClose, &Result{Spam: &SpamResult{IsSpam: true}}, false},
+		{"immediate spam close", immediate, ActionClose, &Result{Spam: &SpamResult{IsSpam: true}}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.executor.retryable(Action{Type: tt.action}, tt.result); got != tt.want {
+			t.Errorf("retryable(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecutor_SkipsAction(t *testing.T) {
+	executor := NewExecutor(nil, false)
+	executor.SetDryRunPolicy(config.NewDryRunPolicy(false, config.DryRunConfig{Comments: true, Closes: true}))
+
+	tests := []struct {
+		action ActionType
+		want   bool
+	}{
+		{ActionAddLabel, false},
+		{ActionRemoveLabel, false},
+		{ActionComment, true},
+		{ActionClose, true},
+		{ActionLock, true},
+	}
+
+	for _, tt := range tests {
+		if got := executor.skipsAction(tt.action); got != tt.want {
+			t.Errorf("skipsAction(%s) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+
+	executor.SetDryRunPolicy(config.NewDryRunPolicy(true, config.DryRunConfig{}))
+	if !executor.skipsAction(ActionAddLabel) {
+		t.Error("skipsAction(add_label) = false under --dry-run, want true")
+	}
+}
+
+// requestLog answers every request with an empty JSON object and records
+// its method and path
+type requestLog []string
+
+func (l *requestLog) RoundTrip(req *http.Request) (*http.Response, error) {
+	*l = append(*l, req.Method+" "+req.URL.Path)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestExecutor_ExecuteHonorsDryRunPolicy(t *testing.T) {
+	var sent requestLog
+	client, err := github.NewClientWithTransport("test", &sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.Defaults.DryRun.Closes = true
+	executor := NewExecutorWithDelayedActions(client, cfg, nil, false)
+
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 9}
+	result := &Result{Actions: []Action{
+		{Type: ActionAddLabel, Label: "bug"},
+		{Type: ActionClose},
+		{Type: ActionLock, LockReason: github.LockResolved},
+	}}
+	if err := executor.Execute(context.Background(), issue, result); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := []string{"POST /repos/octo/app/issues/9/labels"}; !slices.Equal(sent, want) {
+		t.Errorf("sent %v, want only %v", sent, want)
+	}
+}
@@ -3,13 +3,16 @@ package triage
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/style"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
@@ -21,18 +24,24 @@ type Agent struct {
 	llm        llm.Provider
 	classifier *Classifier
 	quality    *QualityChecker
+	spam       *SpamChecker
 	duplicate  *DuplicateChecker
 	similarity *processor.SimilarityFinder
+	gh         *github.Client
 }
 
 // NewAgent creates a new triage agent
 func NewAgent(cfg *config.Config, llmProvider llm.Provider, similarity *processor.SimilarityFinder) *Agent {
+	duplicate := NewDuplicateChecker(&cfg.Triage.Duplicate)
+	duplicate.SetConfig(cfg)
+
 	return &Agent{
 		cfg:        cfg,
 		llm:        llmProvider,
-		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier),
-		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality),
-		duplicate:  NewDuplicateChecker(&cfg.Triage.Duplicate),
+		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier, cfg.Triage.MaxPromptBodyChars),
+		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality, cfg.Triage.MaxPromptBodyChars),
+		spam:       NewSpamChecker(llmProvider, &cfg.Triage.Spam, cfg.Triage.MaxPromptBodyChars),
+		duplicate:  duplicate,
 		similarity: similarity,
 	}
 }
@@ -42,10 +51,12 @@ func NewAgentWithGitHub(cfg *config.Config, llmProvider llm.Provider, similarity
 	return &Agent{
 		cfg:        cfg,
 		llm:        llmProvider,
-		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier),
-		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality),
+		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier, cfg.Triage.MaxPromptBodyChars),
+		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality, cfg.Triage.MaxPromptBodyChars),
+		spam:       NewSpamChecker(llmProvider, &cfg.Triage.Spam, cfg.Triage.MaxPromptBodyChars),
 		duplicate:  NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, gh, cfg),
 		similarity: similarity,
+		gh:         gh,
 	}
 }
 
@@ -55,6 +66,11 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 		Actions: []Action{},
 	}
 
+	// Spam short-circuits the rest of triage
+	if a.checkSpam(ctx, issue, result) {
+		return result, nil
+	}
+
 	// Step 0: Check transfer rules FIRST - if issue should be transferred, skip duplicate detection
 	// Transfer rules take precedence over duplicate detection to avoid closing issues that should be moved
 	repoConfig := a.cfg.GetRepoConfig(issue.Org, issue.Repo)
@@ -63,26 +79,33 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 		matcher := transfer.NewRuleMatcher(repoConfig.TransferRules)
 		if target, _ := matcher.Match(issue); target != "" {
 			// Transfer rule matches - skip duplicate detection to avoid closing before transfer
-			log.Printf("Transfer rule matches for issue #%d (target: %s), skipping duplicate detection", issue.Number, target)
+			logging.Debugf("Transfer rule matches for issue #%d (target: %s), skipping duplicate detection", issue.Number, target)
 			shouldSkipDuplicateCheck = true
 		}
 	}
+	if repoConfig != nil && len(repoConfig.AreaRules) > 0 {
+		if area := transfer.NewAreaMatcher(repoConfig.AreaRules).Match(issue); area != nil {
+			result.AreaTeam = area.Team
+		}
+	}
 
 	// Step 1: Find similar issues
 	similarIssues, err := a.similarity.FindSimilar(ctx, issue, true)
 	if err != nil {
-		log.Printf("Warning: failed to find similar issues: %v", err)
+		logging.Warnf("failed to find similar issues: %v", err)
 	}
 
 	// Step 2: Check for duplicates (only if no transfer rule matched)
-	if !shouldSkipDuplicateCheck && a.cfg.Triage.Duplicate.Enabled && len(similarIssues) > 0 {
-		dupResult := a.duplicate.Check(similarIssues)
+	candidates := a.duplicateCandidates(ctx, issue, similarIssues)
+	if !shouldSkipDuplicateCheck && a.cfg.Triage.Duplicate.Enabled && len(candidates) > 0 {
+		dupResult := a.duplicate.Check(candidates)
 		result.Duplicate = dupResult
 
 		if dupResult.IsDuplicate {
-			result.Actions = append(result.Actions, a.duplicate.GetActions(dupResult)...)
+			result.Actions = append(result.Actions, a.duplicate.GetActions(issue, dupResult)...)
 			// If it's a high-confidence duplicate, skip other analysis
 			if dupResult.ShouldClose {
+				sortActions(result.Actions)
 				return result, nil
 			}
 		}
@@ -92,7 +115,7 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 	if a.cfg.Triage.Classifier.Enabled {
 		labels, err := a.classifier.Classify(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: label classification failed: %v", err)
+			logging.Warnf("label classification failed: %v", err)
 		} else {
 			result.Labels = labels
 			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
@@ -103,15 +126,19 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 	if a.cfg.Triage.Quality.Enabled {
 		qualityResult, err := a.quality.Check(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: quality check failed: %v", err)
+			logging.Warnf("quality check failed: %v", err)
 		} else {
+			qualityResult.NeedsInfo = a.quality.NeedsInfo(qualityResult)
 			result.Quality = qualityResult
-			if a.quality.NeedsInfo(qualityResult) {
+			if qualityResult.NeedsInfo {
 				result.Actions = append(result.Actions, a.qualityToActions(qualityResult)...)
 			}
 		}
 	}
 
+	// Check community interest
+	result.Actions = append(result.Actions, a.popularityActions(ctx, issue)...)
+
 	// Step 5: Build and add triage summary comment
 	summaryComment := a.buildSummaryComment(result, similarIssues, issue)
 	result.Actions = append(result.Actions, Action{
@@ -120,9 +147,116 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 		Reason:  "triage summary",
 	})
 
+	sortActions(result.Actions)
 	return result, nil
 }
 
+// duplicateCandidates returns the matches to check for duplicates. With an
+// independent search it runs its own search at the auto-close threshold, so
+// duplicates below the similarity threshold are still found.
+func (a *Agent) duplicateCandidates(ctx context.Context, issue *models.Issue, similarIssues []vectordb.SearchResult) []vectordb.SearchResult {
+	dup := a.cfg.Triage.Duplicate
+	if !dup.Enabled || !dup.IndependentSearch || a.similarity == nil ||
+		dup.AutoCloseThreshold >= a.cfg.GetSearchThreshold(issue.Org, issue.Repo) {
+		return similarIssues
+	}
+
+	candidates, err := a.similarity.FindSimilarAbove(ctx, issue, dup.AutoCloseThreshold)
+	if err != nil {
+		logging.Warnf("duplicate search failed: %v", err)
+		return similarIssues
+	}
+	return candidates
+}
+
+// checkSpam runs the spam check when enabled and, for spam, fills result with
+// the spam actions. It reports whether the issue is spam.
+func (a *Agent) checkSpam(ctx context.Context, issue *models.Issue, result *Result) bool {
+	cfg := a.cfg.Triage.Spam
+	if !cfg.Enabled {
+		return false
+	}
+
+	spam, err := a.spam.Check(ctx, issue)
+	if err != nil {
+		logging.Warnf("spam check failed: %v", err)
+	}
+	if spam == nil || !spam.IsSpam {
+		return false
+	}
+
+	result.Spam = spam
+	result.Actions = append(result.Actions, Action{
+		Type:   ActionAddLabel,
+		Label:  cfg.Label,
+		Reason: spam.Reason,
+	})
+	// With delayed actions the executor schedules this close instead
+	if cfg.Close {
+		result.Actions = append(result.Actions, Action{
+			Type:   ActionClose,
+			Reason: "spam",
+		})
+	}
+	if cfg.Lock {
+		result.Actions = append(result.Actions, Action{
+			Type:       ActionLock,
+			Reason:     "spam",
+			LockReason: github.LockSpam,
+		})
+	}
+	sortActions(result.Actions)
+	return true
+}
+
+// popularityActions adds the popularity label when the issue's 👍 count
+// reaches the configured threshold
+func (a *Agent) popularityActions(ctx context.Context, issue *models.Issue) []Action {
+	cfg := a.cfg.Triage.Popularity
+	if cfg.Threshold <= 0 || a.gh == nil {
+		return nil
+	}
+
+	reactions, err := a.gh.GetIssueReactions(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		logging.Warnf("failed to fetch issue reactions: %v", err)
+		return nil
+	}
+
+	thumbsUp := 0
+	for _, r := range reactions {
+		if r.Content == "+1" {
+			thumbsUp++
+		}
+	}
+	if thumbsUp < cfg.Threshold {
+		return nil
+	}
+
+	return []Action{{
+		Type:   ActionAddLabel,
+		Label:  cfg.Label,
+		Reason: fmt.Sprintf("%d 👍 reactions", thumbsUp),
+	}}
+}
+
+// actionOrder ranks action types so results are reproducible: labels are
+// applied first, then comments are posted, and closing and locking happen last
+var actionOrder = map[ActionType]int{
+	ActionAddLabel:    0,
+	ActionRemoveLabel: 1,
+	ActionComment:     2,
+	ActionClose:       3,
+	ActionLock:        4,
+}
+
+// sortActions orders actions by type, keeping the relative order within a type
+func sortActions(actions []Action) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actionOrder[actions[i].Type] < actionOrder[actions[j].Type]
+	})
+}
+
 // labelsToActions converts label results to actions
 func (a *Agent) labelsToActions(labels []LabelResult) []Action {
 	var actions []Action
@@ -161,56 +295,67 @@ func (a *Agent) qualityToActions(qr *QualityResult) []Action {
 
 // buildSummaryComment creates a summary of triage actions
 func (a *Agent) buildSummaryComment(result *Result, similarIssues []vectordb.SearchResult, issue *models.Issue) string {
+	st := style.ForRepo(a.cfg, issue.Org, issue.Repo)
 	var sections []string
 
 	// Header
-	sections = append(sections, "## 🤖 Triage Summary\n")
+	sections = append(sections, st.Heading(2, "🤖", st.Text(locale.TriageHeading))+"\n")
 
 	// Labels section
 	if len(result.Labels) > 0 {
 		var labelLines []string
-		labelLines = append(labelLines, "### Labels Applied")
+		labelLines = append(labelLines, "### "+st.Text(locale.LabelsApplied))
 		for _, l := range result.Labels {
-			labelLines = append(labelLines, fmt.Sprintf("- `%s` (%.0f%% confidence) - %s", l.Label, l.Confidence*100, l.Reason))
+			labelLines = append(labelLines, fmt.Sprintf("- `%s` (%s) - %s", l.Label, st.Textf(locale.LabelConfidence, l.Confidence*100), l.Reason))
 		}
 		sections = append(sections, strings.Join(labelLines, "\n"))
 	} else {
-		sections = append(sections, "### Labels\nNo labels applied (no confident matches found)")
+		sections = append(sections, "### "+st.Text(locale.LabelsHeading)+"\n"+st.Text(locale.LabelsNone))
 	}
 
 	// Quality section
 	if result.Quality != nil {
-		qualityLine := fmt.Sprintf("### Quality Score: %.0f%%", result.Quality.Score*100)
+		qualityLine := "### " + st.Textf(locale.QualityScore, result.Quality.Score*100)
 		if len(result.Quality.Missing) > 0 {
-			qualityLine += fmt.Sprintf("\n⚠️ Missing: %s", strings.Join(result.Quality.Missing, ", "))
+			qualityLine += "\n" + st.Icon("⚠️") + st.Textf(locale.QualityMissing, strings.Join(result.Quality.Missing, ", "))
 		} else {
-			qualityLine += "\n✅ Issue is well-documented"
+			qualityLine += "\n" + st.Icon("✅") + st.Text(locale.QualityGood)
 		}
 		sections = append(sections, qualityLine)
 	}
 
-	// Similar issues section
+	// Similar issues section; matches below the display threshold or already
+	// linked by the author only feed duplicate detection
+	similarIssues = vectordb.AtLeast(similarIssues, a.cfg.GetDisplayThreshold(issue.Org, issue.Repo))
+	similarIssues = processor.FilterReferenced(similarIssues, issue)
 	if len(similarIssues) > 0 {
-		crossRepo := processor.HasCrossRepoResults(similarIssues, issue.Org, issue.Repo)
-		similarComment := processor.FormatSimilarityComment(similarIssues, crossRepo)
+		shown := vectordb.TrimResults(similarIssues, a.cfg.Defaults.MaxSimilarToShow)
+		shown = vectordb.SortForDisplay(shown, a.cfg.Defaults.SimilarSort)
+		crossRepo := processor.HasCrossRepoResults(shown, issue.Org, issue.Repo)
+		similarComment := processor.FormatSimilarityComment(shown, crossRepo, st)
 		if similarComment != "" {
-			sections = append(sections, "### Similar Issues\n"+similarComment)
+			sections = append(sections, "### "+st.Text(locale.SimilarIssues)+"\n"+similarComment)
 		}
 	} else {
-		sections = append(sections, "### Similar Issues\nNo similar issues found")
+		sections = append(sections, "### "+st.Text(locale.SimilarIssues)+"\n"+st.Text(locale.SimilarNone))
 	}
 
 	// Duplicate section
 	if result.Duplicate != nil && result.Duplicate.IsDuplicate {
-		dupLine := fmt.Sprintf("### ⚠️ Potential Duplicate\nSimilarity: %.0f%%", result.Duplicate.Similarity*100)
+		dupLine := st.Heading(3, "⚠️", st.Text(locale.PotentialDuplicate)) + "\n" + st.Textf(locale.Similarity, result.Duplicate.Similarity*100)
 		if result.Duplicate.Original != nil {
-			dupLine += fmt.Sprintf("\nOriginal: #%d - %s", result.Duplicate.Original.Number, result.Duplicate.Original.Title)
+			dupLine += "\n" + st.Textf(locale.Original, fmt.Sprintf("#%d - %s", result.Duplicate.Original.Number, result.Duplicate.Original.Title))
 		}
 		sections = append(sections, dupLine)
 	}
 
+	// Area owners section
+	if result.AreaTeam != "" {
+		sections = append(sections, st.Heading(3, "👥", st.Text(locale.AreaOwners))+"\n"+st.Textf(locale.AreaMention, transfer.TeamMention(result.AreaTeam)))
+	}
+
 	// Footer
-	sections = append(sections, "\n---\n<sub>🤖 Powered by [Simili Triage](https://github.com/Kavirubc/gh-simili)</sub>")
+	sections = append(sections, "\n"+st.Footer("Simili Triage"))
 
 	return strings.Join(sections, "\n\n")
 }
@@ -221,14 +366,21 @@ func (a *Agent) TriageWithSimilar(ctx context.Context, issue *models.Issue, simi
 		Actions: []Action{},
 	}
 
+	// Spam short-circuits the rest of triage
+	if a.checkSpam(ctx, issue, result) {
+		return result, nil
+	}
+
 	// Check for duplicates
-	if a.cfg.Triage.Duplicate.Enabled && len(similarIssues) > 0 {
-		dupResult := a.duplicate.Check(similarIssues)
+	candidates := a.duplicateCandidates(ctx, issue, similarIssues)
+	if a.cfg.Triage.Duplicate.Enabled && len(candidates) > 0 {
+		dupResult := a.duplicate.Check(candidates)
 		result.Duplicate = dupResult
 
 		if dupResult.IsDuplicate {
-			result.Actions = append(result.Actions, a.duplicate.GetActions(dupResult)...)
+			result.Actions = append(result.Actions, a.duplicate.GetActions(issue, dupResult)...)
 			if dupResult.ShouldClose {
+				sortActions(result.Actions)
 				return result, nil
 			}
 		}
@@ -238,7 +390,7 @@ func (a *Agent) TriageWithSimilar(ctx context.Context, issue *models.Issue, simi
 	if a.cfg.Triage.Classifier.Enabled {
 		labels, err := a.classifier.Classify(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: label classification failed: %v", err)
+			logging.Warnf("label classification failed: %v", err)
 		} else {
 			result.Labels = labels
 			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
@@ -249,15 +401,20 @@ func (a *Agent) TriageWithSimilar(ctx context.Context, issue *models.Issue, simi
 	if a.cfg.Triage.Quality.Enabled {
 		qualityResult, err := a.quality.Check(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: quality check failed: %v", err)
+			logging.Warnf("quality check failed: %v", err)
 		} else {
+			qualityResult.NeedsInfo = a.quality.NeedsInfo(qualityResult)
 			result.Quality = qualityResult
-			if a.quality.NeedsInfo(qualityResult) {
+			if qualityResult.NeedsInfo {
 				result.Actions = append(result.Actions, a.qualityToActions(qualityResult)...)
 			}
 		}
 	}
 
+	// Check community interest
+	result.Actions = append(result.Actions, a.popularityActions(ctx, issue)...)
+
+	sortActions(result.Actions)
 	return result, nil
 }
 
@@ -268,13 +425,18 @@ func (a *Agent) TriageWithoutDuplicates(ctx context.Context, issue *models.Issue
 		Actions: []Action{},
 	}
 
+	// Spam short-circuits the rest of triage
+	if a.checkSpam(ctx, issue, result) {
+		return result, nil
+	}
+
 	// Skip duplicate check - transfer takes precedence
 
 	// Classify labels
 	if a.cfg.Triage.Classifier.Enabled {
 		labels, err := a.classifier.Classify(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: label classification failed: %v", err)
+			logging.Warnf("label classification failed: %v", err)
 		} else {
 			result.Labels = labels
 			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
@@ -285,14 +447,19 @@ func (a *Agent) TriageWithoutDuplicates(ctx context.Context, issue *models.Issue
 	if a.cfg.Triage.Quality.Enabled {
 		qualityResult, err := a.quality.Check(ctx, issue)
 		if err != nil {
-			log.Printf("Warning: quality check failed: %v", err)
+			logging.Warnf("quality check failed: %v", err)
 		} else {
+			qualityResult.NeedsInfo = a.quality.NeedsInfo(qualityResult)
 			result.Quality = qualityResult
-			if a.quality.NeedsInfo(qualityResult) {
+			if qualityResult.NeedsInfo {
 				result.Actions = append(result.Actions, a.qualityToActions(qualityResult)...)
 			}
 		}
 	}
 
+	// Check community interest
+	result.Actions = append(result.Actions, a.popularityActions(ctx, issue)...)
+
+	sortActions(result.Actions)
 	return result, nil
 }
@@ -0,0 +1,108 @@
+package triage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// reactionsAPI serves an issue's reactions a page of 100 at a time
+type reactionsAPI struct {
+	reactions []string
+	status    int
+	pages     int
+}
+
+func (a *reactionsAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	a.pages++
+	status, body := http.StatusOK, `{"message": "error"}`
+	if a.status != 0 {
+		status = a.status
+	} else {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		start := min((page-1)*100, len(a.reactions))
+		end := min(start+100, len(a.reactions))
+		reactions := make([]github.Reaction, 0, end-start)
+		for _, content := range a.reactions[start:end] {
+			reactions = append(reactions, github.Reaction{Content: content})
+		}
+		data, _ := json.Marshal(reactions)
+		body = string(data)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func repeatReaction(content string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = content
+	}
+	return out
+}
+
+func TestAgent_PopularityActions(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		api       reactionsAPI
+		wantLabel bool
+		wantPages int
+	}{
+		{"disabled", 0, reactionsAPI{reactions: repeatReaction("+1", 50)}, false, 0},
+		{"below threshold", 10, reactionsAPI{reactions: append(repeatReaction("+1", 9), repeatReaction("heart", 20)...)}, false, 1},
+		{"at threshold", 10, reactionsAPI{reactions: append(repeatReaction("heart", 3), repeatReaction("+1", 10)...)}, true, 1},
+		{"counted across pages", 120, reactionsAPI{reactions: repeatReaction("+1", 150)}, true, 2},
+		{"reactions unavailable", 1, reactionsAPI{status: http.StatusForbidden}, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := tt.api
+			gh, err := github.NewClientWithTransport("test", &api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{}
+			cfg.Triage.Popularity = config.PopularityConfig{Threshold: tt.threshold, Label: "popular"}
+			a := &Agent{cfg: cfg, gh: gh}
+
+			actions := a.popularityActions(context.Background(), &models.Issue{Org: "octo", Repo: "app", Number: 3})
+			if got := len(actions) == 1 && actions[0].Type == ActionAddLabel && actions[0].Label == "popular"; got != tt.wantLabel {
+				t.Errorf("popularityActions() = %+v, want popular label = %v", actions, tt.wantLabel)
+			}
+			if api.pages != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d", api.pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestAgent_SummaryCommentAreaOwners(t *testing.T) {
+	cfg := &config.Config{}
+	a := &Agent{cfg: cfg}
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 3}
+
+	comment := a.buildSummaryComment(&Result{AreaTeam: "octo/billing"}, nil, issue)
+
+	heading := style.ForRepo(cfg, issue.Org, issue.Repo).Heading(3, "👥", "Area Owners")
+	if !strings.Contains(comment, heading+"\n") {
+		t.Errorf("summary comment = %q, want heading %q", comment, heading)
+	}
+	if !strings.Contains(comment, "@octo/billing") {
+		t.Errorf("summary comment = %q, want team mention", comment)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/profile"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
@@ -16,22 +20,42 @@ type Classifier struct {
 	llm           llm.Provider
 	labels        []config.LabelConfig
 	minConfidence float64
+	maxBodyChars  int
+	smoothing     float64 // Pseudo-count added to the keyword total when scoring
+	maxRuleConf   float64 // Cap on keyword-match confidence; 0 means none
 }
 
 // NewClassifier creates a new label classifier
-func NewClassifier(provider llm.Provider, cfg *config.ClassifierConfig) *Classifier {
+func NewClassifier(provider llm.Provider, cfg *config.ClassifierConfig, maxBodyChars int) *Classifier {
 	return &Classifier{
 		llm:           provider,
 		labels:        cfg.Labels,
 		minConfidence: cfg.MinConfidence,
+		maxBodyChars:  maxBodyChars,
+		smoothing:     cfg.RuleSmoothing,
+		maxRuleConf:   cfg.RuleMaxConfidence,
 	}
 }
 
+// minLLMTextLength is the combined title+body length below which an issue
+// is too sparse to send to the LLM; it tends to hallucinate labels from the title alone
+const minLLMTextLength = 30
+
+// isTooShortForLLM reports whether an issue has too little text for LLM analysis
+func isTooShortForLLM(issue *models.Issue) bool {
+	return len(strings.TrimSpace(issue.Title))+len(strings.TrimSpace(issue.Body)) < minLLMTextLength
+}
+
 // Classify analyzes an issue and suggests labels
 func (c *Classifier) Classify(ctx context.Context, issue *models.Issue) ([]LabelResult, error) {
 	// First try rule-based classification
 	ruleResults := c.classifyByRules(issue)
 
+	// Near-empty issues get rule-based labels only
+	if isTooShortForLLM(issue) {
+		return c.mergeResults(ruleResults, nil), nil
+	}
+
 	// Then use LLM for remaining labels
 	llmResults, err := c.classifyByLLM(ctx, issue, ruleResults)
 	if err != nil {
@@ -59,14 +83,14 @@ func (c *Classifier) classifyByRules(issue *models.Issue) []LabelResult {
 			}
 		}
 
-		if matchCount > 0 {
-			confidence := float64(matchCount) / float64(len(label.Keywords))
-			if confidence > 1.0 {
-				confidence = 1.0
-			}
+		minMatches := label.MinKeywordMatches
+		if minMatches < 1 {
+			minMatches = 1
+		}
+		if matchCount >= minMatches {
 			results = append(results, LabelResult{
 				Label:      label.Name,
-				Confidence: confidence,
+				Confidence: c.ruleConfidence(matchCount, len(label.Keywords)),
 				Reason:     "keyword match",
 			})
 		}
@@ -75,6 +99,19 @@ func (c *Classifier) classifyByRules(issue *models.Issue) []LabelResult {
 	return results
 }
 
+// ruleConfidence scores matchCount of keywords matched keywords, smoothed so
+// few-keyword labels don't trivially reach full confidence, and capped
+func (c *Classifier) ruleConfidence(matchCount, keywords int) float64 {
+	confidence := float64(matchCount) / (float64(keywords) + c.smoothing)
+	if confidence > 1.0 {
+		confidence = 1.0
+	}
+	if c.maxRuleConf > 0 && confidence > c.maxRuleConf {
+		confidence = c.maxRuleConf
+	}
+	return confidence
+}
+
 // classifyByLLM uses the LLM to classify labels
 func (c *Classifier) classifyByLLM(ctx context.Context, issue *models.Issue, existingResults []LabelResult) ([]LabelResult, error) {
 	// Build list of labels not yet classified by rules
@@ -107,10 +144,17 @@ Available Labels: %s
 
 Classify this issue. Return JSON array only, no other text.`,
 		issue.Title,
-		truncateText(issue.Body, 2000),
+		truncateText(issue.Body, c.maxBodyChars),
 		strings.Join(labelsToClassify, ", "))
 
+	// The author-selected native type is a strong hint for type-like labels
+	if issue.IssueType != "" {
+		prompt = fmt.Sprintf("Issue Type: %s\n\n%s", issue.IssueType, prompt)
+	}
+
+	stop := profile.Track(ctx, "llm_classify")
 	response, err := c.llm.CompleteWithSystem(ctx, system, prompt)
+	stop()
 	if err != nil {
 		return nil, fmt.Errorf("LLM classification failed: %w", err)
 	}
@@ -131,15 +175,17 @@ func (c *Classifier) parseClassificationResponse(response string, validLabels []
 		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
 	}
 
-	// Filter to only valid labels
-	validSet := make(map[string]bool)
+	// Filter to only valid labels, tolerating case and separator differences
+	// ("needs info" for needs-info) and mapping back to the canonical name
+	validSet := make(map[string]string)
 	for _, l := range validLabels {
-		validSet[l] = true
+		validSet[normalizeLabel(l)] = l
 	}
 
 	var filtered []LabelResult
 	for _, r := range results {
-		if validSet[r.Label] {
+		if canonical, ok := validSet[normalizeLabel(r.Label)]; ok {
+			r.Label = canonical
 			r.Reason = "LLM classification"
 			filtered = append(filtered, r)
 		}
@@ -148,6 +194,15 @@ func (c *Classifier) parseClassificationResponse(response string, validLabels []
 	return filtered, nil
 }
 
+// normalizeLabel lowercases a label and collapses runs of whitespace,
+// hyphens and underscores into a single space
+func normalizeLabel(label string) string {
+	fields := strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return r == '-' || r == '_' || unicode.IsSpace(r)
+	})
+	return strings.Join(fields, " ")
+}
+
 // mergeResults combines rule-based and LLM results
 func (c *Classifier) mergeResults(ruleResults, llmResults []LabelResult) []LabelResult {
 	resultMap := make(map[string]LabelResult)
@@ -171,18 +226,52 @@ func (c *Classifier) mergeResults(ruleResults, llmResults []LabelResult) []Label
 
 	var results []LabelResult
 	for _, r := range resultMap {
-		if r.Confidence >= c.minConfidence {
+		if r.Confidence >= c.thresholdFor(r.Label) {
 			results = append(results, r)
 		}
 	}
 
+	// Map iteration is random; sort so output is reproducible
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Confidence != results[j].Confidence {
+			return results[i].Confidence > results[j].Confidence
+		}
+		return results[i].Label < results[j].Label
+	})
+
 	return results
 }
 
-// truncateText limits text length
+// thresholdFor returns the minimum confidence for a label, preferring its override
+func (c *Classifier) thresholdFor(label string) float64 {
+	for _, l := range c.labels {
+		if l.Name == label && l.MinConfidence > 0 {
+			return l.MinConfidence
+		}
+	}
+	return c.minConfidence
+}
+
+// truncateText limits text to maxLen bytes, cutting at the last line or
+// word boundary so the model never sees a half word
 func truncateText(text string, maxLen int) string {
-	if len(text) <= maxLen {
+	if maxLen <= 0 || len(text) <= maxLen {
 		return text
 	}
-	return text[:maxLen] + "..."
+
+	// Back up to a rune start so a multi-byte character isn't split
+	end := maxLen
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	cut := text[:end]
+
+	// Prefer a line break, then a space, as long as it keeps most of the text
+	if i := strings.LastIndexByte(cut, '\n'); i > maxLen/2 {
+		cut = cut[:i]
+	} else if i := strings.LastIndexAny(cut, " \t"); i > maxLen/2 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " \t\r\n") + "..."
 }
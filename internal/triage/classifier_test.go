@@ -0,0 +1,215 @@
+package triage
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestClassifier_MergeResults_Order(t *testing.T) {
+	c := &Classifier{minConfidence: 0.5}
+
+	rules := []LabelResult{
+		{Label: "bug", Confidence: 0.9},
+		{Label: "ui", Confidence: 0.8},
+	}
+	llmResults := []LabelResult{
+		{Label: "docs", Confidence: 0.8},
+		{Label: "bug", Confidence: 0.7},
+		{Label: "api", Confidence: 0.8},
+		{Label: "question", Confidence: 0.3},
+	}
+
+	want := []string{"bug", "api", "docs", "ui"}
+	for i := 0; i < 20; i++ {
+		var got []string
+		for _, r := range c.mergeResults(rules, llmResults) {
+			got = append(got, r.Label)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("mergeResults() labels = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSortActions(t *testing.T) {
+	actions := []Action{
+		{Type: ActionClose},
+		{Type: ActionComment, Reason: "feedback"},
+		{Type: ActionAddLabel, Label: "duplicate"},
+		{Type: ActionComment, Reason: "summary"},
+		{Type: ActionAddLabel, Label: "bug"},
+	}
+
+	sortActions(actions)
+
+	want := []Action{
+		{Type: ActionAddLabel, Label: "duplicate"},
+		{Type: ActionAddLabel, Label: "bug"},
+		{Type: ActionComment, Reason: "feedback"},
+		{Type: ActionComment, Reason: "summary"},
+		{Type: ActionClose},
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("sortActions() = %+v, want %+v", actions, want)
+	}
+}
+
+func TestClassifier_MergeResults_PerLabelThreshold(t *testing.T) {
+	c := &Classifier{
+		minConfidence: 0.6,
+		labels: []config.LabelConfig{
+			{Name: "security", MinConfidence: 0.95},
+			{Name: "documentation", MinConfidence: 0.3},
+			{Name: "bug"},
+		},
+	}
+
+	results := c.mergeResults(nil, []LabelResult{
+		{Label: "security", Confidence: 0.9},
+		{Label: "documentation", Confidence: 0.4},
+		{Label: "bug", Confidence: 0.65},
+	})
+
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Label)
+	}
+	want := []string{"bug", "documentation"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeResults() labels = %v, want %v", got, want)
+	}
+}
+
+func TestClassifier_ParseResponse_FuzzyLabels(t *testing.T) {
+	c := &Classifier{}
+	response := `[{"label":"needs info","confidence":0.9},{"label":"Good_First-Issue","confidence":0.8},{"label":"unknown","confidence":0.9}]`
+
+	results, err := c.parseClassificationResponse(response, []string{"needs-info", "good first issue"})
+	if err != nil {
+		t.Fatalf("parseClassificationResponse() error = %v", err)
+	}
+
+	want := []string{"needs-info", "good first issue"}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, label := range want {
+		if results[i].Label != label {
+			t.Errorf("results[%d].Label = %q, want %q", i, results[i].Label, label)
+		}
+	}
+}
+
+func TestClassifier_ClassifyByRules_Smoothing(t *testing.T) {
+	labels := []config.LabelConfig{
+		{Name: "bug", Keywords: []string{"crash"}},
+		{Name: "perf", Keywords: []string{"slow", "latency", "memory"}, MinKeywordMatches: 2},
+	}
+	issue := &models.Issue{Title: "App is slow and crashes", Body: "Crash after a minute"}
+
+	c := NewClassifier(nil, &config.ClassifierConfig{Labels: labels, RuleSmoothing: 1, RuleMaxConfidence: 0.9}, 0)
+	results := c.classifyByRules(issue)
+
+	if len(results) != 1 || results[0].Label != "bug" {
+		t.Fatalf("classifyByRules() = %+v, want only bug (perf needs 2 keyword matches)", results)
+	}
+	if results[0].Confidence != 0.5 {
+		t.Errorf("bug confidence = %v, want 0.5 with smoothing 1", results[0].Confidence)
+	}
+
+	issue.Body = "High memory use"
+	results = c.classifyByRules(issue)
+	i := slices.IndexFunc(results, func(r LabelResult) bool { return r.Label == "perf" })
+	if i < 0 {
+		t.Fatalf("classifyByRules() = %+v, want perf once slow and memory both match", results)
+	}
+	if results[i].Confidence != 0.5 {
+		t.Errorf("perf confidence = %v, want 0.5 (2 of 3 keywords, smoothing 1)", results[i].Confidence)
+	}
+
+	unsmoothed := NewClassifier(nil, &config.ClassifierConfig{Labels: labels, RuleMaxConfidence: 0.9}, 0)
+	if got := unsmoothed.ruleConfidence(1, 1); got != 0.9 {
+		t.Errorf("ruleConfidence(1, 1) = %v, want the 0.9 cap", got)
+	}
+}
+
+func TestIsTooShortForLLM(t *testing.T) {
+	tests := []struct {
+		title, body string
+		want        bool
+	}{
+		{"Crash", "", true},
+		{"  Crash  ", "   \n\n  ", true},
+		{"Bug", "It crashes sometimes.", true},
+		{"Export crashes", "Exporting a project with no files crashes the CLI.", false},
+	}
+
+	for _, tt := range tests {
+		if got := isTooShortForLLM(&models.Issue{Title: tt.title, Body: tt.body}); got != tt.want {
+			t.Errorf("isTooShortForLLM(%q, %q) = %v, want %v", tt.title, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestClassifier_SkipsLLMForNearEmptyIssue(t *testing.T) {
+	provider := &fakeLLM{response: `{"labels": [{"label": "feature", "confidence": 0.9}]}`}
+	c := NewClassifier(provider, &config.ClassifierConfig{
+		MinConfidence: 0.5,
+		Labels:        []config.LabelConfig{{Name: "bug", Keywords: []string{"crash"}}, {Name: "feature"}},
+	}, 0)
+
+	results, err := c.Classify(context.Background(), &models.Issue{Title: "Crash", Body: ""})
+	if err != nil {
+		t.Fatalf("Classify() error = %v", err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("LLM called %d times for a near-empty issue, want 0", provider.calls)
+	}
+	if len(results) != 1 || results[0].Label != "bug" {
+		t.Errorf("Classify() = %+v, want only the keyword label bug", results)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		maxLen int
+		want   string
+	}{
+		{"fits", "short text", 20, "short text"},
+		{"no limit", "short text", 0, "short text"},
+		{"word boundary", "the quick brown fox jumps", 18, "the quick brown..."},
+		{"line boundary", "first line here\nsecond line", 20, "first line here..."},
+		{"no boundary in the kept half", "abcdefghij klmnop", 8, "abcdefgh..."},
+		{"multi-byte rune", "héllo wörld", 8, "héllo..."},
+	}
+
+	for _, tt := range tests {
+		if got := truncateText(tt.text, tt.maxLen); got != tt.want {
+			t.Errorf("truncateText(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClassifier_PromptHonorsMaxBodyChars(t *testing.T) {
+	body := strings.Repeat("word ", 100) + "TAIL"
+	provider := &fakeLLM{response: `{"labels": []}`}
+	c := NewClassifier(provider, &config.ClassifierConfig{MinConfidence: 0.5, Labels: []config.LabelConfig{{Name: "bug"}}}, 40)
+
+	if _, err := c.Classify(context.Background(), &models.Issue{Title: "Exporter crashes on save", Body: body}); err != nil {
+		t.Fatalf("Classify() error = %v", err)
+	}
+	if provider.calls != 1 {
+		t.Fatalf("LLM called %d times, want 1", provider.calls)
+	}
+	if strings.Contains(provider.prompt, "TAIL") || !strings.Contains(provider.prompt, "word word...") {
+		t.Errorf("prompt should carry the body cut at a word boundary:\n%s", provider.prompt)
+	}
+}
@@ -6,11 +6,22 @@ import (
 
 // Result contains the complete triage analysis
 type Result struct {
-	Labels      []LabelResult    `json:"labels,omitempty"`
-	Quality     *QualityResult   `json:"quality,omitempty"`
-	Duplicate   *DuplicateResult `json:"duplicate,omitempty"`
-	Actions     []Action         `json:"actions"`
-	Error       string           `json:"error,omitempty"`
+	Labels    []LabelResult    `json:"labels,omitempty"`
+	Quality   *QualityResult   `json:"quality,omitempty"`
+	Duplicate *DuplicateResult `json:"duplicate,omitempty"`
+	Spam      *SpamResult      `json:"spam,omitempty"`
+	Actions   []Action         `json:"actions"`
+	AreaTeam  string           `json:"area_team,omitempty"` // Owning team from area rules
+	Error     string           `json:"error,omitempty"`
+}
+
+// SchedulesClose reports whether a close of this result is scheduled rather
+// than immediate when delayed actions are enabled: duplicates and spam are
+func (r *Result) SchedulesClose() bool {
+	if r == nil {
+		return false
+	}
+	return (r.Duplicate != nil && r.Duplicate.IsDuplicate) || (r.Spam != nil && r.Spam.IsSpam)
 }
 
 // LabelResult contains classification result for a single label
@@ -22,17 +33,25 @@ type LabelResult struct {
 
 // QualityResult contains issue quality assessment
 type QualityResult struct {
-	Score    float64  `json:"score"`
-	Missing  []string `json:"missing,omitempty"`
-	Feedback string   `json:"feedback,omitempty"`
+	Score     float64  `json:"score"`
+	Missing   []string `json:"missing,omitempty"`
+	Feedback  string   `json:"feedback,omitempty"`
+	NeedsInfo bool     `json:"needs_info,omitempty"` // Score fell below min_score
+}
+
+// SpamResult contains the spam verdict for an issue
+type SpamResult struct {
+	IsSpam     bool    `json:"is_spam"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason,omitempty"`
 }
 
 // DuplicateResult contains duplicate detection result
 type DuplicateResult struct {
-	IsDuplicate bool           `json:"is_duplicate"`
-	Similarity  float64        `json:"similarity"`
-	Original    *models.Issue  `json:"original,omitempty"`
-	ShouldClose bool           `json:"should_close"`
+	IsDuplicate bool          `json:"is_duplicate"`
+	Similarity  float64       `json:"similarity"`
+	Original    *models.Issue `json:"original,omitempty"`
+	ShouldClose bool          `json:"should_close"`
 }
 
 // Action represents an action to take on the issue
@@ -41,6 +60,8 @@ type Action struct {
 	Label   string     `json:"label,omitempty"`
 	Comment string     `json:"comment,omitempty"`
 	Reason  string     `json:"reason,omitempty"`
+	// LockReason is GitHub's lock reason for ActionLock (off-topic, too heated, resolved, spam)
+	LockReason string `json:"lock_reason,omitempty"`
 }
 
 // ActionType represents the type of action
@@ -51,10 +72,11 @@ const (
 	ActionRemoveLabel ActionType = "remove_label"
 	ActionComment     ActionType = "comment"
 	ActionClose       ActionType = "close"
+	ActionLock        ActionType = "lock"
 )
 
 // IssueContext contains all information about an issue for triage
 type IssueContext struct {
-	Issue        *models.Issue   `json:"issue"`
+	Issue         *models.Issue  `json:"issue"`
 	SimilarIssues []models.Issue `json:"similar_issues,omitempty"`
 }
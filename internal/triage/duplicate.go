@@ -2,13 +2,17 @@ package triage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/locale"
+	"github.com/Kavirubc/gh-simili/internal/logging"
 	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/style"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
@@ -17,10 +21,12 @@ import (
 type DuplicateChecker struct {
 	autoCloseThreshold float64
 	requireConfirm     bool
+	mentionAuthor      bool
 	gh                 *github.Client
 	pendingManager     *pending.Manager
 	cfg                *config.Config
-	dryRun             bool
+	style              style.Style
+	dryRun             config.DryRunPolicy // Consulted for closes
 }
 
 // NewDuplicateChecker creates a new duplicate checker
@@ -28,6 +34,7 @@ func NewDuplicateChecker(cfg *config.DuplicateConfig) *DuplicateChecker {
 	return &DuplicateChecker{
 		autoCloseThreshold: cfg.AutoCloseThreshold,
 		requireConfirm:     cfg.RequireConfirm,
+		mentionAuthor:      cfg.MentionOriginalAuthor,
 	}
 }
 
@@ -36,10 +43,12 @@ func NewDuplicateCheckerWithDelayedActions(cfg *config.DuplicateConfig, gh *gith
 	return &DuplicateChecker{
 		autoCloseThreshold: cfg.AutoCloseThreshold,
 		requireConfirm:     cfg.RequireConfirm,
+		mentionAuthor:      cfg.MentionOriginalAuthor,
 		gh:                 gh,
 		pendingManager:     pending.NewManager(gh, fullCfg),
 		cfg:                fullCfg,
-		dryRun:             false,
+		style:              style.ForDefaults(&fullCfg.Defaults),
+		dryRun:             config.NewDryRunPolicy(false, fullCfg.Defaults.DryRun),
 	}
 }
 
@@ -48,13 +57,22 @@ func NewDuplicateCheckerWithDelayedActionsAndDryRun(cfg *config.DuplicateConfig,
 	return &DuplicateChecker{
 		autoCloseThreshold: cfg.AutoCloseThreshold,
 		requireConfirm:     cfg.RequireConfirm,
+		mentionAuthor:      cfg.MentionOriginalAuthor,
 		gh:                 gh,
 		pendingManager:     pending.NewManager(gh, fullCfg),
 		cfg:                fullCfg,
-		dryRun:             dryRun,
+		style:              style.ForDefaults(&fullCfg.Defaults),
+		dryRun:             config.NewDryRunPolicy(dryRun, fullCfg.Defaults.DryRun),
 	}
 }
 
+// SetConfig renders the checker's comments in each repository's style and
+// language. Checkers built with delayed actions already have the config.
+func (d *DuplicateChecker) SetConfig(cfg *config.Config) {
+	d.cfg = cfg
+	d.style = style.ForDefaults(&cfg.Defaults)
+}
+
 // Check analyzes similar issues to detect duplicates
 func (d *DuplicateChecker) Check(similarIssues []vectordb.SearchResult) *DuplicateResult {
 	if len(similarIssues) == 0 {
@@ -64,11 +82,15 @@ func (d *DuplicateChecker) Check(similarIssues []vectordb.SearchResult) *Duplica
 		}
 	}
 
-	// Find the highest similarity open issue
+	// Find the best open match. Discussions and redacted matches are shown
+	// as related but never treated as the original.
 	var bestMatch *vectordb.SearchResult
 	for i := range similarIssues {
 		r := &similarIssues[i]
-		if r.Issue.State == "open" && (bestMatch == nil || r.Score > bestMatch.Score) {
+		if r.Issue.IsDiscussion() || r.Redacted {
+			continue
+		}
+		if r.Issue.State == "open" && d.better(r, bestMatch) {
 			bestMatch = r
 		}
 	}
@@ -77,7 +99,7 @@ func (d *DuplicateChecker) Check(similarIssues []vectordb.SearchResult) *Duplica
 	if bestMatch == nil {
 		for i := range similarIssues {
 			r := &similarIssues[i]
-			if bestMatch == nil || r.Score > bestMatch.Score {
+			if !r.Issue.IsDiscussion() && !r.Redacted && d.better(r, bestMatch) {
 				bestMatch = r
 			}
 		}
@@ -101,42 +123,82 @@ func (d *DuplicateChecker) Check(similarIssues []vectordb.SearchResult) *Duplica
 	}
 }
 
-// FormatDuplicateComment creates a comment for duplicate issues
-func (d *DuplicateChecker) FormatDuplicateComment(result *DuplicateResult, autoClose bool) string {
+// styleFor returns the checker's style in the language configured for org/repo
+func (d *DuplicateChecker) styleFor(org, repo string) style.Style {
+	if d.cfg == nil {
+		return d.style
+	}
+	return d.style.Localized(d.cfg.GetLanguage(org, repo))
+}
+
+// better reports whether r should replace best as the original: a match at
+// the auto-close threshold beats one below it, then the higher rank score
+// wins, so same_repo_boost picks the local original in close calls without
+// changing which issues count as duplicates
+func (d *DuplicateChecker) better(r, best *vectordb.SearchResult) bool {
+	if best == nil {
+		return true
+	}
+	if rDup, bestDup := r.Score >= d.autoCloseThreshold, best.Score >= d.autoCloseThreshold; rDup != bestDup {
+		return rDup
+	}
+	return r.RankScore() > best.RankScore()
+}
+
+// FormatDuplicateComment creates a comment for a duplicate of issue
+func (d *DuplicateChecker) FormatDuplicateComment(issue *models.Issue, result *DuplicateResult, autoClose bool) string {
 	if result.Original == nil {
 		return ""
 	}
 
+	st := d.styleFor(issue.Org, issue.Repo)
 	var sb strings.Builder
 
 	if autoClose {
-		sb.WriteString("🔒 This issue has been automatically closed as a duplicate.\n\n")
+		sb.WriteString(st.Icon("🔒") + st.Text(locale.DuplicateClosed) + "\n\n")
 	} else {
-		sb.WriteString("⚠️ This issue appears to be a duplicate.\n\n")
+		sb.WriteString(st.Icon("⚠️") + st.Text(locale.DuplicateFlagged) + "\n\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("**Original issue:** [#%d - %s](%s)\n",
+	sb.WriteString(st.Textf(locale.OriginalIssue, fmt.Sprintf("[#%d - %s](%s)",
 		result.Original.Number,
 		result.Original.Title,
-		result.Original.URL))
+		result.Original.URL)) + "\n")
 
-	sb.WriteString(fmt.Sprintf("**Similarity:** %.0f%%\n\n", result.Similarity*100))
+	sb.WriteString(st.Textf(locale.DuplicateSimilarity, result.Similarity*100) + "\n\n")
+
+	if d.mentionAuthor {
+		if mention := OriginalAuthorMention(result.Original); mention != "" {
+			sb.WriteString(mention + "\n\n")
+		}
+	}
 
 	if autoClose {
-		sb.WriteString("If you believe this is not a duplicate, please comment and we will reopen it.\n\n")
+		sb.WriteString(st.Text(locale.DuplicateReopen) + "\n\n")
 	} else {
-		sb.WriteString("Please review the linked issue. If it addresses your concern, ")
-		sb.WriteString("consider closing this issue and following the original.\n\n")
+		sb.WriteString(st.Text(locale.DuplicateReview) + "\n\n")
 	}
 
-	sb.WriteString("---\n")
-	sb.WriteString("<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>")
+	sb.WriteString(st.Footer("Simili"))
 
 	return sb.String()
 }
 
-// GetActions returns actions to take for a duplicate issue
-func (d *DuplicateChecker) GetActions(result *DuplicateResult) []Action {
+// OriginalAuthorMention returns a line pinging the original issue's author,
+// or an empty string when the author is unknown
+func OriginalAuthorMention(original *models.Issue) string {
+	if original == nil {
+		return ""
+	}
+	author := strings.TrimPrefix(strings.TrimSpace(original.Author), "@")
+	if author == "" {
+		return ""
+	}
+	return fmt.Sprintf("cc @%s, you reported the original issue and may want to weigh in.", author)
+}
+
+// GetActions returns actions to take when issue is a duplicate
+func (d *DuplicateChecker) GetActions(issue *models.Issue, result *DuplicateResult) []Action {
 	if !result.IsDuplicate || result.Original == nil {
 		return nil
 	}
@@ -149,7 +211,7 @@ func (d *DuplicateChecker) GetActions(result *DuplicateResult) []Action {
 		},
 		{
 			Type:    ActionComment,
-			Comment: d.FormatDuplicateComment(result, result.ShouldClose),
+			Comment: d.FormatDuplicateComment(issue, result, result.ShouldClose),
 			Reason:  "notify author of duplicate",
 		},
 	}
@@ -208,10 +270,45 @@ func (d *DuplicateChecker) ScheduleClose(ctx context.Context, issue *models.Issu
 	return d.pendingManager.ScheduleClose(ctx, issue, result.Original.URL, commentID, delayHours)
 }
 
+// ScheduleSpamClose schedules a delayed close of spam as not planned,
+// posting a warning comment maintainers can react to
+func (d *DuplicateChecker) ScheduleSpamClose(ctx context.Context, issue *models.Issue, spam *SpamResult) error {
+	if d.pendingManager == nil || d.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+	if d.dryRun.Closes() {
+		return nil
+	}
+
+	delayHours := d.cfg.Defaults.DelayedActions.DelayHours
+	expiresAt := time.Now().Add(time.Duration(delayHours) * time.Hour)
+
+	// No target: the close is not planned rather than a duplicate
+	action := &pending.PendingAction{
+		Type:        pending.ActionTypeClose,
+		Org:         issue.Org,
+		Repo:        issue.Repo,
+		IssueNumber: issue.Number,
+		ScheduledAt: time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+
+	comment, err := d.formatDelayedSpamCloseComment(spam, expiresAt, d.cfg.Defaults.DelayedActions, action)
+	if err != nil {
+		return fmt.Errorf("failed to format warning comment: %w", err)
+	}
+	commentID, err := d.gh.PostCommentWithID(ctx, issue.Org, issue.Repo, issue.Number, comment)
+	if err != nil {
+		return fmt.Errorf("failed to post warning comment: %w", err)
+	}
+
+	return d.pendingManager.ScheduleClose(ctx, issue, "", commentID, delayHours)
+}
+
 // ScheduleCloseSilent schedules a delayed close without posting a comment
 // Used when the comment is already posted (e.g. by unified processor)
 func (d *DuplicateChecker) ScheduleCloseSilent(ctx context.Context, issue *models.Issue, originalIssueURL string, commentID int) error {
-	if d.dryRun {
+	if d.dryRun.Closes() {
 		return nil
 	}
 
@@ -243,29 +340,37 @@ func (d *DuplicateChecker) ProcessPendingClose(ctx context.Context, action *pend
 		action.Org,
 		action.Repo,
 		action.CommentID,
-		d.cfg.Defaults.DelayedActions.ApproveReaction,
-		d.cfg.Defaults.DelayedActions.CancelReaction,
+		d.cfg.Defaults.DelayedActions.Approvals(),
+		d.cfg.Defaults.DelayedActions.Cancellations(),
+		action.ExtendReaction(d.cfg.Defaults.DelayedActions.ExtendReaction),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to check reactions: %w", err)
 	}
 
-	if d.dryRun {
+	if d.dryRun.Closes() {
 		return nil
 	}
 
 	if decision == "cancel" {
-		// User cancelled, add potential-duplicate label instead
 		if err := d.pendingManager.Cancel(ctx, action); err != nil {
 			return err
 		}
+		if !action.ClosesAsDuplicate() {
+			return d.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, formatSpamCloseCancelledComment(d.styleFor(action.Org, action.Repo)))
+		}
+		// User cancelled, add potential-duplicate label instead
 		if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"potential-duplicate"}); err != nil {
 			return err
 		}
-		cancelComment := formatCloseCancelledComment()
+		cancelComment := formatCloseCancelledComment(d.styleFor(action.Org, action.Repo))
 		return d.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
 	}
 
+	if decision == "extend" {
+		return d.pendingManager.Extend(ctx, action, d.cfg.Defaults.DelayedActions.DelayHours)
+	}
+
 	if decision == "approve" && d.cfg.Defaults.DelayedActions.ExecuteOnApprove {
 		// User approved, close immediately
 		return d.executeClose(ctx, action)
@@ -281,23 +386,27 @@ func (d *DuplicateChecker) ProcessPendingClose(ctx context.Context, action *pend
 
 // executeClose performs the actual close
 func (d *DuplicateChecker) executeClose(ctx context.Context, action *pending.PendingAction) error {
-	if d.dryRun {
+	if d.dryRun.Closes() {
 		return nil
 	}
 
-	// Add duplicate label
-	if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"duplicate"}); err != nil {
-		return err
-	}
+	if action.ClosesAsDuplicate() {
+		// Add duplicate label
+		if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"duplicate"}); err != nil {
+			return err
+		}
 
-	// Close issue
-	if err := d.gh.CloseIssue(ctx, action.Org, action.Repo, action.IssueNumber, "not_planned"); err != nil {
+		// Close issue, linking the original
+		if err := d.gh.MarkAsDuplicate(ctx, action.Org, action.Repo, action.IssueNumber, action.Target); err != nil {
+			return err
+		}
+	} else if err := d.gh.CloseIssue(ctx, action.Org, action.Repo, action.IssueNumber, "not_planned"); err != nil {
 		return err
 	}
 
 	// Remove pending label
 	if err := d.pendingManager.Cancel(ctx, action); err != nil {
-		fmt.Printf("Warning: failed to remove pending-close label from %s/%s#%d: %v\n", action.Org, action.Repo, action.IssueNumber, err)
+		logging.Warnf("failed to remove pending-close label from %s/%s#%d: %v", action.Org, action.Repo, action.IssueNumber, err)
 	}
 
 	return nil
@@ -305,48 +414,137 @@ func (d *DuplicateChecker) executeClose(ctx context.Context, action *pending.Pen
 
 // formatDelayedCloseComment creates a warning comment for delayed close
 func (d *DuplicateChecker) formatDelayedCloseComment(result *DuplicateResult, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
-	deadline := expiresAt.Format("2006-01-02 15:04 MST")
+	deadline := expiresAt.Format(pending.DeadlineLayout)
 
 	metadata, err := pending.FormatPendingActionMetadata(action)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf(`⚠️ **This issue will be closed as a duplicate in %d hours**
+	st := d.styleFor(action.Org, action.Repo)
+	original := fmt.Sprintf("[#%d - %s](%s)", result.Original.Number, result.Original.Title, result.Original.URL)
+	return fmt.Sprintf(`%s**%s**
 
-**Original issue:** [#%d - %s](%s)
-**Similarity:** %.0f%%
+%s
+%s
 
-**React to this comment:**
-- 👍 (%s) to approve and proceed with closing
-- 👎 (%s) to cancel and add potential-duplicate label instead
+**%s**
+- %s %s
+- %s %s
 
-**Deadline**: %s
+**%s**: %s
 
-If no reaction is provided, the issue will be closed automatically.
+%s
 
 %s
 
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`,
-		cfg.DelayHours,
-		result.Original.Number,
-		result.Original.Title,
-		result.Original.URL,
-		result.Similarity*100,
-		cfg.ApproveReaction,
-		cfg.CancelReaction,
-		deadline,
+%s`,
+		st.Icon("⚠️"),
+		st.Textf(locale.CloseIn, cfg.DelayHours),
+		st.Textf(locale.OriginalIssue, original),
+		st.Textf(locale.DuplicateSimilarity, result.Similarity*100),
+		st.Text(locale.ReactPrompt),
+		st.Reaction("👍", cfg.ApproveReaction), st.Text(locale.CloseApprove),
+		st.Reaction("👎", cfg.CancelReaction), st.Text(locale.CloseCancel),
+		st.Text(locale.Deadline), deadline,
+		st.Text(locale.CloseAuto),
 		metadata,
+		st.Footer("Simili"),
 	), nil
 }
 
+// formatDelayedSpamCloseComment creates a warning comment for a delayed spam close
+func (d *DuplicateChecker) formatDelayedSpamCloseComment(spam *SpamResult, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
+	deadline := expiresAt.Format(pending.DeadlineLayout)
+
+	metadata, err := pending.FormatPendingActionMetadata(action)
+	if err != nil {
+		return "", err
+	}
+
+	st := d.styleFor(action.Org, action.Repo)
+	return fmt.Sprintf(`%s**%s**
+
+%s
+
+**%s**
+- %s %s
+- %s %s
+
+**%s**: %s
+
+%s
+
+%s
+
+%s`,
+		st.Icon("⚠️"),
+		st.Textf(locale.SpamCloseIn, cfg.DelayHours),
+		st.Textf(locale.SpamConfidence, spam.Confidence*100),
+		st.Text(locale.ReactPrompt),
+		st.Reaction("👍", cfg.ApproveReaction), st.Text(locale.CloseApprove),
+		st.Reaction("👎", cfg.CancelReaction), st.Text(locale.SpamCloseCancel),
+		st.Text(locale.Deadline), deadline,
+		st.Text(locale.CloseAuto),
+		metadata,
+		st.Footer("Simili"),
+	), nil
+}
+
+// formatSpamCloseCancelledComment creates a cancellation comment for a spam close
+func formatSpamCloseCancelledComment(st style.Style) string {
+	return st.Icon("✅") + st.Text(locale.CloseCancelled) + "\n\n" + st.Text(locale.IssueKept) + "\n\n" + st.Footer("Simili")
+}
+
 // formatCloseCancelledComment creates a cancellation comment
-func formatCloseCancelledComment() string {
-	return `✅ Auto-close has been cancelled based on your reaction.
+func formatCloseCancelledComment(st style.Style) string {
+	return st.Icon("✅") + st.Text(locale.CloseCancelled) + "\n\n" + st.Text(locale.CloseKeptLabeled) + "\n\n" + st.Footer("Simili")
+}
 
-The issue will remain open and has been labeled as ` + "`potential-duplicate`" + ` for maintainer review.
+// duplicateLinkMarker tags back-link comments on the original issue so each
+// duplicate is announced there only once
+func duplicateLinkMarker(issue *models.Issue) string {
+	return fmt.Sprintf("<!-- simili-duplicate-link: %s/%s#%d -->", issue.Org, issue.Repo, issue.Number)
+}
+
+// FormatDuplicateLinkComment creates the back-link posted on the original issue
+func FormatDuplicateLinkComment(issue *models.Issue, similarity float64, st style.Style) string {
+	ref := fmt.Sprintf("%s/%s#%d", issue.Org, issue.Repo, issue.Number)
+	return st.Icon("🔗") + st.Textf(locale.DuplicateLinked, ref, similarity*100) + "\n" + duplicateLinkMarker(issue)
+}
+
+// LinkOriginal posts a back-link to issue on the original issue so its
+// watchers learn about the duplicate. Originals the token cannot comment on
+// (other orgs, locked or missing issues) are skipped rather than failing.
+func (d *DuplicateChecker) LinkOriginal(ctx context.Context, issue *models.Issue, result *DuplicateResult) error {
+	if result == nil || !result.IsDuplicate || result.Original == nil || d.gh == nil {
+		return nil
+	}
+	original := result.Original
 
----
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`
+	comments, err := d.gh.ListComments(ctx, original.Org, original.Repo, original.Number)
+	if err != nil {
+		if errors.Is(err, github.ErrForbidden) || errors.Is(err, github.ErrNotFound) {
+			logging.Infof("Cannot link #%d from %s/%s#%d: %v", issue.Number, original.Org, original.Repo, original.Number, err)
+			return nil
+		}
+		return err
+	}
+
+	marker := duplicateLinkMarker(issue)
+	for _, c := range comments {
+		if strings.Contains(c.Body, marker) {
+			return nil // Already linked
+		}
+	}
+
+	body := FormatDuplicateLinkComment(issue, result.Similarity, d.styleFor(original.Org, original.Repo))
+	if err := d.gh.PostComment(ctx, original.Org, original.Repo, original.Number, body); err != nil {
+		if errors.Is(err, github.ErrForbidden) || errors.Is(err, github.ErrNotFound) {
+			logging.Infof("Cannot link #%d from %s/%s#%d: %v", issue.Number, original.Org, original.Repo, original.Number, err)
+			return nil
+		}
+		return err
+	}
+	return nil
 }
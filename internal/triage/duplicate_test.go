@@ -0,0 +1,155 @@
+package triage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/style"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestFormatDuplicateComment_Localized(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "docs-es", Language: "es"}}}
+	d := NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, nil, cfg)
+	result := &DuplicateResult{IsDuplicate: true, Similarity: 0.93, Original: &models.Issue{Number: 7, Title: "Crash"}}
+
+	got := d.FormatDuplicateComment(&models.Issue{Org: "org", Repo: "docs-es", Number: 9}, result, true)
+	for _, want := range []string{"Esta incidencia se ha cerrado automáticamente", "**Similitud:** 93%"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("comment missing %q:\n%s", want, got)
+		}
+	}
+
+	got = d.FormatDuplicateComment(&models.Issue{Org: "org", Repo: "app", Number: 9}, result, false)
+	if !strings.Contains(got, "This issue appears to be a duplicate.") {
+		t.Errorf("repo without a language should stay in English:\n%s", got)
+	}
+}
+
+func TestDuplicateChecker_CheckPrefersBoostedOriginal(t *testing.T) {
+	d := NewDuplicateChecker(&config.DuplicateConfig{AutoCloseThreshold: 0.85})
+	local := vectordb.SearchResult{Issue: models.Issue{Org: "octo", Repo: "app", Number: 2, State: "open"}, Score: 0.88, Boost: 0.03}
+	remote := vectordb.SearchResult{Issue: models.Issue{Org: "octo", Repo: "lib", Number: 1, State: "open"}, Score: 0.90}
+	weak := vectordb.SearchResult{Issue: models.Issue{Org: "octo", Repo: "app", Number: 3, State: "open"}, Score: 0.84, Boost: 0.10}
+
+	tests := []struct {
+		name    string
+		results []vectordb.SearchResult
+		want    int
+	}{
+		{"boost wins a close call", []vectordb.SearchResult{remote, local}, 2},
+		{"boost alone does not make a duplicate", []vectordb.SearchResult{remote, weak}, 1},
+	}
+
+	for _, tt := range tests {
+		got := d.Check(tt.results)
+		if !got.IsDuplicate || got.Original.Number != tt.want {
+			t.Errorf("%s: Check() = duplicate %v of #%d, want #%d", tt.name, got.IsDuplicate, got.Original.Number, tt.want)
+		}
+	}
+}
+
+// commentsAPI fakes the comment endpoints of a single issue
+type commentsAPI struct {
+	existing   []string // Bodies of comments already on the issue
+	listStatus int
+	postStatus int
+	posted     []string
+}
+
+func (a *commentsAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, "{}"
+	switch req.Method {
+	case http.MethodGet:
+		comments := make([]map[string]any, len(a.existing))
+		for i, b := range a.existing {
+			comments[i] = map[string]any{"id": i + 1, "body": b}
+		}
+		data, _ := json.Marshal(comments)
+		status, body = a.listStatus, string(data)
+	case http.MethodPost:
+		var payload map[string]string
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &payload)
+		a.posted = append(a.posted, payload["body"])
+		status = a.postStatus
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status >= 400 {
+		body = `{"message": "error"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestDuplicateChecker_LinkOriginal(t *testing.T) {
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 9}
+	duplicate := &DuplicateResult{IsDuplicate: true, Similarity: 0.91, Original: &models.Issue{Org: "octo", Repo: "app", Number: 2}}
+
+	tests := []struct {
+		name       string
+		api        commentsAPI
+		result     *DuplicateResult
+		wantPosted bool
+		wantErr    bool
+	}{
+		{"links the new duplicate", commentsAPI{existing: []string{"Same here"}}, duplicate, true, false},
+		{"already linked", commentsAPI{existing: []string{FormatDuplicateLinkComment(issue, 0.9, style.New(""))}}, duplicate, false, false},
+		{"not a duplicate", commentsAPI{}, &DuplicateResult{Original: duplicate.Original}, false, false},
+		{"original not readable", commentsAPI{listStatus: http.StatusForbidden}, duplicate, false, false},
+		{"original locked", commentsAPI{postStatus: http.StatusNotFound}, duplicate, true, false},
+		{"post fails", commentsAPI{postStatus: http.StatusUnprocessableEntity}, duplicate, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := tt.api
+			gh, err := github.NewClientWithTransport("test", &api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{}
+			d := NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, gh, cfg)
+
+			err = d.LinkOriginal(context.Background(), issue, tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LinkOriginal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if posted := len(api.posted) > 0; posted != tt.wantPosted {
+				t.Fatalf("posted %q, want posted = %v", api.posted, tt.wantPosted)
+			}
+			if tt.wantPosted && !strings.Contains(api.posted[0], duplicateLinkMarker(issue)) {
+				t.Errorf("back-link %q lacks the duplicate marker", api.posted[0])
+			}
+		})
+	}
+}
+
+func TestDuplicateChecker_RepoLanguage(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "octo", Repo: "app", Enabled: true, Language: "de"}}}
+	d := NewDuplicateChecker(&cfg.Triage.Duplicate)
+	d.SetConfig(cfg)
+
+	issue := &models.Issue{Org: "octo", Repo: "app", Number: 9}
+	result := &DuplicateResult{IsDuplicate: true, Similarity: 0.91, Original: &models.Issue{Org: "octo", Repo: "app", Number: 2}}
+
+	if got := d.FormatDuplicateComment(issue, result, false); !strings.Contains(got, "Dieses Issue scheint ein Duplikat zu sein.") {
+		t.Errorf("FormatDuplicateComment() = %q, want the German notice", got)
+	}
+	if got := FormatDuplicateLinkComment(issue, 0.91, d.styleFor("octo", "app")); !strings.Contains(got, "Ein mögliches Duplikat wurde eröffnet: octo/app#9 (91% ähnlich)") {
+		t.Errorf("FormatDuplicateLinkComment() = %q, want the German back-link", got)
+	}
+}
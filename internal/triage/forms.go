@@ -0,0 +1,95 @@
+package triage
+
+import (
+	"strings"
+)
+
+// formNoResponse is what GitHub issue forms render for an unanswered field
+const formNoResponse = "_No response_"
+
+// formSection is one "### Heading" block of an issue-form body
+type formSection struct {
+	Heading string
+	Content string
+}
+
+// parseFormSections splits an issue-form body into its "### Heading" sections.
+// Returns nil for bodies that weren't produced by an issue form: forms always
+// open with a heading, so a free-form report that uses one further down is
+// left alone.
+func parseFormSections(body string) []formSection {
+	var sections []formSection
+	var current *formSection
+	var content []string
+
+	flush := func() {
+		if current != nil {
+			current.Content = strings.TrimSpace(strings.Join(content, "\n"))
+			sections = append(sections, *current)
+		}
+		content = nil
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "### ") {
+			flush()
+			current = &formSection{Heading: strings.TrimSpace(strings.TrimPrefix(trimmed, "### "))}
+			continue
+		}
+		if current == nil {
+			if trimmed != "" {
+				return nil
+			}
+			continue
+		}
+		content = append(content, line)
+	}
+	flush()
+
+	return sections
+}
+
+// isEmpty reports whether the section was left unanswered
+func (s formSection) isEmpty() bool {
+	return s.Content == "" || s.Content == formNoResponse
+}
+
+// formAnswers joins the answered sections, dropping the template headings and
+// unanswered fields so "_No response_" placeholders don't read as detail
+func formAnswers(sections []formSection) string {
+	var answers []string
+	for _, s := range sections {
+		if !s.isEmpty() {
+			answers = append(answers, s.Content)
+		}
+	}
+	return strings.Join(answers, "\n\n")
+}
+
+// answerText returns the author's answers of an issue-form body, or the body
+// itself when it isn't a form
+func answerText(body string) string {
+	if sections := parseFormSections(body); sections != nil {
+		return formAnswers(sections)
+	}
+	return body
+}
+
+// missingRequiredSections returns required headings that are absent or unanswered
+func missingRequiredSections(sections []formSection, required []string) []string {
+	answered := make(map[string]bool, len(sections))
+	for _, s := range sections {
+		if !s.isEmpty() {
+			answered[strings.ToLower(s.Heading)] = true
+		}
+	}
+
+	var missing []string
+	for _, heading := range required {
+		if !answered[strings.ToLower(strings.TrimSpace(heading))] {
+			missing = append(missing, heading)
+		}
+	}
+	return missing
+}
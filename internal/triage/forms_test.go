@@ -0,0 +1,83 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+const formBody = `### Describe the bug
+
+The app crashes on startup.
+
+### Steps to reproduce
+
+_No response_
+
+### Version
+
+v1.2.3
+`
+
+func TestParseFormSections(t *testing.T) {
+	sections := parseFormSections(formBody)
+	if len(sections) != 3 {
+		t.Fatalf("got %d sections, want 3", len(sections))
+	}
+	if sections[0].Heading != "Describe the bug" || sections[0].Content != "The app crashes on startup." {
+		t.Errorf("unexpected first section: %+v", sections[0])
+	}
+	if !sections[1].isEmpty() {
+		t.Errorf("expected %q section to be empty", sections[1].Heading)
+	}
+
+	if got := parseFormSections("Just a plain issue body"); got != nil {
+		t.Errorf("plain body parsed as form: %+v", got)
+	}
+
+	if got := parseFormSections("\n### Version\n\nv1.2.3"); len(got) != 1 {
+		t.Errorf("form with leading blank line = %+v, want 1 section", got)
+	}
+}
+
+func TestQualityChecker_FreeFormWithHeading(t *testing.T) {
+	body := "Saving a draft fails with a timeout after the latest upgrade.\n\n" +
+		"### Logs\n\ncontext deadline exceeded while writing the draft"
+	if got := parseFormSections(body); got != nil {
+		t.Fatalf("free-form body parsed as form: %+v", got)
+	}
+
+	q := &QualityChecker{requiredSections: []string{"Steps to reproduce", "Version"}}
+	result := q.basicQualityCheck(&models.Issue{Title: "Draft saving times out", Body: body})
+	if len(result.Missing) != 0 || result.Score != 1 {
+		t.Errorf("basicQualityCheck() = %+v, want no missing sections", result)
+	}
+}
+
+func TestMissingRequiredSections(t *testing.T) {
+	sections := parseFormSections(formBody)
+
+	got := missingRequiredSections(sections, []string{"steps to reproduce", "Version", "Logs"})
+	want := []string{"steps to reproduce", "Logs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingRequiredSections() = %v, want %v", got, want)
+	}
+}
+
+func TestFormAnswers(t *testing.T) {
+	got := formAnswers(parseFormSections(formBody))
+	want := "The app crashes on startup.\n\nv1.2.3"
+	if got != want {
+		t.Errorf("formAnswers() = %q, want %q", got, want)
+	}
+}
+
+func TestAnswerText(t *testing.T) {
+	if got, want := answerText(formBody), formAnswers(parseFormSections(formBody)); got != want {
+		t.Errorf("answerText(form) = %q, want %q", got, want)
+	}
+	if got := answerText("Just a plain issue body"); got != "Just a plain issue body" {
+		t.Errorf("answerText(plain) = %q, want the body unchanged", got)
+	}
+}
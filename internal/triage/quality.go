@@ -5,25 +5,38 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/profile"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
+// needsInfoFeedback is posted when rule-based checks alone decide an issue needs more info
+const needsInfoFeedback = "Thanks for the report! This issue doesn't include enough detail for us to act on yet. " +
+	"Please describe what you expected, what happened instead, and how to reproduce it."
+
 // QualityChecker assesses issue quality
 type QualityChecker struct {
-	llm            llm.Provider
-	minScore       float64
-	needsInfoLabel string
+	llm                  llm.Provider
+	minScore             float64
+	needsInfoLabel       string
+	autoNeedsInfoPhrases []string
+	requiredSections     []string
+	maxBodyChars         int
 }
 
 // NewQualityChecker creates a new quality checker
-func NewQualityChecker(provider llm.Provider, cfg *config.QualityConfig) *QualityChecker {
+func NewQualityChecker(provider llm.Provider, cfg *config.QualityConfig, maxBodyChars int) *QualityChecker {
 	return &QualityChecker{
-		llm:            provider,
-		minScore:       cfg.MinScore,
-		needsInfoLabel: cfg.NeedsInfoLabel,
+		llm:                  provider,
+		minScore:             cfg.MinScore,
+		needsInfoLabel:       cfg.NeedsInfoLabel,
+		autoNeedsInfoPhrases: lowerAll(cfg.AutoNeedsInfoPhrases),
+		requiredSections:     cfg.RequiredSections,
+		maxBodyChars:         maxBodyChars,
 	}
 }
 
@@ -32,6 +45,20 @@ func (q *QualityChecker) Check(ctx context.Context, issue *models.Issue) (*Quali
 	// Basic checks first
 	basicResult := q.basicQualityCheck(issue)
 
+	// Near-empty issues always need more info; don't spend tokens on them
+	if isTooShortForLLM(issue) {
+		basicResult.Score = 0
+		basicResult.Feedback = needsInfoFeedback
+		return basicResult, nil
+	}
+
+	// Configured low-effort phrases deterministically need more info
+	if containsAnyPhrase(strings.ToLower(answerText(issue.Body)), q.autoNeedsInfoPhrases) {
+		basicResult.Score = 0
+		basicResult.Feedback = needsInfoFeedback
+		return basicResult, nil
+	}
+
 	// Use LLM for deeper analysis
 	llmResult, err := q.llmQualityCheck(ctx, issue)
 	if err != nil {
@@ -49,8 +76,18 @@ func (q *QualityChecker) basicQualityCheck(issue *models.Issue) *QualityResult {
 		Missing: []string{},
 	}
 
+	// Issue forms pad the body with headings and placeholders; judge the answers
+	body := issue.Body
+	if sections := parseFormSections(issue.Body); sections != nil {
+		body = formAnswers(sections)
+		for _, heading := range missingRequiredSections(sections, q.requiredSections) {
+			result.Score -= 0.2
+			result.Missing = append(result.Missing, heading)
+		}
+	}
+
 	// Check for minimum body length
-	bodyLen := len(strings.TrimSpace(issue.Body))
+	bodyLen := len(strings.TrimSpace(body))
 	if bodyLen < 50 {
 		result.Score -= 0.3
 		result.Missing = append(result.Missing, "detailed description")
@@ -64,7 +101,7 @@ func (q *QualityChecker) basicQualityCheck(issue *models.Issue) *QualityResult {
 	}
 
 	// Common quality indicators
-	bodyLower := strings.ToLower(issue.Body)
+	bodyLower := strings.ToLower(body)
 
 	// Check for reproduction steps (for bugs)
 	if containsAny(bodyLower, []string{"bug", "error", "crash", "broken", "not working"}) {
@@ -101,10 +138,12 @@ Existing Labels: %s
 
 Assess this issue's quality. Return JSON only.`,
 		issue.Title,
-		truncateText(issue.Body, 2000),
+		truncateText(issue.Body, q.maxBodyChars),
 		strings.Join(issue.Labels, ", "))
 
+	stop := profile.Track(ctx, "llm_quality")
 	response, err := q.llm.CompleteWithSystem(ctx, system, prompt)
+	stop()
 	if err != nil {
 		return nil, fmt.Errorf("LLM quality check failed: %w", err)
 	}
@@ -141,18 +180,14 @@ func (q *QualityChecker) mergeResults(basic, llm *QualityResult) *QualityResult
 	// Average the scores
 	score := (basic.Score + llm.Score) / 2
 
-	// Combine missing items (deduplicated)
+	// Combine missing items (deduplicated, rule-based findings first)
 	missingSet := make(map[string]bool)
-	for _, m := range basic.Missing {
-		missingSet[m] = true
-	}
-	for _, m := range llm.Missing {
-		missingSet[m] = true
-	}
-
 	var missing []string
-	for m := range missingSet {
-		missing = append(missing, m)
+	for _, m := range append(basic.Missing, llm.Missing...) {
+		if !missingSet[m] {
+			missingSet[m] = true
+			missing = append(missing, m)
+		}
 	}
 
 	return &QualityResult{
@@ -172,6 +207,37 @@ func (q *QualityChecker) GetNeedsInfoLabel() string {
 	return q.needsInfoLabel
 }
 
+// containsAnyPhrase checks if text contains any of the phrases as whole
+// words, so "please help" doesn't match "please helpers"
+func containsAnyPhrase(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], phrase)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(phrase)
+			if !wordRuneBefore(text, start) && !wordRuneAfter(text, end) {
+				return true
+			}
+			offset = start + 1
+		}
+	}
+	return false
+}
+
+// wordRuneBefore reports whether the rune ending at i is a letter or digit
+func wordRuneBefore(text string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return i > 0 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// wordRuneAfter reports whether the rune starting at i is a letter or digit
+func wordRuneAfter(text string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return i < len(text) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
 // containsAny checks if text contains any of the keywords
 func containsAny(text string, keywords []string) bool {
 	for _, kw := range keywords {
@@ -181,3 +247,14 @@ func containsAny(text string, keywords []string) bool {
 	}
 	return false
 }
+
+// lowerAll returns a lowercased copy of ss
+func lowerAll(ss []string) []string {
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
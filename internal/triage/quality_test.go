@@ -0,0 +1,103 @@
+package triage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// fakeLLM answers every completion with response
+type fakeLLM struct {
+	response string
+	calls    int
+	prompt   string // The last prompt sent
+}
+
+func (f *fakeLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	return f.CompleteWithSystem(ctx, "", prompt)
+}
+
+func (f *fakeLLM) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	f.calls++
+	f.prompt = prompt
+	return f.response, nil
+}
+
+func (f *fakeLLM) Close() error { return nil }
+
+func TestContainsAnyPhrase(t *testing.T) {
+	phrases := []string{"please help", "doesn't work"}
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"please help", true},
+		{"it doesn't work.", true},
+		{"(please help!)", true},
+		{"please helpers welcome", false},
+		{"this doesn't workaround the bug", false},
+		{"pleasehelp", false},
+		{"please helpful reviewers, please help", true},
+	}
+
+	for _, tt := range tests {
+		if got := containsAnyPhrase(tt.text, phrases); got != tt.want {
+			t.Errorf("containsAnyPhrase(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestQualityChecker_AutoNeedsInfoPhrases(t *testing.T) {
+	detailed := "The exporter crashes when the output directory is missing. Steps to reproduce: run export --out /nope."
+
+	tests := []struct {
+		name      string
+		body      string
+		wantLLM   bool
+		wantScore float64
+	}{
+		{"phrase forces needs-info", "It doesn't work, please help me with this as soon as you can thanks", false, 0},
+		{"phrase inside a longer word", detailed + " Any helpers welcome, please helpers!", true, 0.9},
+		{"phrase only in a form heading", "### Please help us reproduce\n\n" + detailed + "\n\n### Version\n\n1.2.3", true, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeLLM{response: `{"score": 0.9, "missing": [], "feedback": ""}`}
+			q := NewQualityChecker(provider, &config.QualityConfig{
+				MinScore:             0.5,
+				AutoNeedsInfoPhrases: []string{"Please help", "doesn't work"},
+			}, 0)
+
+			got, err := q.Check(context.Background(), &models.Issue{Title: "Exporter crash on missing dir", Body: tt.body})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if (provider.calls > 0) != tt.wantLLM {
+				t.Errorf("LLM called %d times, want called = %v", provider.calls, tt.wantLLM)
+			}
+			if !tt.wantLLM && got.Score != tt.wantScore {
+				t.Errorf("Score = %v, want %v", got.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestQualityChecker_NearEmptyNeedsInfo(t *testing.T) {
+	provider := &fakeLLM{response: `{"score": 0.9, "missing": [], "feedback": ""}`}
+	q := NewQualityChecker(provider, &config.QualityConfig{MinScore: 0.5}, 0)
+
+	result, err := q.Check(context.Background(), &models.Issue{Title: "Broken", Body: "help"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("LLM called %d times for a near-empty issue, want 0", provider.calls)
+	}
+	if result.Score != 0 || result.Feedback != needsInfoFeedback {
+		t.Errorf("Check() = %+v, want score 0 with the needs-info feedback", result)
+	}
+}
@@ -0,0 +1,138 @@
+package triage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/profile"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// spamKeywordConfidence is the confidence each matched spam keyword adds
+const spamKeywordConfidence = 0.5
+
+// SpamChecker flags spam issues using keyword heuristics, falling back to
+// the LLM when the keywords alone aren't conclusive
+type SpamChecker struct {
+	llm          llm.Provider
+	threshold    float64
+	keywords     []string
+	maxBodyChars int
+}
+
+// NewSpamChecker creates a new spam checker
+func NewSpamChecker(provider llm.Provider, cfg *config.SpamConfig, maxBodyChars int) *SpamChecker {
+	return &SpamChecker{
+		llm:          provider,
+		threshold:    cfg.Threshold,
+		keywords:     lowerAll(cfg.Keywords),
+		maxBodyChars: maxBodyChars,
+	}
+}
+
+// Check classifies an issue as spam or not
+func (s *SpamChecker) Check(ctx context.Context, issue *models.Issue) (*SpamResult, error) {
+	result := s.keywordCheck(issue)
+	if result.IsSpam || s.llm == nil || isTooShortForLLM(issue) {
+		return result, nil
+	}
+
+	llmResult, err := s.llmCheck(ctx, issue)
+	if err != nil {
+		return result, err
+	}
+	if llmResult.Confidence > result.Confidence {
+		result = llmResult
+	}
+	result.IsSpam = result.Confidence >= s.threshold
+	return result, nil
+}
+
+// keywordCheck scores an issue by the number of distinct spam keywords it contains
+func (s *SpamChecker) keywordCheck(issue *models.Issue) *SpamResult {
+	text := strings.ToLower(issue.Title + "\n" + issue.Body)
+
+	var matched []string
+	for _, kw := range s.keywords {
+		if strings.Contains(text, kw) {
+			matched = append(matched, kw)
+		}
+	}
+
+	result := &SpamResult{}
+	if len(matched) == 0 {
+		return result
+	}
+	result.Confidence = float64(len(matched)) * spamKeywordConfidence
+	if result.Confidence > 1 {
+		result.Confidence = 1
+	}
+	result.Reason = fmt.Sprintf("matched spam keywords: %s", strings.Join(matched, ", "))
+	result.IsSpam = result.Confidence >= s.threshold
+	return result
+}
+
+// llmCheck asks the LLM whether an issue is spam
+func (s *SpamChecker) llmCheck(ctx context.Context, issue *models.Issue) (*SpamResult, error) {
+	system := `You are a spam filter for a GitHub issue tracker. Spam includes advertising, SEO links,
+crypto or investment promotion, and text unrelated to the project. Genuine bug reports,
+questions and feature requests are never spam, even when short or badly written.
+Respond with JSON containing:
+- "spam": true or false
+- "confidence": 0-1 confidence that the issue is spam
+- "reason": one short sentence`
+
+	prompt := fmt.Sprintf(`Issue Title: %s
+
+Issue Body:
+%s
+
+Is this issue spam? Return JSON only.`,
+		issue.Title,
+		truncateText(issue.Body, s.maxBodyChars))
+
+	stop := profile.Track(ctx, "llm_spam")
+	response, err := s.llm.CompleteWithSystem(ctx, system, prompt)
+	stop()
+	if err != nil {
+		return nil, fmt.Errorf("LLM spam check failed: %w", err)
+	}
+
+	return parseSpamResponse(response)
+}
+
+// parseSpamResponse parses the LLM response
+func parseSpamResponse(response string) (*SpamResult, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var parsed struct {
+		Spam       bool    `json:"spam"`
+		Confidence float64 `json:"confidence"`
+		Reason     string  `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(response), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
+	}
+
+	// The confidence is that of the issue being spam, whatever the verdict
+	confidence := parsed.Confidence
+	if !parsed.Spam && confidence > 0.5 {
+		confidence = 1 - confidence
+	}
+	if confidence < 0 {
+		confidence = 0
+	}
+	if confidence > 1 {
+		confidence = 1
+	}
+
+	return &SpamResult{Confidence: confidence, Reason: parsed.Reason}, nil
+}
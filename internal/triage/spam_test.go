@@ -0,0 +1,136 @@
+package triage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestSpamChecker_Keywords(t *testing.T) {
+	s := NewSpamChecker(nil, &config.SpamConfig{
+		Threshold: 0.8,
+		Keywords:  []string{"Airdrop", "casino", "free tokens"},
+	}, 0)
+
+	tests := []struct {
+		name       string
+		issue      *models.Issue
+		wantSpam   bool
+		wantConfid float64
+	}{
+		{
+			name:       "no keywords",
+			issue:      &models.Issue{Title: "Crash on startup", Body: "Steps to reproduce: run the binary"},
+			wantConfid: 0,
+		},
+		{
+			name:       "one keyword stays below threshold",
+			issue:      &models.Issue{Title: "Casino night", Body: "join us"},
+			wantConfid: 0.5,
+		},
+		{
+			name:       "two keywords",
+			issue:      &models.Issue{Title: "AIRDROP live", Body: "claim free tokens now"},
+			wantSpam:   true,
+			wantConfid: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Check(context.Background(), tt.issue)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got.IsSpam != tt.wantSpam || got.Confidence != tt.wantConfid {
+				t.Errorf("Check() = %+v, want spam=%v confidence=%v", got, tt.wantSpam, tt.wantConfid)
+			}
+		})
+	}
+}
+
+func TestParseSpamResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     float64
+	}{
+		{"spam", `{"spam": true, "confidence": 0.9, "reason": "ad"}`, 0.9},
+		{"not spam", "```json\n{\"spam\": false, \"confidence\": 0.95}\n```", 0.05},
+		{"clamped", `{"spam": true, "confidence": 1.5}`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSpamResponse(tt.response)
+			if err != nil {
+				t.Fatalf("parseSpamResponse() error = %v", err)
+			}
+			if diff := got.Confidence - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("parseSpamResponse() confidence = %v, want %v", got.Confidence, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentCheckSpam_Lock(t *testing.T) {
+	issue := &models.Issue{Title: "airdrop", Body: "casino"}
+
+	for _, lock := range []bool{false, true} {
+		cfg := &config.Config{}
+		cfg.Triage.Spam = config.SpamConfig{Enabled: true, Label: "spam", Threshold: 0.8, Lock: lock, Keywords: []string{"airdrop", "casino"}}
+		a := &Agent{cfg: cfg, spam: NewSpamChecker(nil, &cfg.Triage.Spam, 0)}
+
+		result := &Result{}
+		if !a.checkSpam(context.Background(), issue, result) {
+			t.Fatalf("checkSpam() = false, want true")
+		}
+
+		var locked *Action
+		for i := range result.Actions {
+			if result.Actions[i].Type == ActionLock {
+				locked = &result.Actions[i]
+			}
+		}
+		if lock && (locked == nil || locked.LockReason != "spam") {
+			t.Errorf("lock enabled: actions = %+v, want a spam lock", result.Actions)
+		}
+		if !lock && locked != nil {
+			t.Errorf("lock disabled: got unexpected lock action %+v", locked)
+		}
+	}
+}
+
+func TestAgentCheckSpam_Close(t *testing.T) {
+	issue := &models.Issue{Title: "airdrop", Body: "casino"}
+
+	for _, delayed := range []bool{false, true} {
+		cfg := &config.Config{}
+		cfg.Defaults.DelayedActions.Enabled = delayed
+		cfg.Triage.Spam = config.SpamConfig{Enabled: true, Label: "spam", Threshold: 0.8, Close: true, Keywords: []string{"airdrop", "casino"}}
+		a := &Agent{cfg: cfg, spam: NewSpamChecker(nil, &cfg.Triage.Spam, 0)}
+
+		result := &Result{}
+		if !a.checkSpam(context.Background(), issue, result) {
+			t.Fatalf("checkSpam() = false, want true")
+		}
+
+		closes := 0
+		for _, action := range result.Actions {
+			if action.Type == ActionClose {
+				closes++
+			}
+		}
+		if closes != 1 {
+			t.Errorf("delayed=%v: actions = %+v, want one close", delayed, result.Actions)
+		}
+
+		// The executor schedules the close instead when delayed actions are on
+		executor := NewExecutorWithDelayedActions(nil, cfg, &DuplicateChecker{}, false)
+		if got := executor.schedulesClose(result); got != delayed {
+			t.Errorf("delayed=%v: schedulesClose() = %v", delayed, got)
+		}
+	}
+}
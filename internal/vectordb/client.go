@@ -1,16 +1,32 @@
 package vectordb
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/qdrant/go-client/qdrant"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client wraps Qdrant operations
 type Client struct {
 	qdrant *qdrant.Client
+
+	// ensureGroup collapses concurrent EnsureCollection calls per collection
+	ensureGroup singleflight.Group
+
+	// views enables named title/body vectors; nil stores a single vector
+	views *ViewWeights
+
+	// Storage options applied to new collections
+	onDisk       bool
+	quantization config.QuantizationConfig
+
+	// Payload label cap; see capLabels
+	maxLabels      int
+	priorityLabels []string
 }
 
 // NewClient creates a new Qdrant client
@@ -30,7 +46,13 @@ func NewClient(cfg *config.QdrantConfig) (*Client, error) {
 		return nil, fmt.Errorf("failed to connect to Qdrant: %w", err)
 	}
 
-	return &Client{qdrant: client}, nil
+	return &Client{
+		qdrant:         client,
+		onDisk:         cfg.OnDisk,
+		quantization:   cfg.Quantization,
+		maxLabels:      cfg.MaxIndexedLabels,
+		priorityLabels: cfg.PriorityLabels,
+	}, nil
 }
 
 // parseHostPort extracts host and port from URL string
@@ -53,6 +75,15 @@ func parseHostPort(url string) (string, int) {
 	return url, 6334
 }
 
+// HealthCheck pings Qdrant and returns the server version
+func (c *Client) HealthCheck(ctx context.Context) (string, error) {
+	reply, err := c.qdrant.HealthCheck(ctx)
+	if err != nil {
+		return "", fmt.Errorf("qdrant health check failed: %w", err)
+	}
+	return reply.GetVersion(), nil
+}
+
 // Close closes the connection
 func (c *Client) Close() error {
 	if c.qdrant != nil {
@@ -61,7 +92,22 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// CollectionName returns the collection name for an org
-func CollectionName(org string) string {
-	return fmt.Sprintf("%s_issues", org)
+// Collection scopes
+const (
+	CollectionScopeOrg  = "org"  // One collection shared by all of an org's repos
+	CollectionScopeRepo = "repo" // One collection per repository
+)
+
+// CollectionName returns the collection name for an org, or for a single
+// repository when the scope is CollectionScopeRepo, namespaced by the
+// configured collection prefix
+func CollectionName(cfg *config.QdrantConfig, org, repo string) string {
+	name := fmt.Sprintf("%s_issues", org)
+	if cfg.CollectionScope == CollectionScopeRepo {
+		name = fmt.Sprintf("%s_%s_issues", org, repo)
+	}
+	if cfg.CollectionPrefix != "" {
+		name = cfg.CollectionPrefix + "_" + name
+	}
+	return name
 }
@@ -3,14 +3,59 @@ package vectordb
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Kavirubc/gh-simili/internal/logging"
 )
 
 const vectorDimensions = 768
 
-// EnsureCollection creates collection if it doesn't exist
+// EnsureCollection creates collection if it doesn't exist.
+// Concurrent calls for the same collection share a single create attempt.
 func (c *Client) EnsureCollection(ctx context.Context, name string) error {
+	_, err, _ := c.ensureGroup.Do(name, func() (interface{}, error) {
+		return nil, c.ensureCollection(ctx, name)
+	})
+	return err
+}
+
+// vectorParams describes one stored vector, honoring the on-disk setting
+func (c *Client) vectorParams() *qdrant.VectorParams {
+	params := &qdrant.VectorParams{
+		Size:     vectorDimensions,
+		Distance: qdrant.Distance_Cosine,
+	}
+	if c.onDisk {
+		params.OnDisk = qdrant.PtrOf(true)
+	}
+	return params
+}
+
+// quantizationConfig returns the scalar quantization for new collections,
+// or nil when it is off
+func (c *Client) quantizationConfig() *qdrant.QuantizationConfig {
+	q := c.quantization
+	if !q.Scalar {
+		return nil
+	}
+	scalar := &qdrant.ScalarQuantization{Type: qdrant.QuantizationType_Int8}
+	if q.Quantile > 0 {
+		scalar.Quantile = qdrant.PtrOf(float32(q.Quantile))
+	}
+	if q.AlwaysRAM {
+		scalar.AlwaysRam = qdrant.PtrOf(true)
+	}
+	return qdrant.NewQuantizationScalar(scalar)
+}
+
+// ensureCollection performs the existence check and creation
+func (c *Client) ensureCollection(ctx context.Context, name string) error {
 	// Check if collection exists
 	exists, err := c.qdrant.CollectionExists(ctx, name)
 	if err != nil {
@@ -22,14 +67,24 @@ func (c *Client) EnsureCollection(ctx context.Context, name string) error {
 	}
 
 	// Create collection
+	vectors := qdrant.NewVectorsConfig(c.vectorParams())
+	if c.MultiVector() {
+		vectors = qdrant.NewVectorsConfigMap(map[string]*qdrant.VectorParams{
+			VectorTitle: c.vectorParams(),
+			VectorBody:  c.vectorParams(),
+		})
+	}
+
 	err = c.qdrant.CreateCollection(ctx, &qdrant.CreateCollection{
-		CollectionName: name,
-		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
-			Size:     vectorDimensions,
-			Distance: qdrant.Distance_Cosine,
-		}),
+		CollectionName:     name,
+		VectorsConfig:      vectors,
+		QuantizationConfig: c.quantizationConfig(),
 	})
 	if err != nil {
+		// Another process may have created it between our check and create
+		if isAlreadyExists(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to create collection: %w", err)
 	}
 
@@ -43,6 +98,8 @@ func (c *Client) EnsureCollection(ctx context.Context, name string) error {
 		{"state", qdrant.FieldType_FieldTypeKeyword},
 		{"number", qdrant.FieldType_FieldTypeInteger},
 		{"labels", qdrant.FieldType_FieldTypeKeyword},
+		{"issue_type", qdrant.FieldType_FieldTypeKeyword},
+		{"milestone", qdrant.FieldType_FieldTypeKeyword},
 	}
 
 	for _, idx := range indexes {
@@ -53,18 +110,82 @@ func (c *Client) EnsureCollection(ctx context.Context, name string) error {
 		})
 		if err != nil {
 			// Index creation failure is not fatal
-			fmt.Printf("Warning: failed to create index for %s: %v\n", idx.field, err)
+			logging.Warnf("failed to create index for %s: %v", idx.field, err)
 		}
 	}
 
 	return nil
 }
 
+// isAlreadyExists reports whether a Qdrant error means the collection already exists
+func isAlreadyExists(err error) bool {
+	if status.Code(err) == codes.AlreadyExists {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "already exists")
+}
+
 // DeleteCollection removes a collection
 func (c *Client) DeleteCollection(ctx context.Context, name string) error {
 	return c.qdrant.DeleteCollection(ctx, name)
 }
 
+// VectorShape describes a collection's vectors: either one unnamed vector of
+// Size dimensions or the sizes of its named vectors
+type VectorShape struct {
+	Size  int
+	Named map[string]int
+}
+
+// CollectionShape returns the vector layout configured for a collection
+func (c *Client) CollectionShape(ctx context.Context, name string) (VectorShape, error) {
+	info, err := c.qdrant.GetCollectionInfo(ctx, name)
+	if err != nil {
+		return VectorShape{}, fmt.Errorf("failed to get collection info: %w", err)
+	}
+	vectors := info.GetConfig().GetParams().GetVectorsConfig()
+	if params := vectors.GetParams(); params != nil {
+		return VectorShape{Size: int(params.GetSize())}, nil
+	}
+	named := make(map[string]int)
+	for vector, params := range vectors.GetParamsMap().GetMap() {
+		named[vector] = int(params.GetSize())
+	}
+	return VectorShape{Named: named}, nil
+}
+
+// Check reports why an exported point doesn't fit the shape, or nil if it does
+func (s VectorShape) Check(p ExportedPoint) error {
+	if s.Named == nil {
+		if len(p.Vectors) > 0 {
+			return fmt.Errorf("has named vectors, collection expects a single vector")
+		}
+		if len(p.Vector) != s.Size {
+			return fmt.Errorf("has %d dimensions, collection expects %d", len(p.Vector), s.Size)
+		}
+		return nil
+	}
+
+	if len(p.Vectors) == 0 {
+		return fmt.Errorf("has a single vector, collection expects named vectors")
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Named)) {
+		v, ok := p.Vectors[name]
+		if !ok {
+			return fmt.Errorf("is missing the %q vector", name)
+		}
+		if len(v) != s.Named[name] {
+			return fmt.Errorf("%q vector has %d dimensions, collection expects %d", name, len(v), s.Named[name])
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.Vectors)) {
+		if _, ok := s.Named[name]; !ok {
+			return fmt.Errorf("has a %q vector the collection doesn't define", name)
+		}
+	}
+	return nil
+}
+
 // CollectionExists checks if a collection exists
 func (c *Client) CollectionExists(ctx context.Context, name string) (bool, error) {
 	return c.qdrant.CollectionExists(ctx, name)
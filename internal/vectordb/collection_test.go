@@ -0,0 +1,48 @@
+package vectordb
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+func TestCollectionStorageOptions(t *testing.T) {
+	c := &Client{}
+	if c.vectorParams().OnDisk != nil || c.quantizationConfig() != nil {
+		t.Error("default client set on-disk or quantization options")
+	}
+
+	c = &Client{onDisk: true, quantization: config.QuantizationConfig{Scalar: true, Quantile: 0.99, AlwaysRAM: true}}
+	if !c.vectorParams().GetOnDisk() {
+		t.Error("vectorParams().OnDisk = false, want true")
+	}
+	scalar := c.quantizationConfig().GetScalar()
+	if scalar.GetType() != qdrant.QuantizationType_Int8 || scalar.GetQuantile() != 0.99 || !scalar.GetAlwaysRam() {
+		t.Errorf("quantizationConfig() = %v, want int8 with quantile 0.99 kept in RAM", scalar)
+	}
+}
+
+func TestCollectionName(t *testing.T) {
+	tests := []struct {
+		name   string
+		scope  string
+		prefix string
+		want   string
+	}{
+		{"default scope", "", "", "acme_issues"},
+		{"org scope", CollectionScopeOrg, "", "acme_issues"},
+		{"repo scope", CollectionScopeRepo, "", "acme_api_issues"},
+		{"org scope with prefix", CollectionScopeOrg, "prod", "prod_acme_issues"},
+		{"repo scope with prefix", CollectionScopeRepo, "staging", "staging_acme_api_issues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.QdrantConfig{CollectionScope: tt.scope, CollectionPrefix: tt.prefix}
+			if got := CollectionName(cfg, "acme", "api"); got != tt.want {
+				t.Errorf("CollectionName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package vectordb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// ExportedPoint is a portable copy of an indexed point
+type ExportedPoint struct {
+	ID      string               `json:"id"`
+	Vector  []float32            `json:"vector"`
+	Vectors map[string][]float32 `json:"vectors,omitempty"` // Named vectors (multi-vector collections)
+	Payload map[string]any       `json:"payload"`
+}
+
+// Scroll reads every point in a collection, batch points per request
+func (c *Client) Scroll(ctx context.Context, collection string, batch int) ([]ExportedPoint, error) {
+	if batch <= 0 {
+		batch = 256
+	}
+
+	var exported []ExportedPoint
+	var offset *qdrant.PointId
+
+	for {
+		// Fetch one extra point; its ID is the (inclusive) offset of the next page
+		points, err := c.qdrant.Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: collection,
+			Offset:         offset,
+			Limit:          qdrant.PtrOf(uint32(batch + 1)),
+			WithPayload:    qdrant.NewWithPayload(true),
+			WithVectors:    qdrant.NewWithVectors(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scroll failed: %w", err)
+		}
+
+		page := points
+		if len(points) > batch {
+			page = points[:batch]
+		}
+
+		for _, p := range page {
+			point := ExportedPoint{
+				ID:      p.Id.GetUuid(),
+				Vector:  p.Vectors.GetVector().GetData(),
+				Payload: payloadToMap(p.Payload),
+			}
+			if named := p.Vectors.GetVectors().GetVectors(); len(named) > 0 {
+				point.Vectors = make(map[string][]float32, len(named))
+				for name, v := range named {
+					point.Vectors[name] = v.GetData()
+				}
+			}
+			exported = append(exported, point)
+		}
+
+		if len(points) <= batch {
+			break
+		}
+		offset = points[batch].Id
+	}
+
+	return exported, nil
+}
+
+// UpsertExported writes previously exported points back without re-embedding
+func (c *Client) UpsertExported(ctx context.Context, collection string, exported []ExportedPoint) error {
+	points := make([]*qdrant.PointStruct, len(exported))
+	for i, p := range exported {
+		payload, err := qdrant.TryValueMap(normalizePayload(p.Payload))
+		if err != nil {
+			return fmt.Errorf("invalid payload for point %s: %w", p.ID, err)
+		}
+		vectors := qdrant.NewVectors(p.Vector...)
+		if len(p.Vectors) > 0 {
+			named := make(map[string]*qdrant.Vector, len(p.Vectors))
+			for name, v := range p.Vectors {
+				named[name] = qdrant.NewVectorDense(v)
+			}
+			vectors = qdrant.NewVectorsMap(named)
+		}
+		points[i] = &qdrant.PointStruct{
+			Id:      qdrant.NewIDUUID(p.ID),
+			Vectors: vectors,
+			Payload: payload,
+		}
+	}
+
+	return c.upsertPoints(ctx, collection, points)
+}
+
+// normalizePayload converts json.Number values (from a UseNumber decoder)
+// back to int64 or float64 so integer fields stay filterable
+func normalizePayload(payload map[string]any) map[string]any {
+	out := make(map[string]any, len(payload))
+	for k, v := range payload {
+		out[k] = normalizeValue(v)
+	}
+	return out
+}
+
+// normalizeValue converts a single decoded JSON value
+func normalizeValue(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case []any:
+		list := make([]any, len(val))
+		for i, item := range val {
+			list[i] = normalizeValue(item)
+		}
+		return list
+	case map[string]any:
+		return normalizePayload(val)
+	default:
+		return v
+	}
+}
+
+// payloadToMap converts a Qdrant payload to plain Go values
+func payloadToMap(payload map[string]*qdrant.Value) map[string]any {
+	out := make(map[string]any, len(payload))
+	for k, v := range payload {
+		out[k] = valueToAny(v)
+	}
+	return out
+}
+
+// valueToAny converts a single Qdrant value to a plain Go value
+func valueToAny(v *qdrant.Value) any {
+	switch kind := v.GetKind().(type) {
+	case *qdrant.Value_BoolValue:
+		return kind.BoolValue
+	case *qdrant.Value_IntegerValue:
+		return kind.IntegerValue
+	case *qdrant.Value_DoubleValue:
+		return kind.DoubleValue
+	case *qdrant.Value_StringValue:
+		return kind.StringValue
+	case *qdrant.Value_ListValue:
+		list := make([]any, len(kind.ListValue.GetValues()))
+		for i, item := range kind.ListValue.GetValues() {
+			list[i] = valueToAny(item)
+		}
+		return list
+	case *qdrant.Value_StructValue:
+		return payloadToMap(kind.StructValue.GetFields())
+	default:
+		return nil
+	}
+}
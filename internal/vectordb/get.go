@@ -0,0 +1,102 @@
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// StoredIssue is an issue as currently recorded in the index
+type StoredIssue struct {
+	Issue    models.Issue
+	BodyHash string
+}
+
+// GetIssues fetches stored payloads by point ID. IDs that are not
+// indexed are absent from the returned map.
+func (c *Client) GetIssues(ctx context.Context, collection string, ids []string) (map[string]StoredIssue, error) {
+	stored := make(map[string]StoredIssue, len(ids))
+	if len(ids) == 0 {
+		return stored, nil
+	}
+
+	pointIDs := make([]*qdrant.PointId, len(ids))
+	for i, id := range ids {
+		pointIDs[i] = qdrant.NewIDUUID(id)
+	}
+
+	points, err := c.qdrant.Get(ctx, &qdrant.GetPoints{
+		CollectionName: collection,
+		Ids:            pointIDs,
+		WithPayload:    qdrant.NewWithPayload(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get points failed: %w", err)
+	}
+
+	for _, point := range points {
+		var hash string
+		if v := point.Payload["body_hash"]; v != nil {
+			hash = v.GetStringValue()
+		}
+		stored[point.Id.GetUuid()] = StoredIssue{
+			Issue:    payloadToIssue(point.Payload),
+			BodyHash: hash,
+		}
+	}
+
+	return stored, nil
+}
+
+// ScrollIssues reads the stored payload of every issue indexed for org/repo,
+// keyed by point ID. Vectors are not fetched.
+func (c *Client) ScrollIssues(ctx context.Context, collection, org, repo string) (map[string]StoredIssue, error) {
+	const batch = 256
+
+	stored := make(map[string]StoredIssue)
+	filter := &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+		},
+	}
+	var offset *qdrant.PointId
+
+	for {
+		// Fetch one extra point; its ID is the (inclusive) offset of the next page
+		points, err := c.qdrant.Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: collection,
+			Filter:         filter,
+			Offset:         offset,
+			Limit:          qdrant.PtrOf(uint32(batch + 1)),
+			WithPayload:    qdrant.NewWithPayload(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scroll failed: %w", err)
+		}
+
+		page := points
+		if len(points) > batch {
+			page = points[:batch]
+		}
+		for _, point := range page {
+			var hash string
+			if v := point.Payload["body_hash"]; v != nil {
+				hash = v.GetStringValue()
+			}
+			stored[point.Id.GetUuid()] = StoredIssue{
+				Issue:    payloadToIssue(point.Payload),
+				BodyHash: hash,
+			}
+		}
+
+		if len(points) <= batch {
+			break
+		}
+		offset = points[batch].Id
+	}
+
+	return stored, nil
+}
@@ -3,6 +3,7 @@ package vectordb
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 
@@ -14,10 +15,37 @@ import (
 type SearchResult struct {
 	Issue models.Issue
 	Score float64
+	// Separate is set on closed issues returned in their own bucket
+	// after the open results (closed_issue_strategy: separate)
+	Separate bool `json:",omitempty"`
+	// Redacted is set on matches from a private repository shown in a
+	// public one; their title and URL are cleared
+	Redacted bool `json:",omitempty"`
+	// Boost is added to Score when ranking (same_repo_boost); thresholds
+	// and displayed similarity use Score alone
+	Boost float64 `json:"-"`
+}
+
+// RankScore returns the score used to order results, including any boost
+func (r SearchResult) RankScore() float64 {
+	return r.Score + r.Boost
+}
+
+// Closed-issue ranking strategies
+const (
+	ClosedStrategyWeight   = "weight"   // Multiply closed scores by the weight
+	ClosedStrategyDemote   = "demote"   // Keep scores, rank closed after equal-score open issues
+	ClosedStrategySeparate = "separate" // Keep scores, return closed issues after all open ones
+)
+
+// ClosedRanking controls how closed issues are ranked against open ones
+type ClosedRanking struct {
+	Strategy string
+	Weight   float64
 }
 
 // Search finds similar issues in a collection
-func (c *Client) Search(ctx context.Context, collection string, vector []float32, limit int, threshold float64, closedWeight float64) ([]SearchResult, error) {
+func (c *Client) Search(ctx context.Context, collection string, vector []float32, limit int, threshold float64, closed ClosedRanking) ([]SearchResult, error) {
 	scoreThreshold := float32(threshold)
 
 	points, err := c.qdrant.Query(ctx, &qdrant.QueryPoints{
@@ -31,37 +59,11 @@ func (c *Client) Search(ctx context.Context, collection string, vector []float32
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
-	results := make([]SearchResult, 0, len(points))
-	for _, point := range points {
-		issue := payloadToIssue(point.Payload)
-		score := float64(point.Score)
-
-		// Apply closed issue weight adjustment
-		if issue.State == "closed" && closedWeight > 0 {
-			score *= closedWeight
-		}
-
-		results = append(results, SearchResult{
-			Issue: issue,
-			Score: score,
-		})
-	}
-
-	// Re-sort after weight adjustment
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	// Trim to requested limit
-	if len(results) > limit {
-		results = results[:limit]
-	}
-
-	return results, nil
+	return rankResults(points, closed, limit), nil
 }
 
 // SearchFiltered searches with additional filters
-func (c *Client) SearchFiltered(ctx context.Context, collection string, vector []float32, limit int, threshold float64, closedWeight float64, filter *qdrant.Filter) ([]SearchResult, error) {
+func (c *Client) SearchFiltered(ctx context.Context, collection string, vector []float32, limit int, threshold float64, closed ClosedRanking, filter *qdrant.Filter) ([]SearchResult, error) {
 	scoreThreshold := float32(threshold)
 
 	points, err := c.qdrant.Query(ctx, &qdrant.QueryPoints{
@@ -76,13 +78,21 @@ func (c *Client) SearchFiltered(ctx context.Context, collection string, vector [
 		return nil, fmt.Errorf("filtered search failed: %w", err)
 	}
 
+	return rankResults(points, closed, limit), nil
+}
+
+// rankResults converts scored points into results ordered by the closed-issue strategy
+func rankResults(points []*qdrant.ScoredPoint, closed ClosedRanking, limit int) []SearchResult {
 	results := make([]SearchResult, 0, len(points))
 	for _, point := range points {
 		issue := payloadToIssue(point.Payload)
 		score := float64(point.Score)
 
-		if issue.State == "closed" && closedWeight > 0 {
-			score *= closedWeight
+		// Apply closed issue weight adjustment
+		if closed.Strategy == ClosedStrategyWeight || closed.Strategy == "" {
+			if issue.State == "closed" && closed.Weight > 0 {
+				score *= closed.Weight
+			}
 		}
 
 		results = append(results, SearchResult{
@@ -91,15 +101,110 @@ func (c *Client) SearchFiltered(ctx context.Context, collection string, vector [
 		})
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	switch closed.Strategy {
+	case ClosedStrategyDemote:
+		// Scores are compared at displayed (whole percent) precision so a
+		// closed issue only loses ties it would visibly share with an open one
+		sort.SliceStable(results, func(i, j int) bool {
+			si, sj := math.Round(results[i].Score*100), math.Round(results[j].Score*100)
+			if si != sj {
+				return si > sj
+			}
+			return results[i].Issue.State != "closed" && results[j].Issue.State == "closed"
+		})
+	case ClosedStrategySeparate:
+		var open, resolved []SearchResult
+		for _, r := range results {
+			if r.Issue.State == "closed" {
+				r.Separate = true
+				resolved = append(resolved, r)
+			} else {
+				open = append(open, r)
+			}
+		}
+		sortByScore(open)
+		sortByScore(resolved)
+		results = append(open, resolved...)
+	default:
+		sortByScore(results)
+	}
+
+	return TrimResults(results, limit)
+}
+
+// TrimResults limits results to n. When the separate closed bucket is
+// present, half the slots are reserved for it so open matches alone can't
+// push a strong closed match out; slots either group leaves unused go to
+// the other, and open matches stay first.
+func TrimResults(results []SearchResult, n int) []SearchResult {
+	n = min(max(n, 0), len(results))
 
-	if len(results) > limit {
-		results = results[:limit]
+	var open, closed []SearchResult
+	for _, r := range results {
+		if r.Separate {
+			closed = append(closed, r)
+		} else {
+			open = append(open, r)
+		}
 	}
 
-	return results, nil
+	keepOpen := min(len(open), n-min(len(closed), n/2))
+	keepClosed := n - keepOpen
+
+	trimmed := make([]SearchResult, 0, n)
+	trimmed = append(trimmed, open[:keepOpen]...)
+	return append(trimmed, closed[:keepClosed]...)
+}
+
+// AtLeast returns the results scoring at least threshold, in order
+func AtLeast(results []SearchResult, threshold float64) []SearchResult {
+	kept := make([]SearchResult, 0, len(results))
+	for _, r := range results {
+		if r.Score >= threshold {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// Display orders for the similar-issues table
+const (
+	SortScore     = "score"      // Highest similarity first
+	SortOpenFirst = "open-first" // Open issues first, each group by score
+	SortRecent    = "recent"     // Newest first
+)
+
+// SortForDisplay returns results reordered for presentation. Matches in the
+// separate closed bucket stay after the others whatever the order.
+func SortForDisplay(results []SearchResult, order string) []SearchResult {
+	sorted := make([]SearchResult, len(results))
+	copy(sorted, results)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Separate != b.Separate {
+			return !a.Separate
+		}
+		switch order {
+		case SortOpenFirst:
+			if aOpen, bOpen := a.Issue.State != "closed", b.Issue.State != "closed"; aOpen != bOpen {
+				return aOpen
+			}
+		case SortRecent:
+			if !a.Issue.CreatedAt.Equal(b.Issue.CreatedAt) {
+				return a.Issue.CreatedAt.After(b.Issue.CreatedAt)
+			}
+		}
+		return a.RankScore() > b.RankScore()
+	})
+	return sorted
+}
+
+// sortByScore orders results by descending score
+func sortByScore(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
 }
 
 // payloadToIssue converts Qdrant payload to Issue
@@ -127,6 +232,15 @@ func payloadToIssue(payload map[string]*qdrant.Value) models.Issue {
 	if v := payload["url"]; v != nil {
 		issue.URL = v.GetStringValue()
 	}
+	if v := payload["issue_type"]; v != nil {
+		issue.IssueType = v.GetStringValue()
+	}
+	if v := payload["kind"]; v != nil {
+		issue.Kind = v.GetStringValue()
+	}
+	if v := payload["milestone"]; v != nil {
+		issue.Milestone = v.GetStringValue()
+	}
 	if v := payload["created_at"]; v != nil {
 		issue.CreatedAt, _ = time.Parse(time.RFC3339, v.GetStringValue())
 	}
@@ -0,0 +1,139 @@
+package vectordb
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+func TestSortForDisplay(t *testing.T) {
+	now := time.Now()
+	results := []SearchResult{
+		{Issue: models.Issue{Number: 1, State: "closed", CreatedAt: now.Add(-3 * time.Hour)}, Score: 0.95},
+		{Issue: models.Issue{Number: 2, State: "open", CreatedAt: now.Add(-2 * time.Hour)}, Score: 0.90},
+		{Issue: models.Issue{Number: 3, State: "open", CreatedAt: now.Add(-1 * time.Hour)}, Score: 0.85},
+		{Issue: models.Issue{Number: 4, State: "closed", CreatedAt: now}, Score: 0.99, Separate: true},
+	}
+
+	tests := []struct {
+		order string
+		want  []int
+	}{
+		{SortScore, []int{1, 2, 3, 4}},
+		{"", []int{1, 2, 3, 4}},
+		{SortOpenFirst, []int{2, 3, 1, 4}},
+		{SortRecent, []int{3, 2, 1, 4}},
+	}
+
+	for _, tt := range tests {
+		got := SortForDisplay(results, tt.order)
+		for i, n := range tt.want {
+			if got[i].Issue.Number != n {
+				t.Errorf("SortForDisplay(%q)[%d] = #%d, want #%d", tt.order, i, got[i].Issue.Number, n)
+			}
+		}
+	}
+
+	if results[0].Issue.Number != 1 || results[1].Issue.Number != 2 {
+		t.Error("SortForDisplay modified its input")
+	}
+}
+
+func TestTrimResults(t *testing.T) {
+	results := []SearchResult{
+		{Issue: models.Issue{Number: 1}},
+		{Issue: models.Issue{Number: 2}},
+		{Issue: models.Issue{Number: 3, State: "closed"}, Separate: true},
+		{Issue: models.Issue{Number: 4, State: "closed"}, Separate: true},
+	}
+
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{3, []int{1, 2, 3}},
+		{2, []int{1, 3}},
+		{1, []int{1}},
+		{10, []int{1, 2, 3, 4}},
+		{0, nil},
+	}
+
+	for _, tt := range tests {
+		var got []int
+		for _, r := range TrimResults(results, tt.n) {
+			got = append(got, r.Issue.Number)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("TrimResults(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTrimResults_ReservesClosedBucket(t *testing.T) {
+	var results []SearchResult
+	for i := 1; i <= 6; i++ {
+		results = append(results, SearchResult{Issue: models.Issue{Number: i}, Score: 0.9})
+	}
+	results = append(results, SearchResult{Issue: models.Issue{Number: 7, State: "closed"}, Score: 0.99, Separate: true})
+
+	var got []int
+	for _, r := range TrimResults(results, 5) {
+		got = append(got, r.Issue.Number)
+	}
+	if want := []int{1, 2, 3, 4, 7}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("TrimResults(5) = %v, want %v", got, want)
+	}
+}
+
+func TestRankResults_SeparateRespectsLimit(t *testing.T) {
+	point := func(number int64, state string, score float32) *qdrant.ScoredPoint {
+		return &qdrant.ScoredPoint{Score: score, Payload: map[string]*qdrant.Value{
+			"number": qdrant.NewValueInt(number),
+			"state":  qdrant.NewValueString(state),
+		}}
+	}
+	points := []*qdrant.ScoredPoint{
+		point(1, "closed", 0.99),
+		point(2, "open", 0.90),
+		point(3, "closed", 0.88),
+		point(4, "open", 0.80),
+	}
+
+	got := rankResults(points, ClosedRanking{Strategy: ClosedStrategySeparate}, 3)
+	var order []int
+	for _, r := range got {
+		order = append(order, r.Issue.Number)
+	}
+	if want := []int{2, 4, 1}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("rankResults() = %v, want %v", order, want)
+	}
+	if !got[2].Separate || got[0].Separate {
+		t.Errorf("Separate flags = %v, %v, want only the closed match marked", got[0].Separate, got[2].Separate)
+	}
+}
+
+func TestFuseScores(t *testing.T) {
+	title := []float32{1, 0}
+	body := []float32{0, 1}
+
+	if got := cosine(title, title); math.Abs(got-1) > 1e-9 {
+		t.Errorf("cosine(same) = %v, want 1", got)
+	}
+	if got := cosine(title, body); got != 0 {
+		t.Errorf("cosine(orthogonal) = %v, want 0", got)
+	}
+	if got := cosine(title, []float32{1}); got != 0 {
+		t.Errorf("cosine(mismatched) = %v, want 0", got)
+	}
+
+	if got := fuseScores(ViewWeights{Title: 0.25, Body: 0.75}, 1, 0.6); math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("fuseScores() = %v, want 0.7", got)
+	}
+	if got := fuseScores(ViewWeights{}, 1, 0.5); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("fuseScores(zero weights) = %v, want 0.75", got)
+	}
+}
@@ -3,6 +3,8 @@ package vectordb
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Kavirubc/gh-simili/pkg/models"
@@ -11,7 +13,7 @@ import (
 
 // Upsert inserts or updates a single issue vector
 func (c *Client) Upsert(ctx context.Context, collection string, issue *models.Issue, vector []float32) error {
-	point := issueToPoint(issue, vector)
+	point := c.issueToPoint(issue, vector)
 
 	_, err := c.qdrant.Upsert(ctx, &qdrant.UpsertPoints{
 		CollectionName: collection,
@@ -31,9 +33,14 @@ func (c *Client) UpsertBatch(ctx context.Context, collection string, issues []*m
 
 	points := make([]*qdrant.PointStruct, len(issues))
 	for i, issue := range issues {
-		points[i] = issueToPoint(issue, vectors[i])
+		points[i] = c.issueToPoint(issue, vectors[i])
 	}
 
+	return c.upsertPoints(ctx, collection, points)
+}
+
+// upsertPoints writes prepared points in a single request
+func (c *Client) upsertPoints(ctx context.Context, collection string, points []*qdrant.PointStruct) error {
 	_, err := c.qdrant.Upsert(ctx, &qdrant.UpsertPoints{
 		CollectionName: collection,
 		Points:         points,
@@ -86,30 +93,73 @@ func (c *Client) DeleteBatch(ctx context.Context, collection string, ids []strin
 }
 
 // issueToPoint converts an Issue to a Qdrant point
-func issueToPoint(issue *models.Issue, vector []float32) *qdrant.PointStruct {
-	labelValues := make([]*qdrant.Value, len(issue.Labels))
-	for i, label := range issue.Labels {
-		labelValues[i] = qdrant.NewValueString(label)
-	}
-
+func (c *Client) issueToPoint(issue *models.Issue, vector []float32) *qdrant.PointStruct {
 	return &qdrant.PointStruct{
 		Id:      qdrant.NewIDUUID(issue.UUID()),
 		Vectors: qdrant.NewVectors(vector...),
-		Payload: map[string]*qdrant.Value{
-			"org":        qdrant.NewValueString(issue.Org),
-			"repo":       qdrant.NewValueString(issue.Repo),
-			"number":     qdrant.NewValueInt(int64(issue.Number)),
-			"title":      qdrant.NewValueString(issue.Title),
-			"state":      qdrant.NewValueString(issue.State),
-			"author":     qdrant.NewValueString(issue.Author),
-			"url":        qdrant.NewValueString(issue.URL),
-			"body_hash":  qdrant.NewValueString(issue.BodyHash()),
-			"created_at": qdrant.NewValueString(issue.CreatedAt.Format(time.RFC3339)),
-			"updated_at": qdrant.NewValueString(issue.UpdatedAt.Format(time.RFC3339)),
-			"labels": &qdrant.Value{
-				Kind: &qdrant.Value_ListValue{
-					ListValue: &qdrant.ListValue{Values: labelValues},
-				},
+		Payload: issuePayload(issue, capLabels(issue.Labels, c.maxLabels, c.priorityLabels)),
+	}
+}
+
+// capLabels bounds labels to max entries, keeping priority labels
+// (case-insensitive) first and then the rest in issue order. The kept labels
+// stay in their original order. max <= 0 keeps every label.
+func capLabels(labels []string, max int, priority []string) []string {
+	if max <= 0 || len(labels) <= max {
+		return labels
+	}
+
+	keep := make([]bool, len(labels))
+	kept := 0
+	isPriority := func(label string) bool {
+		return slices.ContainsFunc(priority, func(p string) bool { return strings.EqualFold(p, label) })
+	}
+	for i, label := range labels {
+		if kept < max && isPriority(label) {
+			keep[i] = true
+			kept++
+		}
+	}
+	for i := range labels {
+		if kept < max && !keep[i] {
+			keep[i] = true
+			kept++
+		}
+	}
+
+	capped := make([]string, 0, max)
+	for i, label := range labels {
+		if keep[i] {
+			capped = append(capped, label)
+		}
+	}
+	return capped
+}
+
+// issuePayload builds the stored payload for an issue with the given labels
+func issuePayload(issue *models.Issue, labels []string) map[string]*qdrant.Value {
+	labelValues := make([]*qdrant.Value, len(labels))
+	for i, label := range labels {
+		labelValues[i] = qdrant.NewValueString(label)
+	}
+
+	return map[string]*qdrant.Value{
+		"org":        qdrant.NewValueString(issue.Org),
+		"repo":       qdrant.NewValueString(issue.Repo),
+		"number":     qdrant.NewValueInt(int64(issue.Number)),
+		"title":      qdrant.NewValueString(issue.Title),
+		"state":      qdrant.NewValueString(issue.State),
+		"author":     qdrant.NewValueString(issue.Author),
+		"url":        qdrant.NewValueString(issue.URL),
+		"body_hash":  qdrant.NewValueString(issue.BodyHash()),
+		"issue_type": qdrant.NewValueString(issue.IssueType),
+		"kind":       qdrant.NewValueString(issue.Kind),
+		"milestone":  qdrant.NewValueString(issue.Milestone),
+		"created_at": qdrant.NewValueString(issue.CreatedAt.Format(time.RFC3339)),
+		"updated_at": qdrant.NewValueString(issue.UpdatedAt.Format(time.RFC3339)),
+		"labels": &qdrant.Value{
+			Kind: &qdrant.Value_ListValue{
+				ListValue: &qdrant.ListValue{Values: labelValues},
 			},
 		},
 	}
@@ -0,0 +1,31 @@
+package vectordb
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCapLabels(t *testing.T) {
+	labels := []string{"a", "b", "Area/Net", "c", "kind/bug"}
+
+	tests := []struct {
+		name     string
+		max      int
+		priority []string
+		want     []string
+	}{
+		{name: "no cap", max: 0, want: labels},
+		{name: "under cap", max: 10, want: labels},
+		{name: "first N", max: 2, want: []string{"a", "b"}},
+		{name: "priority kept in issue order", max: 3, priority: []string{"kind/bug", "area/net"}, want: []string{"a", "Area/Net", "kind/bug"}},
+		{name: "priority beyond cap", max: 1, priority: []string{"kind/bug", "area/net"}, want: []string{"Area/Net"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := capLabels(labels, tt.max, tt.priority); !slices.Equal(got, tt.want) {
+				t.Errorf("capLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
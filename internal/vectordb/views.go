@@ -0,0 +1,149 @@
+package vectordb
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// Named vectors stored per issue when multi-vector mode is enabled
+const (
+	VectorTitle = "title"
+	VectorBody  = "body"
+)
+
+// Views holds the separately embedded title and body of an issue
+type Views struct {
+	Title []float32
+	Body  []float32
+}
+
+// ViewWeights controls how title and body scores are combined at search time
+type ViewWeights struct {
+	Title float64
+	Body  float64
+}
+
+// SetMultiVector switches the client to named title/body vectors fused with
+// the configured weights, or back to a single vector when disabled.
+// Collections created before the switch must be reindexed.
+func (c *Client) SetMultiVector(cfg *config.MultiVectorConfig) {
+	if !cfg.Enabled {
+		c.views = nil
+		return
+	}
+	c.views = &ViewWeights{Title: cfg.TitleWeight, Body: cfg.BodyWeight}
+}
+
+// MultiVector reports whether the client stores named title/body vectors
+func (c *Client) MultiVector() bool {
+	return c != nil && c.views != nil
+}
+
+// UpsertViewsBatch inserts or updates issues with separate title and body vectors
+func (c *Client) UpsertViewsBatch(ctx context.Context, collection string, issues []*models.Issue, views []Views) error {
+	if len(issues) != len(views) {
+		return fmt.Errorf("issues and views length mismatch")
+	}
+
+	points := make([]*qdrant.PointStruct, len(issues))
+	for i, issue := range issues {
+		points[i] = &qdrant.PointStruct{
+			Id: qdrant.NewIDUUID(issue.UUID()),
+			Vectors: qdrant.NewVectorsMap(map[string]*qdrant.Vector{
+				VectorTitle: qdrant.NewVectorDense(views[i].Title),
+				VectorBody:  qdrant.NewVectorDense(views[i].Body),
+			}),
+			Payload: issuePayload(issue, capLabels(issue.Labels, c.maxLabels, c.priorityLabels)),
+		}
+	}
+
+	return c.upsertPoints(ctx, collection, points)
+}
+
+// SearchViews queries the title and body vectors separately and ranks the
+// union of candidates by the weighted sum of both cosine similarities
+func (c *Client) SearchViews(ctx context.Context, collection string, query Views, limit int, threshold float64, closed ClosedRanking, filter *qdrant.Filter) ([]SearchResult, error) {
+	weights := ViewWeights{Title: 0.5, Body: 0.5}
+	if c.views != nil {
+		weights = *c.views
+	}
+
+	candidates := make(map[string]*qdrant.ScoredPoint)
+	var order []string
+	for _, view := range []struct {
+		name   string
+		vector []float32
+	}{
+		{VectorTitle, query.Title},
+		{VectorBody, query.Body},
+	} {
+		// No score threshold here: a weak title match can still fuse above it
+		points, err := c.qdrant.Query(ctx, &qdrant.QueryPoints{
+			CollectionName: collection,
+			Query:          qdrant.NewQuery(view.vector...),
+			Using:          qdrant.PtrOf(view.name),
+			Limit:          qdrant.PtrOf(uint64(limit * 2)),
+			WithPayload:    qdrant.NewWithPayload(true),
+			WithVectors:    qdrant.NewWithVectors(true),
+			Filter:         filter,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s search failed: %w", view.name, err)
+		}
+		for _, p := range points {
+			id := p.GetId().GetUuid()
+			if _, ok := candidates[id]; !ok {
+				candidates[id] = p
+				order = append(order, id)
+			}
+		}
+	}
+
+	fused := make([]*qdrant.ScoredPoint, 0, len(candidates))
+	for _, id := range order {
+		p := candidates[id]
+		stored := p.GetVectors().GetVectors().GetVectors()
+		score := fuseScores(weights,
+			cosine(query.Title, stored[VectorTitle].GetData()),
+			cosine(query.Body, stored[VectorBody].GetData()))
+		if score < threshold {
+			continue
+		}
+		p.Score = float32(score)
+		fused = append(fused, p)
+	}
+
+	return rankResults(fused, closed, limit), nil
+}
+
+// fuseScores combines per-view similarities into a weighted average
+func fuseScores(weights ViewWeights, title, body float64) float64 {
+	total := weights.Title + weights.Body
+	if total <= 0 {
+		return (title + body) / 2
+	}
+	return (weights.Title*title + weights.Body*body) / total
+}
+
+// cosine returns the cosine similarity of two vectors, or 0 when either is
+// empty or their lengths differ
+func cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -11,8 +11,8 @@ type Config struct {
 
 // QdrantConfig contains Qdrant connection settings
 type QdrantConfig struct {
-	URL    string `yaml:"url"`
-	APIKey string `yaml:"api_key"`
+	URL     string `yaml:"url"`
+	APIKey  string `yaml:"api_key"`
 	UseGRPC bool   `yaml:"use_grpc"`
 }
 
@@ -4,11 +4,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// KindDiscussion marks indexed discussions; issues leave Kind empty
+const KindDiscussion = "discussion"
+
 // Issue represents a GitHub issue with its metadata
 type Issue struct {
 	Org       string    `json:"org"`
@@ -20,6 +24,9 @@ type Issue struct {
 	Labels    []string  `json:"labels"`
 	Author    string    `json:"author"`
 	URL       string    `json:"url"`
+	IssueType string    `json:"issue_type,omitempty"` // GitHub native issue type (e.g. "Bug")
+	Kind      string    `json:"kind,omitempty"`       // KindDiscussion for indexed discussions
+	Milestone string    `json:"milestone,omitempty"`  // Milestone title, empty when unset
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -29,6 +36,21 @@ func (i *Issue) FullRepo() string {
 	return fmt.Sprintf("%s/%s", i.Org, i.Repo)
 }
 
+// IsDiscussion reports whether the item is a GitHub Discussion rather than an issue
+func (i *Issue) IsDiscussion() bool {
+	return i.Kind == KindDiscussion
+}
+
+// HasLabel reports whether the issue carries the label, ignoring case
+func (i *Issue) HasLabel(name string) bool {
+	for _, l := range i.Labels {
+		if strings.EqualFold(l, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // UUID generates a deterministic UUID based on org/repo#number
 func (i *Issue) UUID() string {
 	return IssueUUID(i.Org, i.Repo, i.Number)
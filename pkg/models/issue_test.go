@@ -67,3 +67,14 @@ func TestIssue_BodyHash(t *testing.T) {
 		t.Errorf("Different body produced same hash")
 	}
 }
+
+func TestIssue_HasLabel(t *testing.T) {
+	issue := &Issue{Labels: []string{"bug", "No-Bot"}}
+
+	if !issue.HasLabel("no-bot") {
+		t.Error("HasLabel(\"no-bot\") = false, want true")
+	}
+	if issue.HasLabel("enhancement") {
+		t.Error("HasLabel(\"enhancement\") = true, want false")
+	}
+}